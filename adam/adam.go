@@ -0,0 +1,164 @@
+package adam
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math/rand/v2"
+	"slices"
+
+	"github.com/joaovictorjs/adam-script/ast"
+	"github.com/joaovictorjs/adam-script/compiler"
+	"github.com/joaovictorjs/adam-script/diagnostic"
+	"github.com/joaovictorjs/adam-script/parser"
+	"github.com/joaovictorjs/adam-script/resolver"
+	"github.com/joaovictorjs/adam-script/sandbox"
+	"github.com/joaovictorjs/adam-script/stdlib"
+	"github.com/joaovictorjs/adam-script/types"
+	"github.com/joaovictorjs/adam-script/value"
+	"github.com/joaovictorjs/adam-script/vm"
+)
+
+type Options struct {
+	Strict bool
+
+	// MaxSteps, MaxMemory and MaxCallDepth bound every Eval and Call, zero
+	// leaves steps and memory unlimited and uses the default call depth.
+	MaxSteps     int
+	MaxMemory    int
+	MaxCallDepth int
+
+	// Permissions guards the built-in modules, the zero value denies all
+	// file and environment access.
+	Permissions Permissions
+
+	// FS is the file system behind the fs module, such as os.DirFS,
+	// fstest.MapFS or a WritableFS from DirFS. Nil denies every file access.
+	FS fs.FS
+
+	// Random seeds math.random, nil picks a random seed.
+	Random rand.Source
+}
+
+type (
+	InterruptedError = vm.InterruptedError
+	StepLimitError   = vm.StepLimitError
+	MemoryLimitError = vm.MemoryLimitError
+	CallDepthError   = vm.CallDepthError
+
+	Permissions     = sandbox.Permissions
+	Grant           = sandbox.Grant
+	PermissionError = sandbox.PermissionError
+
+	WritableFS = stdlib.WritableFS
+	FileError  = stdlib.FileError
+)
+
+// DirFS opens dir for the fs module with write access, scripts cannot leave
+// it even through symbolic links.
+func DirFS(dir string) (WritableFS, error) {
+	return stdlib.DirFS(dir)
+}
+
+type VM struct {
+	options Options
+	machine *vm.VM
+}
+
+func New(options Options) *VM {
+	limits := []vm.Option{vm.WithMaxSteps(options.MaxSteps), vm.WithMaxMemory(options.MaxMemory)}
+	if options.MaxCallDepth > 0 {
+		limits = append(limits, vm.WithMaxCallDepth(options.MaxCallDepth))
+	}
+	machine := vm.New(limits...)
+	stdlib.Install(machine, stdlib.Options{Permissions: options.Permissions, FS: options.FS, Random: options.Random})
+	return &VM{options: options, machine: machine}
+}
+
+func (m *VM) Eval(ctx context.Context, source string) (any, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, &InterruptedError{Cause: err}
+	}
+
+	program, err := parser.NewParser(source).Parse()
+	if err != nil {
+		return nil, err
+	}
+
+	if m.options.Strict {
+		if err := m.check(program, source); err != nil {
+			return nil, err
+		}
+	}
+
+	function, err := compiler.Compile(program)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := m.machine.Run(ctx, function)
+	if err != nil {
+		return nil, err
+	}
+	return fromValue(result)
+}
+
+func (m *VM) Set(name string, v any) error {
+	converted, err := toValue(v)
+	if err != nil {
+		return err
+	}
+	return m.machine.SetGlobal(name, converted)
+}
+
+func (m *VM) Get(name string) (any, error) {
+	v, ok := m.machine.Global(name)
+	if !ok {
+		return nil, fmt.Errorf("Undefined variable '%s'.", name)
+	}
+	return fromValue(v)
+}
+
+func (m *VM) Call(name string, args ...any) (any, error) {
+	return m.CallContext(context.Background(), name, args...)
+}
+
+func (m *VM) CallContext(ctx context.Context, name string, args ...any) (any, error) {
+	callee, ok := m.machine.Global(name)
+	if !ok {
+		return nil, fmt.Errorf("Undefined variable '%s'.", name)
+	}
+
+	arguments := make([]value.Value, 0, len(args))
+	for _, arg := range args {
+		argument, err := toValue(arg)
+		if err != nil {
+			return nil, err
+		}
+		arguments = append(arguments, argument)
+	}
+
+	result, err := m.machine.Call(ctx, callee, arguments...)
+	if err != nil {
+		return nil, err
+	}
+	return fromValue(result)
+}
+
+func (m *VM) check(program ast.Program, source string) error {
+	resolution := resolver.Resolve(program, resolver.WithGlobals(m.machine.Globals()...))
+	diagnostics := append(resolution.Diagnostics, types.Check(program, resolution).Diagnostics...)
+	slices.SortStableFunc(diagnostics, func(a, b diagnostic.Diagnostic) int {
+		return cmp.Compare(a.Span.Start, b.Span.Start)
+	})
+
+	var errs []error
+	for _, d := range diagnostics {
+		if d.Severity == diagnostic.Error {
+			errs = append(errs, errors.New(d.Format(source)))
+		}
+	}
+	return errors.Join(errs...)
+}
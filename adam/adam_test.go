@@ -0,0 +1,378 @@
+package adam
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GivenSource_WhenEval_ThenShouldReturnGoValue(t *testing.T) {
+	type TestCase struct {
+		name          string
+		source        string
+		expectedValue any
+	}
+
+	testcases := []TestCase{
+		{
+			name:          "number",
+			source:        "1 + 2 * 3",
+			expectedValue: 7.0,
+		},
+		{
+			name:          "string",
+			source:        `"a" + "b"`,
+			expectedValue: "ab",
+		},
+		{
+			name:          "null",
+			source:        "let x = 1",
+			expectedValue: nil,
+		},
+	}
+
+	for _, test := range testcases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			result, err := New(Options{}).Eval(context.Background(), test.source)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedValue, result)
+		})
+	}
+}
+
+func Test_GivenHostValues_WhenSetAndGet_ThenShouldRoundTrip(t *testing.T) {
+	type TestCase struct {
+		name          string
+		value         any
+		expectedValue any
+	}
+
+	shared := map[string]int{"n": 1}
+
+	testcases := []TestCase{
+		{name: "int", value: 42, expectedValue: 42.0},
+		{name: "unsigned", value: uint8(7), expectedValue: 7.0},
+		{name: "float", value: float32(0.5), expectedValue: 0.5},
+		{name: "bool", value: true, expectedValue: true},
+		{name: "string", value: "rule", expectedValue: "rule"},
+		{name: "nil", value: nil, expectedValue: nil},
+		{name: "nil pointer", value: (*int)(nil), expectedValue: nil},
+		{name: "slice", value: []int{1, 2}, expectedValue: []any{1.0, 2.0}},
+		{name: "array", value: [2]string{"a", "b"}, expectedValue: []any{"a", "b"}},
+		{
+			name:          "nested map",
+			value:         map[string]any{"limit": 10, "tags": []string{"x"}, "enabled": false},
+			expectedValue: map[string]any{"limit": 10.0, "tags": []any{"x"}, "enabled": false},
+		},
+		{
+			name:          "shared map",
+			value:         []map[string]int{shared, shared},
+			expectedValue: []any{map[string]any{"n": 1.0}, map[string]any{"n": 1.0}},
+		},
+	}
+
+	for _, test := range testcases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			m := New(Options{})
+			assert.NoError(t, m.Set("value", test.value))
+
+			result, err := m.Get("value")
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedValue, result)
+		})
+	}
+}
+
+func Test_GivenHostValue_WhenUsedByScript_ThenShouldBeVisible(t *testing.T) {
+	m := New(Options{})
+	assert.NoError(t, m.Set("base", 10))
+
+	_, err := m.Eval(context.Background(), "let total = base * 2; fn add(n) { total = total + n; return total }")
+	assert.NoError(t, err)
+
+	result, err := m.Call("add", 5)
+	assert.NoError(t, err)
+	assert.Equal(t, 25.0, result)
+
+	total, err := m.Get("total")
+	assert.NoError(t, err)
+	assert.Equal(t, 25.0, total)
+}
+
+func Test_GivenInvalidUsage_WhenCalled_ThenShouldReturnCorrectError(t *testing.T) {
+	type TestCase struct {
+		name          string
+		run           func(m *VM) error
+		expectedError error
+	}
+
+	testcases := []TestCase{
+		{
+			name:          "unsupported type",
+			run:           func(m *VM) error { return m.Set("c", make(chan int)) },
+			expectedError: fmt.Errorf("Cannot convert Go value of type 'chan int' to a script value."),
+		},
+		{
+			name:          "unsupported nested type",
+			run:           func(m *VM) error { return m.Set("f", []any{1, func() {}}) },
+			expectedError: fmt.Errorf("Cannot convert Go value of type 'func()' to a script value."),
+		},
+		{
+			name: "cyclic map",
+			run: func(m *VM) error {
+				cyclic := map[string]any{}
+				cyclic["self"] = cyclic
+				return m.Set("m", cyclic)
+			},
+			expectedError: fmt.Errorf("Cannot convert a cyclic Go value of type 'map[string]interface {}' to a script value."),
+		},
+		{
+			name: "cyclic slice",
+			run: func(m *VM) error {
+				cyclic := []any{nil}
+				cyclic[0] = cyclic
+				return m.Set("s", cyclic)
+			},
+			expectedError: fmt.Errorf("Cannot convert a cyclic Go value of type '[]interface {}' to a script value."),
+		},
+		{
+			name: "cyclic pointer",
+			run: func(m *VM) error {
+				var cyclic any
+				cyclic = &cyclic
+				return m.Set("p", cyclic)
+			},
+			expectedError: fmt.Errorf("Cannot convert a cyclic Go value of type '*interface {}' to a script value."),
+		},
+		{
+			name:          "non string map keys",
+			run:           func(m *VM) error { return m.Set("m", map[int]string{}) },
+			expectedError: fmt.Errorf("Cannot convert Go map with key type 'int' to a script value, keys must be strings."),
+		},
+		{
+			name: "constant assignment",
+			run: func(m *VM) error {
+				if _, err := m.Eval(context.Background(), "const limit = 1"); err != nil {
+					return err
+				}
+				return m.Set("limit", 2)
+			},
+			expectedError: fmt.Errorf("Cannot assign to constant 'limit'."),
+		},
+		{
+			name: "function result",
+			run: func(m *VM) error {
+				_, err := m.Eval(context.Background(), "fn f() { return } f")
+				return err
+			},
+			expectedError: fmt.Errorf("Cannot convert script value of type 'function' to a Go value."),
+		},
+		{
+			name: "undefined variable",
+			run: func(m *VM) error {
+				_, err := m.Get("missing")
+				return err
+			},
+			expectedError: fmt.Errorf("Undefined variable 'missing'."),
+		},
+		{
+			name: "calling a non function",
+			run: func(m *VM) error {
+				if err := m.Set("n", 1); err != nil {
+					return err
+				}
+				_, err := m.Call("n")
+				return err
+			},
+			expectedError: fmt.Errorf("Value of type 'number' is not callable."),
+		},
+		{
+			name: "runtime error",
+			run: func(m *VM) error {
+				_, err := m.Eval(context.Background(), `"a" - 1`)
+				return err
+			},
+			expectedError: fmt.Errorf("Invalid operands 'string' and 'number' for operator '-'."),
+		},
+	}
+
+	for _, test := range testcases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, test.expectedError, test.run(New(Options{})))
+		})
+	}
+}
+
+func Test_GivenStrictOptions_WhenEval_ThenShouldReportDiagnostics(t *testing.T) {
+	m := New(Options{Strict: true})
+	assert.NoError(t, m.Set("limit", 5))
+
+	result, err := m.Eval(context.Background(), "limit + 1")
+	assert.NoError(t, err)
+	assert.Equal(t, 6.0, result)
+
+	_, err = m.Eval(context.Background(), "let x: number = \"a\";\nlimt + 1")
+	assert.EqualError(t, err, "1:17: error[type-mismatch]: Cannot assign 'string' to variable 'x' of type 'number'.\n2:1: error[undefined-variable]: Undefined variable 'limt'. Did you mean 'limit'?")
+
+	_, err = m.Get("x")
+	assert.EqualError(t, err, "Undefined variable 'x'.")
+}
+
+func Test_GivenCanceledContext_WhenEval_ThenShouldNotRun(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	m := New(Options{})
+	_, err := m.Eval(ctx, "let x = 1")
+	assert.ErrorIs(t, err, context.Canceled)
+
+	_, err = m.Get("x")
+	assert.EqualError(t, err, "Undefined variable 'x'.")
+}
+
+func Test_GivenHostMap_WhenScriptAccessesMembers_ThenShouldReadAndWriteEntries(t *testing.T) {
+	m := New(Options{})
+	assert.NoError(t, m.Set("config", map[string]any{"limit": 3}))
+
+	result, err := m.Eval(context.Background(), "config.limit = config.limit * 2; config.limit")
+	assert.NoError(t, err)
+	assert.Equal(t, 6.0, result)
+
+	_, err = m.Eval(context.Background(), "config.missing")
+	assert.EqualError(t, err, "Value of type 'map' has no member 'missing'.")
+}
+
+func Test_GivenLimits_WhenExceeded_ThenShouldReturnDistinctErrors(t *testing.T) {
+	type TestCase struct {
+		name          string
+		options       Options
+		source        string
+		expectedError error
+	}
+
+	testcases := []TestCase{
+		{
+			name:          "step limit",
+			options:       Options{MaxSteps: 50},
+			source:        "fn f() { return f() } f()",
+			expectedError: &StepLimitError{Limit: 50},
+		},
+		{
+			name:          "call depth",
+			options:       Options{MaxCallDepth: 8},
+			source:        "fn f() { return f() } f()",
+			expectedError: &CallDepthError{Limit: 8},
+		},
+		{
+			name:          "memory limit",
+			options:       Options{MaxMemory: 16},
+			source:        `let s = "abcdefgh"; s = s + s; s + s`,
+			expectedError: &MemoryLimitError{Limit: 16},
+		},
+	}
+
+	for _, test := range testcases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := New(test.options).Eval(context.Background(), test.source)
+			assert.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_GivenDeadline_WhenCallContext_ThenShouldReturnInterruptedError(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	m := New(Options{MaxCallDepth: 1 << 20})
+	assert.NoError(t, m.RegisterFunc("stop", cancel))
+
+	_, err := m.Eval(context.Background(), "fn f() { return f() } fn run() { stop(); return f() }")
+	assert.NoError(t, err)
+
+	_, err = m.CallContext(ctx, "run")
+
+	var interrupted *InterruptedError
+	assert.ErrorAs(t, err, &interrupted)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	var steps *StepLimitError
+	assert.False(t, errors.As(err, &steps))
+}
+
+func Test_GivenPermissions_WhenEval_ThenShouldGuardBuiltins(t *testing.T) {
+	t.Setenv("ADAM_TEST_VARIABLE", "value")
+
+	denied := New(Options{})
+	_, err := denied.Eval(context.Background(), `env.get("ADAM_TEST_VARIABLE")`)
+
+	var permission *PermissionError
+	assert.ErrorAs(t, err, &permission)
+	assert.Equal(t, &PermissionError{Capability: "env", Target: "ADAM_TEST_VARIABLE"}, permission)
+
+	result, err := denied.Eval(context.Background(), `try { env.get("ADAM_TEST_VARIABLE") } catch (e) { e.capability }`)
+	assert.NoError(t, err)
+	assert.Equal(t, "env", result)
+
+	allowed := New(Options{Strict: true, Permissions: Permissions{Env: Grant{Targets: []string{"ADAM_TEST_VARIABLE"}}}})
+	result, err = allowed.Eval(context.Background(), `env.get("ADAM_TEST_VARIABLE")`)
+	assert.NoError(t, err)
+	assert.Equal(t, "value", result)
+}
+
+func Test_GivenRandomSource_WhenEval_ThenShouldBeDeterministic(t *testing.T) {
+	first, err := New(Options{Random: rand.NewPCG(1, 2)}).Eval(context.Background(), "math.random()")
+	assert.NoError(t, err)
+
+	second, err := New(Options{Random: rand.NewPCG(1, 2)}).Eval(context.Background(), "math.random()")
+	assert.NoError(t, err)
+	assert.Equal(t, first, second)
+}
+
+func Test_GivenFS_WhenEval_ThenShouldReadThroughIt(t *testing.T) {
+	files := fstest.MapFS{"data/rules.json": {Data: []byte(`{"limit": 3}`)}}
+	m := New(Options{FS: files, Permissions: Permissions{Read: Grant{Targets: []string{"./data"}}}})
+
+	result, err := m.Eval(context.Background(), `json.parse(fs.readFile("data/rules.json")).limit`)
+	assert.NoError(t, err)
+	assert.Equal(t, float64(3), result)
+
+	_, err = m.Eval(context.Background(), `fs.readFile("data/missing.json")`)
+	var fileError *FileError
+	assert.ErrorAs(t, err, &fileError)
+	assert.Equal(t, "data/missing.json", fileError.Path)
+}
+
+func Test_GivenDirFS_WhenEval_ThenShouldWriteIntoDirectory(t *testing.T) {
+	dir := t.TempDir()
+	fsys, err := DirFS(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := New(Options{FS: fsys, Permissions: Permissions{Write: Grant{All: true}}})
+	_, err = m.Eval(context.Background(), `fs.mkdir("out"); fs.writeFile("out/result.txt", "done");`)
+	assert.NoError(t, err)
+
+	data, err := os.ReadFile(filepath.Join(dir, "out", "result.txt"))
+	assert.NoError(t, err)
+	assert.Equal(t, "done", string(data))
+}
+
+func Test_GivenCyclicMap_WhenEval_ThenShouldReturnConversionError(t *testing.T) {
+	m := New(Options{})
+	_, err := m.Eval(context.Background(), "let o = {a: 1}; o.self = o; o")
+	assert.EqualError(t, err, "Cannot convert a cyclic script value of type 'map' to a Go value.")
+
+	result, err := m.Eval(context.Background(), "let s = {n: 1}; ({x: s, y: s})")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"x": map[string]any{"n": 1.0}, "y": map[string]any{"n": 1.0}}, result)
+}
@@ -0,0 +1,172 @@
+package adam
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/joaovictorjs/adam-script/value"
+)
+
+type readOnlyView struct {
+	Value any
+}
+
+func ReadOnly(v any) any {
+	return readOnlyView{Value: v}
+}
+
+var readOnlyViewType = reflect.TypeFor[readOnlyView]()
+
+type fieldBinding struct {
+	index    []int
+	readOnly bool
+}
+
+type methodBinding struct {
+	name      string
+	pointer   bool
+	signature *signature
+}
+
+type structBinding struct {
+	fields  map[string]fieldBinding
+	methods map[string]methodBinding
+}
+
+var bindings sync.Map
+
+func bindingFor(t reflect.Type) *structBinding {
+	if cached, ok := bindings.Load(t); ok {
+		return cached.(*structBinding)
+	}
+
+	binding := &structBinding{fields: map[string]fieldBinding{}, methods: map[string]methodBinding{}}
+	for _, field := range reflect.VisibleFields(t) {
+		if !field.IsExported() || !reachable(t, field.Index) {
+			continue
+		}
+
+		name, options, _ := strings.Cut(field.Tag.Get("adam"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		if _, ok := binding.fields[name]; !ok {
+			binding.fields[name] = fieldBinding{index: field.Index, readOnly: options == "readonly"}
+		}
+	}
+
+	pointer := reflect.PointerTo(t)
+	for i := range pointer.NumMethod() {
+		method := pointer.Method(i)
+		if _, ok := binding.fields[method.Name]; ok {
+			continue
+		}
+
+		in := make([]reflect.Type, 0, method.Type.NumIn()-1)
+		for j := 1; j < method.Type.NumIn(); j++ {
+			in = append(in, method.Type.In(j))
+		}
+		out := make([]reflect.Type, 0, method.Type.NumOut())
+		for j := range method.Type.NumOut() {
+			out = append(out, method.Type.Out(j))
+		}
+
+		s, err := newSignature(t.Name()+"."+method.Name, reflect.FuncOf(in, out, method.Type.IsVariadic()))
+		if err != nil {
+			continue
+		}
+
+		_, byValue := t.MethodByName(method.Name)
+		binding.methods[method.Name] = methodBinding{name: method.Name, pointer: !byValue, signature: s}
+	}
+
+	cached, _ := bindings.LoadOrStore(t, binding)
+	return cached.(*structBinding)
+}
+
+func reachable(t reflect.Type, index []int) bool {
+	for i := 1; i < len(index); i++ {
+		if !t.FieldByIndex(index[:i]).IsExported() {
+			return false
+		}
+	}
+	return true
+}
+
+type object struct {
+	value    reflect.Value
+	binding  *structBinding
+	readOnly bool
+}
+
+func newObject(rv reflect.Value, readOnly bool) *object {
+	return &object{value: rv, binding: bindingFor(rv.Type()), readOnly: readOnly}
+}
+
+func (o *object) Type() string {
+	return "object"
+}
+
+func (o *object) String() string {
+	return "<object " + o.value.Type().String() + ">"
+}
+
+func (o *object) Member(name string) (value.Value, error) {
+	if field, ok := o.binding.fields[name]; ok {
+		fv, err := o.value.FieldByIndexErr(field.index)
+		if err != nil {
+			return value.Null{}, nil
+		}
+
+		readOnly := o.readOnly || field.readOnly
+		if fv.Kind() == reflect.Struct {
+			return newObject(fv, readOnly || !fv.CanSet()), nil
+		}
+		return reflectToValue(fv, readOnly)
+	}
+
+	if method, ok := o.binding.methods[name]; ok && (!method.pointer || o.value.CanAddr() && !o.readOnly) {
+		receiver := o.value
+		if method.pointer {
+			receiver = receiver.Addr()
+		}
+		return method.signature.native(receiver.MethodByName(method.name)), nil
+	}
+
+	return nil, fmt.Errorf("Object of type '%s' has no member '%s'.", o.value.Type(), name)
+}
+
+func (o *object) SetMember(name string, v value.Value) error {
+	field, ok := o.binding.fields[name]
+	if !ok {
+		if _, ok := o.binding.methods[name]; ok {
+			return fmt.Errorf("Cannot assign to method '%s' of object of type '%s'.", name, o.value.Type())
+		}
+		return fmt.Errorf("Object of type '%s' has no member '%s'.", o.value.Type(), name)
+	}
+
+	fv, err := o.value.FieldByIndexErr(field.index)
+	if o.readOnly || field.readOnly || err != nil || !fv.CanSet() {
+		return fmt.Errorf("Member '%s' of object of type '%s' is read-only.", name, o.value.Type())
+	}
+
+	converted, err := fromScript(v, fv.Type())
+	if err != nil {
+		return fmt.Errorf("Member '%s' of object of type '%s' must be %s.", name, o.value.Type(), err)
+	}
+	fv.Set(converted)
+	return nil
+}
+
+func (o *object) goValue() any {
+	if o.value.CanAddr() && !o.readOnly {
+		return o.value.Addr().Interface()
+	}
+	return o.value.Interface()
+}
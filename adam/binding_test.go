@@ -0,0 +1,305 @@
+package adam
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/joaovictorjs/adam-script/value"
+	"github.com/stretchr/testify/assert"
+)
+
+type address struct {
+	City string
+}
+
+type Audit struct {
+	CreatedBy string
+}
+
+type user struct {
+	Audit
+	Name     string
+	Email    string `adam:"email"`
+	Password string `adam:"-"`
+	ID       int    `adam:"id,readonly"`
+	Address  address
+	Manager  *user
+	visits   int
+}
+
+func (u user) Greet(greeting string) string {
+	return greeting + ", " + u.Name
+}
+
+func (u *user) Rename(name string) {
+	u.Name = name
+}
+
+func (u *user) Visit() int {
+	u.visits++
+	return u.visits
+}
+
+func (u *user) Unsupported(chan int) {}
+
+type team struct {
+	Lead    any
+	Members []*user
+	ByName  map[string]*user
+}
+
+func newUser() *user {
+	return &user{
+		Audit:    Audit{CreatedBy: "admin"},
+		Name:     "Ada",
+		Email:    "ada@example.com",
+		Password: "secret",
+		ID:       7,
+		Address:  address{City: "London"},
+		Manager:  &user{Name: "Grace"},
+	}
+}
+
+func Test_GivenBoundStruct_WhenScriptReadsMembers_ThenShouldReturnFieldsAndCallMethods(t *testing.T) {
+	type TestCase struct {
+		name          string
+		source        string
+		expectedValue any
+	}
+
+	testcases := []TestCase{
+		{name: "field", source: "u.Name", expectedValue: "Ada"},
+		{name: "renamed field", source: "u.email", expectedValue: "ada@example.com"},
+		{name: "read only field", source: "u.id", expectedValue: 7.0},
+		{name: "promoted field", source: "u.CreatedBy", expectedValue: "admin"},
+		{name: "nested struct", source: "u.Address.City", expectedValue: "London"},
+		{name: "nested pointer", source: "u.Manager.Greet(\"Hi\")", expectedValue: "Hi, Grace"},
+		{name: "nil pointer", source: "u.Manager.Manager", expectedValue: nil},
+		{name: "value receiver method", source: "u.Greet(\"Hello\")", expectedValue: "Hello, Ada"},
+		{name: "pointer receiver method", source: "u.Visit(); u.Visit()", expectedValue: 2.0},
+		{name: "method as value", source: "let greet = u.Greet; greet(\"Hey\")", expectedValue: "Hey, Ada"},
+	}
+
+	for _, test := range testcases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			m := New(Options{})
+			assert.NoError(t, m.Set("u", newUser()))
+
+			result, err := m.Eval(context.Background(), test.source)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedValue, result)
+		})
+	}
+}
+
+func Test_GivenBoundStruct_WhenScriptWritesMembers_ThenShouldUpdateGoValue(t *testing.T) {
+	u := newUser()
+	m := New(Options{})
+	assert.NoError(t, m.Set("u", u))
+
+	_, err := m.Eval(context.Background(), `u.Name = "Grace"; u.Address.City = "Paris"; u.Rename(u.Name + "!")`)
+	assert.NoError(t, err)
+	assert.Equal(t, "Grace!", u.Name)
+	assert.Equal(t, "Paris", u.Address.City)
+
+	result, err := m.Get("u")
+	assert.NoError(t, err)
+	assert.Same(t, u, result)
+}
+
+func Test_GivenBoundStruct_WhenScriptMisusesMembers_ThenShouldReturnCorrectError(t *testing.T) {
+	type TestCase struct {
+		name          string
+		value         any
+		source        string
+		expectedError error
+	}
+
+	testcases := []TestCase{
+		{
+			name:          "hidden field",
+			value:         newUser(),
+			source:        "u.Password",
+			expectedError: fmt.Errorf("Object of type 'adam.user' has no member 'Password'."),
+		},
+		{
+			name:          "unexported field",
+			value:         newUser(),
+			source:        "u.visits",
+			expectedError: fmt.Errorf("Object of type 'adam.user' has no member 'visits'."),
+		},
+		{
+			name:          "method with unsupported signature",
+			value:         newUser(),
+			source:        "u.Unsupported",
+			expectedError: fmt.Errorf("Object of type 'adam.user' has no member 'Unsupported'."),
+		},
+		{
+			name:          "read only field",
+			value:         newUser(),
+			source:        "u.id = 8",
+			expectedError: fmt.Errorf("Member 'id' of object of type 'adam.user' is read-only."),
+		},
+		{
+			name:          "wrong field type",
+			value:         newUser(),
+			source:        "u.Name = 1",
+			expectedError: fmt.Errorf("Member 'Name' of object of type 'adam.user' must be of type 'string' but got 'number'."),
+		},
+		{
+			name:          "assigning a method",
+			value:         newUser(),
+			source:        "u.Greet = 1",
+			expectedError: fmt.Errorf("Cannot assign to method 'Greet' of object of type 'adam.user'."),
+		},
+		{
+			name:          "struct copy is read only",
+			value:         *newUser(),
+			source:        `u.Name = "x"`,
+			expectedError: fmt.Errorf("Member 'Name' of object of type 'adam.user' is read-only."),
+		},
+		{
+			name:          "read only view",
+			value:         ReadOnly(newUser()),
+			source:        `u.Address.City = "x"`,
+			expectedError: fmt.Errorf("Member 'City' of object of type 'adam.address' is read-only."),
+		},
+		{
+			name:          "read only view through interface field",
+			value:         ReadOnly(&team{Lead: newUser()}),
+			source:        `u.Lead.Name = "x"`,
+			expectedError: fmt.Errorf("Member 'Name' of object of type 'adam.user' is read-only."),
+		},
+		{
+			name:          "read only view through map field",
+			value:         ReadOnly(&team{ByName: map[string]*user{"ada": newUser()}}),
+			source:        `u.ByName.ada.Name = "x"`,
+			expectedError: fmt.Errorf("Member 'Name' of object of type 'adam.user' is read-only."),
+		},
+		{
+			name:          "read only view hides mutating methods",
+			value:         ReadOnly(newUser()),
+			source:        "u.Visit()",
+			expectedError: fmt.Errorf("Object of type 'adam.user' has no member 'Visit'."),
+		},
+		{
+			name:          "wrong method argument",
+			value:         newUser(),
+			source:        "u.Greet(1)",
+			expectedError: fmt.Errorf("Function 'user.Greet' expects argument 1 to be of type 'string' but got 'number'."),
+		},
+	}
+
+	for _, test := range testcases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			m := New(Options{})
+			assert.NoError(t, m.Set("u", test.value))
+
+			_, err := m.Eval(context.Background(), test.source)
+			assert.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_GivenBoundStruct_WhenPassedToRegisteredFunc_ThenShouldReceiveGoValue(t *testing.T) {
+	u := newUser()
+	m := New(Options{})
+	assert.NoError(t, m.Set("u", u))
+	assert.NoError(t, m.Set("view", ReadOnly(u)))
+	assert.NoError(t, m.RegisterFunc("promote", func(target *user) string {
+		target.ID++
+		return target.Name
+	}))
+
+	result, err := m.Eval(context.Background(), "promote(u) + promote(view)")
+	assert.NoError(t, err)
+	assert.Equal(t, "AdaAda", result)
+	assert.Equal(t, 8, u.ID)
+}
+
+func Test_GivenStructType_WhenBoundTwice_ThenShouldReuseCachedMetadata(t *testing.T) {
+	first := bindingFor(reflect.TypeFor[user]())
+	second := bindingFor(reflect.TypeFor[user]())
+
+	assert.Same(t, first, second)
+	assert.Contains(t, first.fields, "email")
+	assert.NotContains(t, first.fields, "Password")
+	assert.True(t, first.fields["id"].readOnly)
+	assert.True(t, first.methods["Visit"].pointer)
+	assert.False(t, first.methods["Greet"].pointer)
+}
+
+func Test_GivenReadOnlyView_WhenNestedObjectsAreWritten_ThenShouldReturnReadOnlyError(t *testing.T) {
+	type TestCase struct {
+		name   string
+		team   func(lead *user) *team
+		nested func(t *testing.T, o *object) value.Value
+	}
+
+	member := func(t *testing.T, v value.Value, name string) value.Value {
+		t.Helper()
+		o, ok := v.(*object)
+		if !ok {
+			t.Fatalf("Expected an object but got '%s'.", v.Type())
+		}
+		result, err := o.Member(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return result
+	}
+
+	testcases := []TestCase{
+		{
+			name: "interface field",
+			team: func(lead *user) *team { return &team{Lead: lead} },
+			nested: func(t *testing.T, o *object) value.Value {
+				return member(t, o, "Lead")
+			},
+		},
+		{
+			name: "slice field",
+			team: func(lead *user) *team { return &team{Members: []*user{lead}} },
+			nested: func(t *testing.T, o *object) value.Value {
+				return member(t, o, "Members").(value.Array)[0]
+			},
+		},
+		{
+			name: "map field",
+			team: func(lead *user) *team { return &team{ByName: map[string]*user{"ada": lead}} },
+			nested: func(t *testing.T, o *object) value.Value {
+				return member(t, o, "ByName").(value.Map)["ada"]
+			},
+		},
+		{
+			name: "slice behind interface field",
+			team: func(lead *user) *team { return &team{Lead: []*user{lead}} },
+			nested: func(t *testing.T, o *object) value.Value {
+				return member(t, o, "Lead").(value.Array)[0]
+			},
+		},
+	}
+
+	for _, test := range testcases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			lead := newUser()
+			converted, err := toValue(ReadOnly(test.team(lead)))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			nested, ok := test.nested(t, converted.(*object)).(*object)
+			if !ok {
+				t.Fatal("Expected the nested value to be an object.")
+			}
+			err = nested.SetMember("Name", value.String("x"))
+			assert.Equal(t, fmt.Errorf("Member 'Name' of object of type 'adam.user' is read-only."), err)
+			assert.Equal(t, "Ada", lead.Name)
+		})
+	}
+}
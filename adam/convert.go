@@ -0,0 +1,167 @@
+package adam
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/joaovictorjs/adam-script/value"
+)
+
+var scriptValueType = reflect.TypeFor[value.Value]()
+
+func toValue(v any) (value.Value, error) {
+	if v == nil {
+		return value.Null{}, nil
+	}
+	return reflectToValue(reflect.ValueOf(v), false)
+}
+
+// reflectToValue converts rv into a script value, structs reached through it
+// become objects that are read-only when readOnly is set.
+func reflectToValue(rv reflect.Value, readOnly bool) (value.Value, error) {
+	return convertReflected(rv, readOnly, map[reference]bool{})
+}
+
+// reference identifies the Go map, pointer or slice behind a reflected value,
+// the type and length tell apart slices sharing their first element.
+type reference struct {
+	pointer uintptr
+	typ     reflect.Type
+	length  int
+}
+
+// convertReflected tracks the references on the current path in active,
+// script values are copied so a Go value that holds itself has no end.
+func convertReflected(rv reflect.Value, readOnly bool, active map[reference]bool) (value.Value, error) {
+	if rv.Type() == readOnlyViewType {
+		return convertReflected(rv.Field(0), true, active)
+	}
+
+	if ref, ok := referenceOf(rv); ok {
+		if active[ref] {
+			return nil, fmt.Errorf("Cannot convert a cyclic Go value of type '%s' to a script value.", rv.Type())
+		}
+		active[ref] = true
+		defer delete(active, ref)
+	}
+
+	nilable := rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface
+	if rv.Type().Implements(scriptValueType) && !(nilable && rv.IsNil()) {
+		return rv.Interface().(value.Value), nil
+	}
+
+	switch rv.Kind() {
+	case reflect.Bool:
+		return value.Bool(rv.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return value.Number(rv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return value.Number(rv.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return value.Number(rv.Float()), nil
+	case reflect.String:
+		return value.String(rv.String()), nil
+	case reflect.Slice, reflect.Array:
+		array := make(value.Array, 0, rv.Len())
+		for i := range rv.Len() {
+			element, err := convertReflected(rv.Index(i), readOnly, active)
+			if err != nil {
+				return nil, err
+			}
+			array = append(array, element)
+		}
+		return array, nil
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("Cannot convert Go map with key type '%s' to a script value, keys must be strings.", rv.Type().Key())
+		}
+
+		m := make(value.Map, rv.Len())
+		iterator := rv.MapRange()
+		for iterator.Next() {
+			element, err := convertReflected(iterator.Value(), readOnly, active)
+			if err != nil {
+				return nil, err
+			}
+			m[iterator.Key().String()] = element
+		}
+		return m, nil
+	case reflect.Struct:
+		return newObject(rv, readOnly), nil
+	case reflect.Pointer, reflect.Interface:
+		if rv.IsNil() {
+			return value.Null{}, nil
+		}
+		return convertReflected(rv.Elem(), readOnly, active)
+	default:
+		return nil, fmt.Errorf("Cannot convert Go value of type '%s' to a script value.", rv.Type())
+	}
+}
+
+func referenceOf(rv reflect.Value) (reference, bool) {
+	switch rv.Kind() {
+	case reflect.Map, reflect.Pointer:
+		if rv.IsNil() {
+			return reference{}, false
+		}
+		return reference{pointer: rv.Pointer(), typ: rv.Type()}, true
+	case reflect.Slice:
+		if rv.Len() == 0 {
+			return reference{}, false
+		}
+		return reference{pointer: rv.Pointer(), typ: rv.Type(), length: rv.Len()}, true
+	default:
+		return reference{}, false
+	}
+}
+
+func fromValue(v value.Value) (any, error) {
+	return convertValue(v, map[uintptr]bool{})
+}
+
+// convertValue tracks the maps and arrays on the current path in active, Go
+// values cannot hold themselves the way script maps can.
+func convertValue(v value.Value, active map[uintptr]bool) (any, error) {
+	if id, ok := value.Identity(v); ok {
+		if active[id] {
+			return nil, fmt.Errorf("Cannot convert a cyclic script value of type '%s' to a Go value.", v.Type())
+		}
+		active[id] = true
+		defer delete(active, id)
+	}
+
+	switch v := v.(type) {
+	case value.Null:
+		return nil, nil
+	case value.Bool:
+		return bool(v), nil
+	case value.Number:
+		return float64(v), nil
+	case value.String:
+		return string(v), nil
+	case value.Array:
+		array := make([]any, 0, len(v))
+		for _, element := range v {
+			converted, err := convertValue(element, active)
+			if err != nil {
+				return nil, err
+			}
+			array = append(array, converted)
+		}
+		return array, nil
+	case *object:
+		return v.goValue(), nil
+	case value.Map:
+		m := make(map[string]any, len(v))
+		for key, element := range v {
+			converted, err := convertValue(element, active)
+			if err != nil {
+				return nil, err
+			}
+			m[key] = converted
+		}
+		return m, nil
+	default:
+		return nil, fmt.Errorf("Cannot convert script value of type '%s' to a Go value.", v.Type())
+	}
+}
@@ -0,0 +1,261 @@
+package adam
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"reflect"
+
+	"github.com/joaovictorjs/adam-script/value"
+	"github.com/joaovictorjs/adam-script/vm"
+)
+
+var (
+	contextType = reflect.TypeFor[context.Context]()
+	errorType   = reflect.TypeFor[error]()
+)
+
+func (m *VM) RegisterFunc(name string, fn any) error {
+	native, err := adaptFunc(name, fn)
+	if err != nil {
+		return err
+	}
+	return m.machine.SetGlobal(name, native)
+}
+
+type signature struct {
+	name        string
+	variadic    bool
+	withContext bool
+	withError   bool
+	parameters  []reflect.Type
+	results     int
+}
+
+func adaptFunc(name string, fn any) (*vm.Native, error) {
+	rv := reflect.ValueOf(fn)
+	if rv.Kind() != reflect.Func || rv.IsNil() {
+		return nil, fmt.Errorf("Cannot register '%s', expected a function but got '%T'.", name, fn)
+	}
+
+	s, err := newSignature(name, rv.Type())
+	if err != nil {
+		return nil, err
+	}
+	return s.native(rv), nil
+}
+
+func newSignature(name string, t reflect.Type) (*signature, error) {
+	withContext := t.NumIn() > 0 && t.In(0) == contextType
+
+	var parameters []reflect.Type
+	for i := range t.NumIn() {
+		if i == 0 && withContext {
+			continue
+		}
+
+		parameter := t.In(i)
+		if i == t.NumIn()-1 && t.IsVariadic() {
+			parameter = parameter.Elem()
+		}
+		if !supported(parameter) {
+			return nil, fmt.Errorf("Cannot register '%s', parameter %d has unsupported type '%s'.", name, len(parameters)+1, parameter)
+		}
+		parameters = append(parameters, parameter)
+	}
+
+	withError := t.NumOut() > 0 && t.Out(t.NumOut()-1) == errorType
+	results := t.NumOut()
+	if withError {
+		results--
+	}
+	if results > 1 {
+		return nil, fmt.Errorf("Cannot register '%s', functions may only return a value, an error or both.", name)
+	}
+	if results == 1 && !supported(t.Out(0)) {
+		return nil, fmt.Errorf("Cannot register '%s', result has unsupported type '%s'.", name, t.Out(0))
+	}
+
+	s := &signature{
+		name:        name,
+		variadic:    t.IsVariadic(),
+		withContext: withContext,
+		withError:   withError,
+		parameters:  parameters,
+		results:     results,
+	}
+	return s, nil
+}
+
+func (s *signature) native(rv reflect.Value) *vm.Native {
+	name, parameters := s.name, s.parameters
+	call := func(ctx context.Context, arguments []value.Value) (result value.Value, err error) {
+		if s.variadic {
+			if len(arguments) < len(parameters)-1 {
+				return nil, fmt.Errorf("Function '%s' expects at least %d arguments but got %d.", name, len(parameters)-1, len(arguments))
+			}
+		} else if len(arguments) != len(parameters) {
+			return nil, fmt.Errorf("Function '%s' expects %d arguments but got %d.", name, len(parameters), len(arguments))
+		}
+
+		in := make([]reflect.Value, 0, len(arguments)+1)
+		if s.withContext {
+			in = append(in, reflect.ValueOf(ctx))
+		}
+		for index, argument := range arguments {
+			parameter := parameters[min(index, len(parameters)-1)]
+			converted, err := fromScript(argument, parameter)
+			if err != nil {
+				return nil, fmt.Errorf("Function '%s' expects argument %d to be %s.", name, index+1, err)
+			}
+			in = append(in, converted)
+		}
+
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				result, err = nil, fmt.Errorf("Function '%s' panicked: %v.", name, recovered)
+			}
+		}()
+
+		out := rv.Call(in)
+		if s.withError {
+			if failure := out[len(out)-1]; !failure.IsNil() {
+				return nil, failure.Interface().(error)
+			}
+		}
+
+		if s.results == 0 {
+			return value.Null{}, nil
+		}
+		return reflectToValue(out[0], false)
+	}
+
+	return &vm.Native{Name: name, Function: call}
+}
+
+func supported(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Bool, reflect.String, reflect.Float32, reflect.Float64,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	case reflect.Slice:
+		return supported(t.Elem())
+	case reflect.Map:
+		return t.Key().Kind() == reflect.String && supported(t.Elem())
+	case reflect.Interface:
+		return t.NumMethod() == 0 || t == scriptValueType
+	case reflect.Struct:
+		return true
+	case reflect.Pointer:
+		return t.Elem().Kind() == reflect.Struct
+	default:
+		return false
+	}
+}
+
+func fromScript(v value.Value, t reflect.Type) (reflect.Value, error) {
+	mismatch := fmt.Errorf("of type '%s' but got '%s'", t, v.Type())
+
+	switch t.Kind() {
+	case reflect.Interface:
+		if t == scriptValueType {
+			return reflect.ValueOf(&v).Elem(), nil
+		}
+		converted, err := fromValue(v)
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("convertible to a Go value but got '%s'", v.Type())
+		}
+		if converted == nil {
+			return reflect.Zero(t), nil
+		}
+		return reflect.ValueOf(converted), nil
+	case reflect.Bool:
+		b, ok := v.(value.Bool)
+		if !ok {
+			return reflect.Value{}, mismatch
+		}
+		return reflect.ValueOf(bool(b)).Convert(t), nil
+	case reflect.String:
+		s, ok := v.(value.String)
+		if !ok {
+			return reflect.Value{}, mismatch
+		}
+		return reflect.ValueOf(string(s)).Convert(t), nil
+	case reflect.Float32, reflect.Float64:
+		n, ok := v.(value.Number)
+		if !ok {
+			return reflect.Value{}, mismatch
+		}
+		return reflect.ValueOf(float64(n)).Convert(t), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, ok := v.(value.Number)
+		if !ok {
+			return reflect.Value{}, mismatch
+		}
+		if !integral(n, math.MinInt64, math.MaxInt64) || reflect.Zero(t).OverflowInt(int64(n)) {
+			return reflect.Value{}, fmt.Errorf("an integer of type '%s' but got %s", t, value.Inspect(v))
+		}
+		return reflect.ValueOf(int64(n)).Convert(t), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, ok := v.(value.Number)
+		if !ok {
+			return reflect.Value{}, mismatch
+		}
+		if !integral(n, 0, math.MaxUint64) || reflect.Zero(t).OverflowUint(uint64(n)) {
+			return reflect.Value{}, fmt.Errorf("an integer of type '%s' but got %s", t, value.Inspect(v))
+		}
+		return reflect.ValueOf(uint64(n)).Convert(t), nil
+	case reflect.Slice:
+		array, ok := v.(value.Array)
+		if !ok {
+			return reflect.Value{}, mismatch
+		}
+		slice := reflect.MakeSlice(t, 0, len(array))
+		for _, element := range array {
+			converted, err := fromScript(element, t.Elem())
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			slice = reflect.Append(slice, converted)
+		}
+		return slice, nil
+	case reflect.Struct:
+		o, ok := v.(*object)
+		if !ok || o.value.Type() != t {
+			return reflect.Value{}, mismatch
+		}
+		return o.value, nil
+	case reflect.Pointer:
+		o, ok := v.(*object)
+		if !ok || o.value.Type() != t.Elem() {
+			return reflect.Value{}, mismatch
+		}
+		if o.value.CanAddr() && !o.readOnly {
+			return o.value.Addr(), nil
+		}
+		copied := reflect.New(t.Elem())
+		copied.Elem().Set(o.value)
+		return copied, nil
+	case reflect.Map:
+		m, ok := v.(value.Map)
+		if !ok {
+			return reflect.Value{}, mismatch
+		}
+		converted := reflect.MakeMapWithSize(t, len(m))
+		for key, element := range m {
+			e, err := fromScript(element, t.Elem())
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			converted.SetMapIndex(reflect.ValueOf(key).Convert(t.Key()), e)
+		}
+		return converted, nil
+	default:
+		return reflect.Value{}, mismatch
+	}
+}
+
+func integral(n value.Number, lower, upper float64) bool {
+	return float64(n) == math.Trunc(float64(n)) && float64(n) >= lower && float64(n) < upper
+}
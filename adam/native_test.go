@@ -0,0 +1,209 @@
+package adam
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type contextKey struct{}
+
+func Test_GivenRegisteredFunc_WhenCalledFromScript_ThenShouldAdaptValues(t *testing.T) {
+	type TestCase struct {
+		name          string
+		fn            any
+		source        string
+		expectedValue any
+	}
+
+	testcases := []TestCase{
+		{
+			name:          "plain function",
+			fn:            func(a, b int) int { return a * b },
+			source:        "f(6, 7)",
+			expectedValue: 42.0,
+		},
+		{
+			name:          "no result",
+			fn:            func(string) {},
+			source:        `f("ignored")`,
+			expectedValue: nil,
+		},
+		{
+			name:          "variadic",
+			fn:            func(separator string, parts ...string) string { return strings.Join(parts, separator) },
+			source:        `f("-", "a", "b", "c") + f("+")`,
+			expectedValue: "a-b-c",
+		},
+		{
+			name:          "error result",
+			fn:            func(n float64) (float64, error) { return n / 2, nil },
+			source:        "f(5)",
+			expectedValue: 2.5,
+		},
+		{
+			name:          "leading context",
+			fn:            func(ctx context.Context, suffix string) string { return ctx.Value(contextKey{}).(string) + suffix },
+			source:        `f("!")`,
+			expectedValue: "host!",
+		},
+		{
+			name:          "collections",
+			fn:            func(values []any, options map[string]bool) int { return len(values) + len(options) },
+			source:        "f(list, flags)",
+			expectedValue: 3.0,
+		},
+		{
+			name:          "returned collections",
+			fn:            func() map[string][]int { return map[string][]int{"a": {1, 2}} },
+			source:        "f()",
+			expectedValue: map[string]any{"a": []any{1.0, 2.0}},
+		},
+	}
+
+	for _, test := range testcases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			m := New(Options{})
+			assert.NoError(t, m.RegisterFunc("f", test.fn))
+			assert.NoError(t, m.Set("list", []int{1, 2}))
+			assert.NoError(t, m.Set("flags", map[string]bool{"x": true}))
+
+			ctx := context.WithValue(context.Background(), contextKey{}, "host")
+			result, err := m.Eval(ctx, test.source)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedValue, result)
+		})
+	}
+}
+
+func Test_GivenRegisteredFunc_WhenCalledIncorrectly_ThenShouldReturnScriptError(t *testing.T) {
+	type TestCase struct {
+		name          string
+		fn            any
+		source        string
+		expectedError error
+	}
+
+	testcases := []TestCase{
+		{
+			name:          "too few arguments",
+			fn:            func(a, b int) int { return a + b },
+			source:        "f(1)",
+			expectedError: fmt.Errorf("Function 'f' expects 2 arguments but got 1."),
+		},
+		{
+			name:          "too few variadic arguments",
+			fn:            func(a int, rest ...int) int { return a },
+			source:        "f()",
+			expectedError: fmt.Errorf("Function 'f' expects at least 1 arguments but got 0."),
+		},
+		{
+			name:          "wrong argument type",
+			fn:            func(s string) string { return s },
+			source:        "f(1)",
+			expectedError: fmt.Errorf("Function 'f' expects argument 1 to be of type 'string' but got 'number'."),
+		},
+		{
+			name:          "fractional integer",
+			fn:            func(n int) int { return n },
+			source:        "f(3 / 2)",
+			expectedError: fmt.Errorf("Function 'f' expects argument 1 to be an integer of type 'int' but got 1.5."),
+		},
+		{
+			name:          "overflowing integer",
+			fn:            func(n uint8) uint8 { return n },
+			source:        "f(256)",
+			expectedError: fmt.Errorf("Function 'f' expects argument 1 to be an integer of type 'uint8' but got 256."),
+		},
+		{
+			name:          "wrong variadic element",
+			fn:            func(parts ...string) int { return len(parts) },
+			source:        `f("a", 2)`,
+			expectedError: fmt.Errorf("Function 'f' expects argument 2 to be of type 'string' but got 'number'."),
+		},
+		{
+			name:          "returned error",
+			fn:            func() (int, error) { return 0, errors.New("Quota exceeded.") },
+			source:        "f()",
+			expectedError: errors.New("Quota exceeded."),
+		},
+		{
+			name:          "panic",
+			fn:            func() int { panic("boom") },
+			source:        "f()",
+			expectedError: fmt.Errorf("Function 'f' panicked: boom."),
+		},
+	}
+
+	for _, test := range testcases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			m := New(Options{})
+			assert.NoError(t, m.RegisterFunc("f", test.fn))
+
+			_, err := m.Eval(context.Background(), test.source)
+			assert.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_GivenUnsupportedFunc_WhenRegisterFunc_ThenShouldReturnCorrectError(t *testing.T) {
+	type TestCase struct {
+		name          string
+		fn            any
+		expectedError error
+	}
+
+	testcases := []TestCase{
+		{
+			name:          "not a function",
+			fn:            42,
+			expectedError: fmt.Errorf("Cannot register 'f', expected a function but got 'int'."),
+		},
+		{
+			name:          "unsupported parameter",
+			fn:            func(int, chan int) {},
+			expectedError: fmt.Errorf("Cannot register 'f', parameter 2 has unsupported type 'chan int'."),
+		},
+		{
+			name:          "unsupported result",
+			fn:            func() chan int { return nil },
+			expectedError: fmt.Errorf("Cannot register 'f', result has unsupported type 'chan int'."),
+		},
+		{
+			name:          "unsupported result with error",
+			fn:            func() (func(), error) { return nil, nil },
+			expectedError: fmt.Errorf("Cannot register 'f', result has unsupported type 'func()'."),
+		},
+		{
+			name:          "too many results",
+			fn:            func() (int, int) { return 1, 2 },
+			expectedError: fmt.Errorf("Cannot register 'f', functions may only return a value, an error or both."),
+		},
+	}
+
+	for _, test := range testcases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, test.expectedError, New(Options{}).RegisterFunc("f", test.fn))
+		})
+	}
+}
+
+func Test_GivenRegisteredFunc_WhenInspected_ThenShouldBeNativeFunction(t *testing.T) {
+	m := New(Options{Strict: true})
+	assert.NoError(t, m.RegisterFunc("double", func(n int) int { return n * 2 }))
+
+	result, err := m.Eval(context.Background(), "fn quadruple(n) { return double(double(n)) } quadruple(3)")
+	assert.NoError(t, err)
+	assert.Equal(t, 12.0, result)
+
+	result, err = m.Call("double", 4)
+	assert.NoError(t, err)
+	assert.Equal(t, 8.0, result)
+}
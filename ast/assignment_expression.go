@@ -0,0 +1,65 @@
+package ast
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type AssignmentExpression struct {
+	Target Expression
+	Value  Expression
+	Span   Span
+}
+
+func (AssignmentExpression) node() {}
+
+func (AssignmentExpression) expression() {}
+
+func (e AssignmentExpression) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]any{
+		"Kind":   "AssignmentExpression",
+		"Target": e.Target,
+		"Value":  e.Value,
+		"Span":   e.Span,
+	})
+}
+
+func (e *AssignmentExpression) UnmarshalJSON(data []byte) error {
+	if err := checkKind(data, "AssignmentExpression"); err != nil {
+		return err
+	}
+
+	var fields struct {
+		Target json.RawMessage
+		Value  json.RawMessage
+		Span   Span
+	}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	if fields.Target == nil {
+		return missingField("AssignmentExpression", "Target")
+	}
+
+	target, err := unmarshalExpression(fields.Target)
+	if err != nil {
+		return err
+	}
+
+	switch target.(type) {
+	case IdentifierExpression, MemberExpression:
+	default:
+		return fmt.Errorf("Invalid assignment target '%T'.", target)
+	}
+
+	value, err := unmarshalExpression(fields.Value)
+	if err != nil {
+		return err
+	}
+
+	e.Target = target
+	e.Value = value
+	e.Span = fields.Span
+	return nil
+}
@@ -1,11 +1,16 @@
 package ast
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
 
 type BinaryExpression struct {
 	Left     Expression
 	Operator uint8
 	Right    Expression
+	Span     Span
 }
 
 func (BinaryExpression) node() {}
@@ -18,5 +23,42 @@ func (e BinaryExpression) MarshalJSON() ([]byte, error) {
 		"Left":     e.Left,
 		"Operator": string(e.Operator),
 		"Right":    e.Right,
+		"Span":     e.Span,
 	})
 }
+
+func (e *BinaryExpression) UnmarshalJSON(data []byte) error {
+	if err := checkKind(data, "BinaryExpression"); err != nil {
+		return err
+	}
+
+	var fields struct {
+		Left     json.RawMessage
+		Operator string
+		Right    json.RawMessage
+		Span     Span
+	}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	if len(fields.Operator) != 1 || !strings.Contains("+-*/", fields.Operator) {
+		return fmt.Errorf("Invalid operator '%s' in BinaryExpression.", fields.Operator)
+	}
+
+	left, err := unmarshalExpression(fields.Left)
+	if err != nil {
+		return err
+	}
+
+	right, err := unmarshalExpression(fields.Right)
+	if err != nil {
+		return err
+	}
+
+	e.Left = left
+	e.Operator = fields.Operator[0]
+	e.Right = right
+	e.Span = fields.Span
+	return nil
+}
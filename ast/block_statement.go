@@ -0,0 +1,45 @@
+package ast
+
+import "encoding/json"
+
+type BlockStatement struct {
+	Statements []Statement
+	Span       Span
+}
+
+func (BlockStatement) node() {}
+
+func (BlockStatement) statement() {}
+
+func (s BlockStatement) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]any{
+		"Kind":       "BlockStatement",
+		"Statements": s.Statements,
+		"Span":       s.Span,
+	})
+}
+
+func (s *BlockStatement) UnmarshalJSON(data []byte) error {
+	if err := checkKind(data, "BlockStatement"); err != nil {
+		return err
+	}
+
+	var fields struct {
+		Statements []json.RawMessage
+		Span       Span
+	}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	s.Statements = nil
+	for _, raw := range fields.Statements {
+		statement, err := unmarshalStatement(raw)
+		if err != nil {
+			return err
+		}
+		s.Statements = append(s.Statements, statement)
+	}
+	s.Span = fields.Span
+	return nil
+}
@@ -0,0 +1,54 @@
+package ast
+
+import "encoding/json"
+
+type CallExpression struct {
+	Callee    Expression
+	Arguments []Expression
+	Span      Span
+}
+
+func (CallExpression) node() {}
+
+func (CallExpression) expression() {}
+
+func (e CallExpression) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]any{
+		"Kind":      "CallExpression",
+		"Callee":    e.Callee,
+		"Arguments": e.Arguments,
+		"Span":      e.Span,
+	})
+}
+
+func (e *CallExpression) UnmarshalJSON(data []byte) error {
+	if err := checkKind(data, "CallExpression"); err != nil {
+		return err
+	}
+
+	var fields struct {
+		Callee    json.RawMessage
+		Arguments []json.RawMessage
+		Span      Span
+	}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	callee, err := unmarshalExpression(fields.Callee)
+	if err != nil {
+		return err
+	}
+
+	e.Callee = callee
+	e.Arguments = nil
+	for _, raw := range fields.Arguments {
+		argument, err := unmarshalExpression(raw)
+		if err != nil {
+			return err
+		}
+		e.Arguments = append(e.Arguments, argument)
+	}
+	e.Span = fields.Span
+	return nil
+}
@@ -0,0 +1,5 @@
+package ast
+
+func Clone[T Node](node T) T {
+	return Rewrite(node, func(n Node) Node { return n }).(T)
+}
@@ -0,0 +1,148 @@
+package ast
+
+import (
+	"fmt"
+	"math"
+)
+
+type EqualOption func(*equalConfig)
+
+type equalConfig struct {
+	ignoreSpans bool
+}
+
+func IgnoreSpans() EqualOption {
+	return func(c *equalConfig) {
+		c.ignoreSpans = true
+	}
+}
+
+func newEqualConfig(options []EqualOption) equalConfig {
+	config := equalConfig{}
+	for _, option := range options {
+		option(&config)
+	}
+	return config
+}
+
+func Equal(a, b Node, options ...EqualOption) bool {
+	config := newEqualConfig(options)
+	return config.equal(a, b)
+}
+
+func (c equalConfig) equal(a, b Node) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+
+	if !c.ignoreSpans && SpanOf(a) != SpanOf(b) {
+		return false
+	}
+
+	switch x := a.(type) {
+	case Program:
+		y, ok := b.(Program)
+		if !ok || len(x.Statements) != len(y.Statements) {
+			return false
+		}
+		for i := range x.Statements {
+			if !c.equal(x.Statements[i], y.Statements[i]) {
+				return false
+			}
+		}
+		return true
+	case BlockStatement:
+		y, ok := b.(BlockStatement)
+		if !ok || len(x.Statements) != len(y.Statements) {
+			return false
+		}
+		for i := range x.Statements {
+			if !c.equal(x.Statements[i], y.Statements[i]) {
+				return false
+			}
+		}
+		return true
+	case ExpressionStatement:
+		y, ok := b.(ExpressionStatement)
+		return ok && c.equal(x.Expression, y.Expression)
+	case VariableDeclaration:
+		y, ok := b.(VariableDeclaration)
+		return ok && x.Constant == y.Constant && x.Identifier == y.Identifier && x.Type == y.Type && c.equal(x.Value, y.Value)
+	case FunctionDeclaration:
+		y, ok := b.(FunctionDeclaration)
+		if !ok || x.Name != y.Name || x.ReturnType != y.ReturnType || len(x.Parameters) != len(y.Parameters) {
+			return false
+		}
+		for i := range x.Parameters {
+			if !c.equalParameter(x.Parameters[i], y.Parameters[i]) {
+				return false
+			}
+		}
+		return c.equal(x.Body, y.Body)
+	case ReturnStatement:
+		y, ok := b.(ReturnStatement)
+		return ok && c.equal(x.Value, y.Value)
+	case TryStatement:
+		y, ok := b.(TryStatement)
+		return ok && c.equalParameter(x.Parameter, y.Parameter) && c.equal(x.Body, y.Body) && c.equal(x.Handler, y.Handler)
+	case CallExpression:
+		y, ok := b.(CallExpression)
+		if !ok || len(x.Arguments) != len(y.Arguments) || !c.equal(x.Callee, y.Callee) {
+			return false
+		}
+		for i := range x.Arguments {
+			if !c.equal(x.Arguments[i], y.Arguments[i]) {
+				return false
+			}
+		}
+		return true
+	case MemberExpression:
+		y, ok := b.(MemberExpression)
+		return ok && x.Property == y.Property && c.equal(x.Object, y.Object)
+	case ObjectExpression:
+		y, ok := b.(ObjectExpression)
+		if !ok || len(x.Properties) != len(y.Properties) {
+			return false
+		}
+		for i := range x.Properties {
+			if !c.equalProperty(x.Properties[i], y.Properties[i]) {
+				return false
+			}
+		}
+		return true
+	case AssignmentExpression:
+		y, ok := b.(AssignmentExpression)
+		return ok && c.equal(x.Target, y.Target) && c.equal(x.Value, y.Value)
+	case BinaryExpression:
+		y, ok := b.(BinaryExpression)
+		return ok && x.Operator == y.Operator && c.equal(x.Left, y.Left) && c.equal(x.Right, y.Right)
+	case UnaryExpression:
+		y, ok := b.(UnaryExpression)
+		return ok && x.Operator == y.Operator && c.equal(x.Operand, y.Operand)
+	case NumericLiteralExpression:
+		y, ok := b.(NumericLiteralExpression)
+		return ok && math.Float64bits(x.Value) == math.Float64bits(y.Value)
+	case StringLiteralExpression:
+		y, ok := b.(StringLiteralExpression)
+		return ok && x.Value == y.Value
+	case IdentifierExpression:
+		y, ok := b.(IdentifierExpression)
+		return ok && x.Symbol == y.Symbol
+	default:
+		panic(fmt.Sprintf("ast.Equal: unexpected node type %T", x))
+	}
+}
+
+func (c equalConfig) equalParameter(a, b Parameter) bool {
+	if !c.ignoreSpans && a.Span != b.Span {
+		return false
+	}
+	return a.Name == b.Name && a.Type == b.Type
+}
+
+func (c equalConfig) equalProperty(a, b Property) bool {
+	if !c.ignoreSpans && a.Span != b.Span {
+		return false
+	}
+	return a.Key == b.Key && c.equal(a.Value, b.Value)
+}
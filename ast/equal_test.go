@@ -0,0 +1,146 @@
+package ast
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func spannedNumber(value float64, start int) NumericLiteralExpression {
+	return NumericLiteralExpression{Value: value, Span: Span{Start: start, End: start + 1}}
+}
+
+func Test_GivenNodes_WhenEqual_ThenShouldCompareStructurally(t *testing.T) {
+	type TestCase struct {
+		name     string
+		a        Node
+		b        Node
+		options  []EqualOption
+		expected bool
+	}
+
+	testcases := []TestCase{
+		{
+			name:     "same tree",
+			a:        walkTestProgram,
+			b:        Clone(walkTestProgram),
+			expected: true,
+		},
+		{
+			name:     "different spans",
+			a:        spannedNumber(1, 0),
+			b:        spannedNumber(1, 4),
+			expected: false,
+		},
+		{
+			name:     "different spans ignored",
+			a:        spannedNumber(1, 0),
+			b:        spannedNumber(1, 4),
+			options:  []EqualOption{IgnoreSpans()},
+			expected: true,
+		},
+		{
+			name:     "different values",
+			a:        NumericLiteralExpression{Value: 1},
+			b:        NumericLiteralExpression{Value: 2},
+			expected: false,
+		},
+		{
+			name:     "signed zeros are different literals",
+			a:        NumericLiteralExpression{Value: 0},
+			b:        NumericLiteralExpression{Value: negativeZero()},
+			expected: false,
+		},
+		{
+			name:     "different node kinds",
+			a:        IdentifierExpression{Symbol: "x"},
+			b:        StringLiteralExpression{Value: "x"},
+			expected: false,
+		},
+		{
+			name: "different operators",
+			a: BinaryExpression{
+				Left:     IdentifierExpression{Symbol: "a"},
+				Operator: '+',
+				Right:    IdentifierExpression{Symbol: "b"},
+			},
+			b: BinaryExpression{
+				Left:     IdentifierExpression{Symbol: "a"},
+				Operator: '-',
+				Right:    IdentifierExpression{Symbol: "b"},
+			},
+			expected: false,
+		},
+		{
+			name:     "different statement counts",
+			a:        walkTestProgram,
+			b:        Program{Statements: walkTestProgram.Statements[:1]},
+			expected: false,
+		},
+		{
+			name:     "nil nodes",
+			a:        nil,
+			b:        nil,
+			expected: true,
+		},
+	}
+
+	for _, test := range testcases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, test.expected, Equal(test.a, test.b, test.options...))
+			if test.expected {
+				assert.Equal(t, Hash(test.a, test.options...), Hash(test.b, test.options...))
+			}
+		})
+	}
+}
+
+func Test_GivenDifferentTrees_WhenHash_ThenShouldReturnDifferentHashes(t *testing.T) {
+	hashes := map[uint64]Node{}
+	nodes := []Node{
+		walkTestProgram,
+		walkTestProgram.Statements[0],
+		walkTestProgram.Statements[1],
+		IdentifierExpression{Symbol: "ab"},
+		StringLiteralExpression{Value: "ab"},
+		BinaryExpression{Left: IdentifierExpression{Symbol: "a"}, Operator: '+', Right: IdentifierExpression{Symbol: "b"}},
+		BinaryExpression{Left: IdentifierExpression{Symbol: "b"}, Operator: '+', Right: IdentifierExpression{Symbol: "a"}},
+		spannedNumber(1, 0),
+		spannedNumber(1, 4),
+	}
+
+	for _, node := range nodes {
+		hash := Hash(node)
+		assert.NotContains(t, hashes, hash, "%#v collides with %#v", node, hashes[hash])
+		hashes[hash] = node
+	}
+	assert.Equal(t, Hash(spannedNumber(1, 0), IgnoreSpans()), Hash(spannedNumber(1, 4), IgnoreSpans()))
+}
+
+func Test_GivenProgram_WhenClone_ThenShouldNotShareStatements(t *testing.T) {
+	clone := Clone(walkTestProgram)
+	clone.Statements[0] = ExpressionStatement{Expression: IdentifierExpression{Symbol: "changed"}}
+
+	assert.Equal(t, "x", walkTestProgram.Statements[0].(VariableDeclaration).Identifier)
+	assert.False(t, Equal(walkTestProgram, clone))
+}
+
+func Test_GivenFunctionDeclaration_WhenClone_ThenShouldNotShareParameters(t *testing.T) {
+	declaration := FunctionDeclaration{
+		Name:       "add",
+		Parameters: []Parameter{{Name: "a", Type: "number"}, {Name: "b"}},
+		Body:       BlockStatement{Statements: []Statement{ReturnStatement{Value: IdentifierExpression{Symbol: "a"}}}},
+	}
+
+	clone := Clone(declaration)
+	clone.Parameters[0].Name = "changed"
+
+	assert.Equal(t, "a", declaration.Parameters[0].Name)
+	assert.False(t, Equal(declaration, clone))
+}
+
+func negativeZero() float64 {
+	zero := 0.0
+	return -zero
+}
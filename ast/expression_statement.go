@@ -4,6 +4,7 @@ import "encoding/json"
 
 type ExpressionStatement struct {
 	Expression Expression
+	Span       Span
 }
 
 func (ExpressionStatement) node() {}
@@ -14,5 +15,29 @@ func (e ExpressionStatement) MarshalJSON() ([]byte, error) {
 	return json.Marshal(map[string]any{
 		"Kind":       "ExpressionStatement",
 		"Expression": e.Expression,
+		"Span":       e.Span,
 	})
 }
+
+func (e *ExpressionStatement) UnmarshalJSON(data []byte) error {
+	if err := checkKind(data, "ExpressionStatement"); err != nil {
+		return err
+	}
+
+	var fields struct {
+		Expression json.RawMessage
+		Span       Span
+	}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	expression, err := unmarshalExpression(fields.Expression)
+	if err != nil {
+		return err
+	}
+
+	e.Expression = expression
+	e.Span = fields.Span
+	return nil
+}
@@ -0,0 +1,97 @@
+package ast
+
+import "encoding/json"
+
+type Parameter struct {
+	Name string
+	Type string
+	Span Span
+}
+
+type FunctionDeclaration struct {
+	Name       string
+	Parameters []Parameter
+	ReturnType string
+	Body       BlockStatement
+	Span       Span
+}
+
+func (FunctionDeclaration) node() {}
+
+func (FunctionDeclaration) statement() {}
+
+func (p Parameter) MarshalJSON() ([]byte, error) {
+	fields := map[string]any{
+		"Name": p.Name,
+		"Span": p.Span,
+	}
+	if p.Type != "" {
+		fields["Type"] = p.Type
+	}
+	return json.Marshal(fields)
+}
+
+func (p *Parameter) UnmarshalJSON(data []byte) error {
+	var fields struct {
+		Name *string
+		Type string
+		Span Span
+	}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	if fields.Name == nil {
+		return missingField("Parameter", "Name")
+	}
+
+	p.Name = *fields.Name
+	p.Type = fields.Type
+	p.Span = fields.Span
+	return nil
+}
+
+func (s FunctionDeclaration) MarshalJSON() ([]byte, error) {
+	fields := map[string]any{
+		"Kind":       "FunctionDeclaration",
+		"Name":       s.Name,
+		"Parameters": s.Parameters,
+		"Body":       s.Body,
+		"Span":       s.Span,
+	}
+	if s.ReturnType != "" {
+		fields["ReturnType"] = s.ReturnType
+	}
+	return json.Marshal(fields)
+}
+
+func (s *FunctionDeclaration) UnmarshalJSON(data []byte) error {
+	if err := checkKind(data, "FunctionDeclaration"); err != nil {
+		return err
+	}
+
+	var fields struct {
+		Name       *string
+		Parameters []Parameter
+		ReturnType string
+		Body       *BlockStatement
+		Span       Span
+	}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	if fields.Name == nil {
+		return missingField("FunctionDeclaration", "Name")
+	}
+	if fields.Body == nil {
+		return missingField("FunctionDeclaration", "Body")
+	}
+
+	s.Name = *fields.Name
+	s.Parameters = fields.Parameters
+	s.ReturnType = fields.ReturnType
+	s.Body = *fields.Body
+	s.Span = fields.Span
+	return nil
+}
@@ -0,0 +1,144 @@
+package ast
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"hash/fnv"
+	"math"
+)
+
+func Hash(node Node, options ...EqualOption) uint64 {
+	config := newEqualConfig(options)
+	h := fnv.New64a()
+	config.hash(h, node)
+	return h.Sum64()
+}
+
+func (c equalConfig) hash(h hash.Hash64, node Node) {
+	if node == nil {
+		writeHashString(h, "nil")
+		return
+	}
+
+	switch n := node.(type) {
+	case Program:
+		writeHashString(h, "Program")
+		writeHashInt(h, int64(len(n.Statements)))
+		for _, statement := range n.Statements {
+			c.hash(h, statement)
+		}
+	case BlockStatement:
+		writeHashString(h, "BlockStatement")
+		writeHashInt(h, int64(len(n.Statements)))
+		for _, statement := range n.Statements {
+			c.hash(h, statement)
+		}
+	case ExpressionStatement:
+		writeHashString(h, "ExpressionStatement")
+		c.hash(h, n.Expression)
+	case VariableDeclaration:
+		writeHashString(h, "VariableDeclaration")
+		writeHashBool(h, n.Constant)
+		writeHashString(h, n.Identifier)
+		writeHashString(h, n.Type)
+		c.hash(h, n.Value)
+	case FunctionDeclaration:
+		writeHashString(h, "FunctionDeclaration")
+		writeHashString(h, n.Name)
+		writeHashInt(h, int64(len(n.Parameters)))
+		for _, parameter := range n.Parameters {
+			c.hashParameter(h, parameter)
+		}
+		writeHashString(h, n.ReturnType)
+		c.hash(h, n.Body)
+	case ReturnStatement:
+		writeHashString(h, "ReturnStatement")
+		c.hash(h, n.Value)
+	case TryStatement:
+		writeHashString(h, "TryStatement")
+		c.hash(h, n.Body)
+		c.hashParameter(h, n.Parameter)
+		c.hash(h, n.Handler)
+	case CallExpression:
+		writeHashString(h, "CallExpression")
+		c.hash(h, n.Callee)
+		writeHashInt(h, int64(len(n.Arguments)))
+		for _, argument := range n.Arguments {
+			c.hash(h, argument)
+		}
+	case MemberExpression:
+		writeHashString(h, "MemberExpression")
+		c.hash(h, n.Object)
+		writeHashString(h, n.Property)
+	case ObjectExpression:
+		writeHashString(h, "ObjectExpression")
+		writeHashInt(h, int64(len(n.Properties)))
+		for _, property := range n.Properties {
+			writeHashString(h, property.Key)
+			c.hash(h, property.Value)
+			if !c.ignoreSpans {
+				writeHashInt(h, int64(property.Span.Start))
+				writeHashInt(h, int64(property.Span.End))
+			}
+		}
+	case AssignmentExpression:
+		writeHashString(h, "AssignmentExpression")
+		c.hash(h, n.Target)
+		c.hash(h, n.Value)
+	case BinaryExpression:
+		writeHashString(h, "BinaryExpression")
+		writeHashInt(h, int64(n.Operator))
+		c.hash(h, n.Left)
+		c.hash(h, n.Right)
+	case UnaryExpression:
+		writeHashString(h, "UnaryExpression")
+		writeHashInt(h, int64(n.Operator))
+		c.hash(h, n.Operand)
+	case NumericLiteralExpression:
+		writeHashString(h, "NumericLiteralExpression")
+		writeHashInt(h, int64(math.Float64bits(n.Value)))
+	case StringLiteralExpression:
+		writeHashString(h, "StringLiteralExpression")
+		writeHashString(h, n.Value)
+	case IdentifierExpression:
+		writeHashString(h, "IdentifierExpression")
+		writeHashString(h, n.Symbol)
+	default:
+		panic(fmt.Sprintf("ast.Hash: unexpected node type %T", n))
+	}
+
+	if !c.ignoreSpans {
+		span := SpanOf(node)
+		writeHashInt(h, int64(span.Start))
+		writeHashInt(h, int64(span.End))
+	}
+}
+
+func (c equalConfig) hashParameter(h hash.Hash64, parameter Parameter) {
+	writeHashString(h, parameter.Name)
+	writeHashString(h, parameter.Type)
+	if !c.ignoreSpans {
+		writeHashInt(h, int64(parameter.Span.Start))
+		writeHashInt(h, int64(parameter.Span.End))
+	}
+}
+
+func writeHashString(h hash.Hash64, value string) {
+	writeHashInt(h, int64(len(value)))
+	h.Write([]byte(value))
+}
+
+func writeHashInt(h hash.Hash64, value int64) {
+	var buffer [8]byte
+	binary.LittleEndian.PutUint64(buffer[:], uint64(value))
+	h.Write(buffer[:])
+}
+
+func writeHashBool(h hash.Hash64, value bool) {
+	if value {
+		writeHashInt(h, 1)
+	} else {
+		writeHashInt(h, 0)
+	}
+}
@@ -0,0 +1,42 @@
+package ast
+
+import "encoding/json"
+
+type IdentifierExpression struct {
+	Symbol string
+	Span   Span
+}
+
+func (IdentifierExpression) node() {}
+
+func (IdentifierExpression) expression() {}
+
+func (e IdentifierExpression) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]any{
+		"Kind":   "IdentifierExpression",
+		"Symbol": e.Symbol,
+		"Span":   e.Span,
+	})
+}
+
+func (e *IdentifierExpression) UnmarshalJSON(data []byte) error {
+	if err := checkKind(data, "IdentifierExpression"); err != nil {
+		return err
+	}
+
+	var fields struct {
+		Symbol *string
+		Span   Span
+	}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	if fields.Symbol == nil {
+		return missingField("IdentifierExpression", "Symbol")
+	}
+
+	e.Symbol = *fields.Symbol
+	e.Span = fields.Span
+	return nil
+}
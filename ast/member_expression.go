@@ -0,0 +1,51 @@
+package ast
+
+import "encoding/json"
+
+type MemberExpression struct {
+	Object   Expression
+	Property string
+	Span     Span
+}
+
+func (MemberExpression) node() {}
+
+func (MemberExpression) expression() {}
+
+func (e MemberExpression) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]any{
+		"Kind":     "MemberExpression",
+		"Object":   e.Object,
+		"Property": e.Property,
+		"Span":     e.Span,
+	})
+}
+
+func (e *MemberExpression) UnmarshalJSON(data []byte) error {
+	if err := checkKind(data, "MemberExpression"); err != nil {
+		return err
+	}
+
+	var fields struct {
+		Object   json.RawMessage
+		Property *string
+		Span     Span
+	}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	if fields.Property == nil {
+		return missingField("MemberExpression", "Property")
+	}
+
+	object, err := unmarshalExpression(fields.Object)
+	if err != nil {
+		return err
+	}
+
+	e.Object = object
+	e.Property = *fields.Property
+	e.Span = fields.Span
+	return nil
+}
@@ -4,6 +4,7 @@ import "encoding/json"
 
 type NumericLiteralExpression struct {
 	Value float64
+	Span  Span
 }
 
 func (NumericLiteralExpression) node() {}
@@ -14,5 +15,28 @@ func (e NumericLiteralExpression) MarshalJSON() ([]byte, error) {
 	return json.Marshal(map[string]any{
 		"Kind":  "NumericLiteralExpression",
 		"Value": e.Value,
+		"Span":  e.Span,
 	})
 }
+
+func (e *NumericLiteralExpression) UnmarshalJSON(data []byte) error {
+	if err := checkKind(data, "NumericLiteralExpression"); err != nil {
+		return err
+	}
+
+	var fields struct {
+		Value *float64
+		Span  Span
+	}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	if fields.Value == nil {
+		return missingField("NumericLiteralExpression", "Value")
+	}
+
+	e.Value = *fields.Value
+	e.Span = fields.Span
+	return nil
+}
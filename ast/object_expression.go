@@ -0,0 +1,77 @@
+package ast
+
+import "encoding/json"
+
+type Property struct {
+	Key   string
+	Value Expression
+	Span  Span
+}
+
+type ObjectExpression struct {
+	Properties []Property
+	Span       Span
+}
+
+func (ObjectExpression) node() {}
+
+func (ObjectExpression) expression() {}
+
+func (p Property) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]any{
+		"Key":   p.Key,
+		"Value": p.Value,
+		"Span":  p.Span,
+	})
+}
+
+func (p *Property) UnmarshalJSON(data []byte) error {
+	var fields struct {
+		Key   *string
+		Value json.RawMessage
+		Span  Span
+	}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	if fields.Key == nil {
+		return missingField("Property", "Key")
+	}
+
+	value, err := unmarshalExpression(fields.Value)
+	if err != nil {
+		return err
+	}
+
+	p.Key = *fields.Key
+	p.Value = value
+	p.Span = fields.Span
+	return nil
+}
+
+func (e ObjectExpression) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]any{
+		"Kind":       "ObjectExpression",
+		"Properties": e.Properties,
+		"Span":       e.Span,
+	})
+}
+
+func (e *ObjectExpression) UnmarshalJSON(data []byte) error {
+	if err := checkKind(data, "ObjectExpression"); err != nil {
+		return err
+	}
+
+	var fields struct {
+		Properties []Property
+		Span       Span
+	}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	e.Properties = fields.Properties
+	e.Span = fields.Span
+	return nil
+}
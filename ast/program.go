@@ -4,6 +4,7 @@ import "encoding/json"
 
 type Program struct {
 	Statements []Statement
+	Span       Span
 }
 
 func (Program) node() {}
@@ -12,5 +13,31 @@ func (n Program) MarshalJSON() ([]byte, error) {
 	return json.Marshal(map[string]any{
 		"Kind":       "Program",
 		"Statements": n.Statements,
+		"Span":       n.Span,
 	})
 }
+
+func (n *Program) UnmarshalJSON(data []byte) error {
+	if err := checkKind(data, "Program"); err != nil {
+		return err
+	}
+
+	var fields struct {
+		Statements []json.RawMessage
+		Span       Span
+	}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	n.Statements = nil
+	for _, raw := range fields.Statements {
+		statement, err := unmarshalStatement(raw)
+		if err != nil {
+			return err
+		}
+		n.Statements = append(n.Statements, statement)
+	}
+	n.Span = fields.Span
+	return nil
+}
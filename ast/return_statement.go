@@ -0,0 +1,45 @@
+package ast
+
+import "encoding/json"
+
+type ReturnStatement struct {
+	Value Expression
+	Span  Span
+}
+
+func (ReturnStatement) node() {}
+
+func (ReturnStatement) statement() {}
+
+func (s ReturnStatement) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]any{
+		"Kind":  "ReturnStatement",
+		"Value": s.Value,
+		"Span":  s.Span,
+	})
+}
+
+func (s *ReturnStatement) UnmarshalJSON(data []byte) error {
+	if err := checkKind(data, "ReturnStatement"); err != nil {
+		return err
+	}
+
+	var fields struct {
+		Value json.RawMessage
+		Span  Span
+	}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	s.Value = nil
+	if fields.Value != nil && string(fields.Value) != "null" {
+		value, err := unmarshalExpression(fields.Value)
+		if err != nil {
+			return err
+		}
+		s.Value = value
+	}
+	s.Span = fields.Span
+	return nil
+}
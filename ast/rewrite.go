@@ -0,0 +1,116 @@
+package ast
+
+import (
+	"fmt"
+	"slices"
+)
+
+func Rewrite(node Node, f func(Node) Node) Node {
+	switch n := node.(type) {
+	case Program:
+		statements := make([]Statement, 0, len(n.Statements))
+		for _, statement := range n.Statements {
+			statements = append(statements, rewriteStatement(statement, f))
+		}
+		n.Statements = statements
+		return f(n)
+	case BlockStatement:
+		statements := make([]Statement, 0, len(n.Statements))
+		for _, statement := range n.Statements {
+			statements = append(statements, rewriteStatement(statement, f))
+		}
+		n.Statements = statements
+		return f(n)
+	case ExpressionStatement:
+		n.Expression = rewriteExpression(n.Expression, f)
+		return f(n)
+	case VariableDeclaration:
+		n.Value = rewriteExpression(n.Value, f)
+		return f(n)
+	case FunctionDeclaration:
+		replacement := Rewrite(n.Body, f)
+		body, ok := replacement.(BlockStatement)
+		if !ok {
+			panic(fmt.Sprintf("ast.Rewrite: cannot replace function body with %T", replacement))
+		}
+		n.Parameters = slices.Clone(n.Parameters)
+		n.Body = body
+		return f(n)
+	case ReturnStatement:
+		if n.Value != nil {
+			n.Value = rewriteExpression(n.Value, f)
+		}
+		return f(n)
+	case TryStatement:
+		n.Body = rewriteBlock(n.Body, "try body", f)
+		n.Handler = rewriteBlock(n.Handler, "catch handler", f)
+		return f(n)
+	case CallExpression:
+		n.Callee = rewriteExpression(n.Callee, f)
+		arguments := make([]Expression, 0, len(n.Arguments))
+		for _, argument := range n.Arguments {
+			arguments = append(arguments, rewriteExpression(argument, f))
+		}
+		n.Arguments = arguments
+		return f(n)
+	case MemberExpression:
+		n.Object = rewriteExpression(n.Object, f)
+		return f(n)
+	case ObjectExpression:
+		properties := make([]Property, 0, len(n.Properties))
+		for _, property := range n.Properties {
+			property.Value = rewriteExpression(property.Value, f)
+			properties = append(properties, property)
+		}
+		n.Properties = properties
+		return f(n)
+	case AssignmentExpression:
+		replacement := Rewrite(n.Target, f)
+		switch replacement.(type) {
+		case IdentifierExpression, MemberExpression:
+		default:
+			panic(fmt.Sprintf("ast.Rewrite: cannot replace assignment target with %T", replacement))
+		}
+		n.Target = replacement.(Expression)
+		n.Value = rewriteExpression(n.Value, f)
+		return f(n)
+	case BinaryExpression:
+		n.Left = rewriteExpression(n.Left, f)
+		n.Right = rewriteExpression(n.Right, f)
+		return f(n)
+	case UnaryExpression:
+		n.Operand = rewriteExpression(n.Operand, f)
+		return f(n)
+	case NumericLiteralExpression, StringLiteralExpression, IdentifierExpression:
+		return f(n)
+	default:
+		panic(fmt.Sprintf("ast.Rewrite: unexpected node type %T", n))
+	}
+}
+
+func rewriteBlock(block BlockStatement, name string, f func(Node) Node) BlockStatement {
+	replacement := Rewrite(block, f)
+	result, ok := replacement.(BlockStatement)
+	if !ok {
+		panic(fmt.Sprintf("ast.Rewrite: cannot replace %s with %T", name, replacement))
+	}
+	return result
+}
+
+func rewriteStatement(statement Statement, f func(Node) Node) Statement {
+	replacement := Rewrite(statement, f)
+	result, ok := replacement.(Statement)
+	if !ok {
+		panic(fmt.Sprintf("ast.Rewrite: cannot replace statement %T with %T", statement, replacement))
+	}
+	return result
+}
+
+func rewriteExpression(expression Expression, f func(Node) Node) Expression {
+	replacement := Rewrite(expression, f)
+	result, ok := replacement.(Expression)
+	if !ok {
+		panic(fmt.Sprintf("ast.Rewrite: cannot replace expression %T with %T", expression, replacement))
+	}
+	return result
+}
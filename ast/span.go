@@ -0,0 +1,47 @@
+package ast
+
+import "fmt"
+
+type Span struct {
+	Start int
+	End   int
+}
+
+func SpanOf(node Node) Span {
+	switch n := node.(type) {
+	case Program:
+		return n.Span
+	case BlockStatement:
+		return n.Span
+	case ExpressionStatement:
+		return n.Span
+	case VariableDeclaration:
+		return n.Span
+	case FunctionDeclaration:
+		return n.Span
+	case ReturnStatement:
+		return n.Span
+	case TryStatement:
+		return n.Span
+	case CallExpression:
+		return n.Span
+	case MemberExpression:
+		return n.Span
+	case ObjectExpression:
+		return n.Span
+	case AssignmentExpression:
+		return n.Span
+	case BinaryExpression:
+		return n.Span
+	case UnaryExpression:
+		return n.Span
+	case NumericLiteralExpression:
+		return n.Span
+	case StringLiteralExpression:
+		return n.Span
+	case IdentifierExpression:
+		return n.Span
+	default:
+		panic(fmt.Sprintf("ast.SpanOf: unexpected node type %T", n))
+	}
+}
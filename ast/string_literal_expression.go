@@ -0,0 +1,42 @@
+package ast
+
+import "encoding/json"
+
+type StringLiteralExpression struct {
+	Value string
+	Span  Span
+}
+
+func (StringLiteralExpression) node() {}
+
+func (StringLiteralExpression) expression() {}
+
+func (e StringLiteralExpression) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]any{
+		"Kind":  "StringLiteralExpression",
+		"Value": e.Value,
+		"Span":  e.Span,
+	})
+}
+
+func (e *StringLiteralExpression) UnmarshalJSON(data []byte) error {
+	if err := checkKind(data, "StringLiteralExpression"); err != nil {
+		return err
+	}
+
+	var fields struct {
+		Value *string
+		Span  Span
+	}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	if fields.Value == nil {
+		return missingField("StringLiteralExpression", "Value")
+	}
+
+	e.Value = *fields.Value
+	e.Span = fields.Span
+	return nil
+}
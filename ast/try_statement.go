@@ -0,0 +1,56 @@
+package ast
+
+import "encoding/json"
+
+type TryStatement struct {
+	Body      BlockStatement
+	Parameter Parameter
+	Handler   BlockStatement
+	Span      Span
+}
+
+func (TryStatement) node() {}
+
+func (TryStatement) statement() {}
+
+func (s TryStatement) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]any{
+		"Kind":      "TryStatement",
+		"Body":      s.Body,
+		"Parameter": s.Parameter,
+		"Handler":   s.Handler,
+		"Span":      s.Span,
+	})
+}
+
+func (s *TryStatement) UnmarshalJSON(data []byte) error {
+	if err := checkKind(data, "TryStatement"); err != nil {
+		return err
+	}
+
+	var fields struct {
+		Body      *BlockStatement
+		Parameter *Parameter
+		Handler   *BlockStatement
+		Span      Span
+	}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	if fields.Body == nil {
+		return missingField("TryStatement", "Body")
+	}
+	if fields.Parameter == nil {
+		return missingField("TryStatement", "Parameter")
+	}
+	if fields.Handler == nil {
+		return missingField("TryStatement", "Handler")
+	}
+
+	s.Body = *fields.Body
+	s.Parameter = *fields.Parameter
+	s.Handler = *fields.Handler
+	s.Span = fields.Span
+	return nil
+}
@@ -0,0 +1,54 @@
+package ast
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type UnaryExpression struct {
+	Operator uint8
+	Operand  Expression
+	Span     Span
+}
+
+func (UnaryExpression) node() {}
+
+func (UnaryExpression) expression() {}
+
+func (e UnaryExpression) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]any{
+		"Kind":     "UnaryExpression",
+		"Operator": string(e.Operator),
+		"Operand":  e.Operand,
+		"Span":     e.Span,
+	})
+}
+
+func (e *UnaryExpression) UnmarshalJSON(data []byte) error {
+	if err := checkKind(data, "UnaryExpression"); err != nil {
+		return err
+	}
+
+	var fields struct {
+		Operator string
+		Operand  json.RawMessage
+		Span     Span
+	}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	if fields.Operator != "-" {
+		return fmt.Errorf("Invalid operator '%s' in UnaryExpression.", fields.Operator)
+	}
+
+	operand, err := unmarshalExpression(fields.Operand)
+	if err != nil {
+		return err
+	}
+
+	e.Operator = fields.Operator[0]
+	e.Operand = operand
+	e.Span = fields.Span
+	return nil
+}
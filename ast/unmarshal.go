@@ -0,0 +1,151 @@
+package ast
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+)
+
+//go:embed schema.json
+var JSONSchema []byte
+
+func UnmarshalProgram(data []byte) (Program, error) {
+	var program Program
+	err := json.Unmarshal(data, &program)
+	return program, err
+}
+
+func unmarshalNode(data json.RawMessage) (Node, error) {
+	var header struct {
+		Kind string
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return nil, err
+	}
+
+	var node Node
+	var err error
+	switch header.Kind {
+	case "Program":
+		var n Program
+		err = json.Unmarshal(data, &n)
+		node = n
+	case "BlockStatement":
+		var n BlockStatement
+		err = json.Unmarshal(data, &n)
+		node = n
+	case "ExpressionStatement":
+		var n ExpressionStatement
+		err = json.Unmarshal(data, &n)
+		node = n
+	case "VariableDeclaration":
+		var n VariableDeclaration
+		err = json.Unmarshal(data, &n)
+		node = n
+	case "AssignmentExpression":
+		var n AssignmentExpression
+		err = json.Unmarshal(data, &n)
+		node = n
+	case "FunctionDeclaration":
+		var n FunctionDeclaration
+		err = json.Unmarshal(data, &n)
+		node = n
+	case "ReturnStatement":
+		var n ReturnStatement
+		err = json.Unmarshal(data, &n)
+		node = n
+	case "TryStatement":
+		var n TryStatement
+		err = json.Unmarshal(data, &n)
+		node = n
+	case "CallExpression":
+		var n CallExpression
+		err = json.Unmarshal(data, &n)
+		node = n
+	case "MemberExpression":
+		var n MemberExpression
+		err = json.Unmarshal(data, &n)
+		node = n
+	case "ObjectExpression":
+		var n ObjectExpression
+		err = json.Unmarshal(data, &n)
+		node = n
+	case "BinaryExpression":
+		var n BinaryExpression
+		err = json.Unmarshal(data, &n)
+		node = n
+	case "UnaryExpression":
+		var n UnaryExpression
+		err = json.Unmarshal(data, &n)
+		node = n
+	case "NumericLiteralExpression":
+		var n NumericLiteralExpression
+		err = json.Unmarshal(data, &n)
+		node = n
+	case "StringLiteralExpression":
+		var n StringLiteralExpression
+		err = json.Unmarshal(data, &n)
+		node = n
+	case "IdentifierExpression":
+		var n IdentifierExpression
+		err = json.Unmarshal(data, &n)
+		node = n
+	case "":
+		return nil, fmt.Errorf("Missing node kind in %s.", data)
+	default:
+		return nil, fmt.Errorf("Unknown node kind '%s'.", header.Kind)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	return node, nil
+}
+
+func unmarshalStatement(data json.RawMessage) (Statement, error) {
+	node, err := unmarshalNode(data)
+	if err != nil {
+		return nil, err
+	}
+
+	statement, ok := node.(Statement)
+	if !ok {
+		return nil, fmt.Errorf("Expected a statement but found '%T'.", node)
+	}
+	return statement, nil
+}
+
+func unmarshalExpression(data json.RawMessage) (Expression, error) {
+	if data == nil {
+		return nil, fmt.Errorf("Missing expression.")
+	}
+
+	node, err := unmarshalNode(data)
+	if err != nil {
+		return nil, err
+	}
+
+	expression, ok := node.(Expression)
+	if !ok {
+		return nil, fmt.Errorf("Expected an expression but found '%T'.", node)
+	}
+	return expression, nil
+}
+
+func checkKind(data []byte, expected string) error {
+	var header struct {
+		Kind string
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return err
+	}
+
+	if header.Kind != expected {
+		return fmt.Errorf("Expected node kind '%s' but found '%s'.", expected, header.Kind)
+	}
+	return nil
+}
+
+func missingField(kind string, field string) error {
+	return fmt.Errorf("Missing field '%s' in %s.", field, kind)
+}
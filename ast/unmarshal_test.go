@@ -0,0 +1,122 @@
+package ast
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GivenMarshaledProgram_WhenUnmarshalProgram_ThenShouldReturnSameProgram(t *testing.T) {
+	data, err := json.Marshal(walkTestProgram)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	program, err := UnmarshalProgram(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, walkTestProgram, program)
+}
+
+func Test_GivenEmptyProgram_WhenUnmarshalProgram_ThenShouldReturnEmptyProgram(t *testing.T) {
+	program, err := UnmarshalProgram([]byte(`{"Kind":"Program","Statements":null}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, Program{}, program)
+}
+
+func Test_GivenInvalidJSON_WhenUnmarshalProgram_ThenShouldReturnCorrectError(t *testing.T) {
+	type TestCase struct {
+		name          string
+		data          string
+		expectedError error
+	}
+
+	testcases := []TestCase{
+		{
+			name:          "wrong root kind",
+			data:          `{"Kind":"ExpressionStatement"}`,
+			expectedError: fmt.Errorf("Expected node kind 'Program' but found 'ExpressionStatement'."),
+		},
+		{
+			name:          "unknown node kind",
+			data:          `{"Kind":"Program","Statements":[{"Kind":"IfStatement"}]}`,
+			expectedError: fmt.Errorf("Unknown node kind 'IfStatement'."),
+		},
+		{
+			name:          "missing node kind",
+			data:          `{"Kind":"Program","Statements":[{"Expression":null}]}`,
+			expectedError: fmt.Errorf(`Missing node kind in {"Expression":null}.`),
+		},
+		{
+			name:          "expression where statement is expected",
+			data:          `{"Kind":"Program","Statements":[{"Kind":"NumericLiteralExpression","Value":1}]}`,
+			expectedError: fmt.Errorf("Expected a statement but found 'ast.NumericLiteralExpression'."),
+		},
+		{
+			name:          "statement where expression is expected",
+			data:          `{"Kind":"Program","Statements":[{"Kind":"ExpressionStatement","Expression":{"Kind":"Program"}}]}`,
+			expectedError: fmt.Errorf("Expected an expression but found 'ast.Program'."),
+		},
+		{
+			name:          "missing expression",
+			data:          `{"Kind":"Program","Statements":[{"Kind":"ExpressionStatement"}]}`,
+			expectedError: fmt.Errorf("Missing expression."),
+		},
+		{
+			name:          "missing literal value",
+			data:          `{"Kind":"Program","Statements":[{"Kind":"ExpressionStatement","Expression":{"Kind":"NumericLiteralExpression"}}]}`,
+			expectedError: fmt.Errorf("Missing field 'Value' in NumericLiteralExpression."),
+		},
+		{
+			name:          "missing declaration identifier",
+			data:          `{"Kind":"Program","Statements":[{"Kind":"VariableDeclaration","Value":{"Kind":"IdentifierExpression","Symbol":"x"}}]}`,
+			expectedError: fmt.Errorf("Missing field 'Identifier' in VariableDeclaration."),
+		},
+		{
+			name:          "invalid operator",
+			data:          `{"Kind":"Program","Statements":[{"Kind":"ExpressionStatement","Expression":{"Kind":"BinaryExpression","Operator":"**"}}]}`,
+			expectedError: fmt.Errorf("Invalid operator '**' in BinaryExpression."),
+		},
+		{
+			name:          "unknown operator",
+			data:          `{"Kind":"Program","Statements":[{"Kind":"ExpressionStatement","Expression":{"Kind":"BinaryExpression","Operator":"%"}}]}`,
+			expectedError: fmt.Errorf("Invalid operator '%%' in BinaryExpression."),
+		},
+		{
+			name:          "unknown unary operator",
+			data:          `{"Kind":"Program","Statements":[{"Kind":"ExpressionStatement","Expression":{"Kind":"UnaryExpression","Operator":"+"}}]}`,
+			expectedError: fmt.Errorf("Invalid operator '+' in UnaryExpression."),
+		},
+	}
+
+	for _, test := range testcases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := UnmarshalProgram([]byte(test.data))
+			assert.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_GivenJSONSchema_WhenDecoded_ThenShouldDescribeEveryNodeKind(t *testing.T) {
+	var schema struct {
+		Defs map[string]json.RawMessage `json:"$defs"`
+	}
+	if err := json.Unmarshal(JSONSchema, &schema); err != nil {
+		t.Fatal(err)
+	}
+
+	Inspect(walkTestProgram, func(node Node) bool {
+		if node != nil {
+			assert.Contains(t, schema.Defs, fmt.Sprintf("%T", node)[len("ast."):])
+		}
+		return true
+	})
+}
@@ -0,0 +1,62 @@
+package ast
+
+import "encoding/json"
+
+type VariableDeclaration struct {
+	Constant   bool
+	Identifier string
+	Type       string
+	Value      Expression
+	Span       Span
+}
+
+func (VariableDeclaration) node() {}
+
+func (VariableDeclaration) statement() {}
+
+func (s VariableDeclaration) MarshalJSON() ([]byte, error) {
+	fields := map[string]any{
+		"Kind":       "VariableDeclaration",
+		"Constant":   s.Constant,
+		"Identifier": s.Identifier,
+		"Value":      s.Value,
+		"Span":       s.Span,
+	}
+	if s.Type != "" {
+		fields["Type"] = s.Type
+	}
+	return json.Marshal(fields)
+}
+
+func (s *VariableDeclaration) UnmarshalJSON(data []byte) error {
+	if err := checkKind(data, "VariableDeclaration"); err != nil {
+		return err
+	}
+
+	var fields struct {
+		Constant   bool
+		Identifier *string
+		Type       string
+		Value      json.RawMessage
+		Span       Span
+	}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	if fields.Identifier == nil {
+		return missingField("VariableDeclaration", "Identifier")
+	}
+
+	value, err := unmarshalExpression(fields.Value)
+	if err != nil {
+		return err
+	}
+
+	s.Constant = fields.Constant
+	s.Identifier = *fields.Identifier
+	s.Type = fields.Type
+	s.Value = value
+	s.Span = fields.Span
+	return nil
+}
@@ -0,0 +1,74 @@
+package ast
+
+import "fmt"
+
+type Visitor interface {
+	Visit(node Node) Visitor
+}
+
+func Walk(v Visitor, node Node) {
+	if v = v.Visit(node); v == nil {
+		return
+	}
+
+	switch n := node.(type) {
+	case Program:
+		for _, statement := range n.Statements {
+			Walk(v, statement)
+		}
+	case BlockStatement:
+		for _, statement := range n.Statements {
+			Walk(v, statement)
+		}
+	case ExpressionStatement:
+		Walk(v, n.Expression)
+	case VariableDeclaration:
+		Walk(v, n.Value)
+	case FunctionDeclaration:
+		Walk(v, n.Body)
+	case ReturnStatement:
+		if n.Value != nil {
+			Walk(v, n.Value)
+		}
+	case TryStatement:
+		Walk(v, n.Body)
+		Walk(v, n.Handler)
+	case CallExpression:
+		Walk(v, n.Callee)
+		for _, argument := range n.Arguments {
+			Walk(v, argument)
+		}
+	case MemberExpression:
+		Walk(v, n.Object)
+	case ObjectExpression:
+		for _, property := range n.Properties {
+			Walk(v, property.Value)
+		}
+	case AssignmentExpression:
+		Walk(v, n.Target)
+		Walk(v, n.Value)
+	case BinaryExpression:
+		Walk(v, n.Left)
+		Walk(v, n.Right)
+	case UnaryExpression:
+		Walk(v, n.Operand)
+	case NumericLiteralExpression, StringLiteralExpression, IdentifierExpression:
+	default:
+		panic(fmt.Sprintf("ast.Walk: unexpected node type %T", n))
+	}
+
+	v.Visit(nil)
+}
+
+type inspector func(Node) bool
+
+func (f inspector) Visit(node Node) Visitor {
+	if f(node) {
+		return f
+	}
+	return nil
+}
+
+func Inspect(node Node, f func(Node) bool) {
+	Walk(inspector(f), node)
+}
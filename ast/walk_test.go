@@ -0,0 +1,175 @@
+package ast
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var walkTestProgram = Program{
+	Statements: []Statement{
+		VariableDeclaration{
+			Identifier: "x",
+			Value: BinaryExpression{
+				Left:     NumericLiteralExpression{Value: 1},
+				Operator: '+',
+				Right:    UnaryExpression{Operator: '-', Operand: NumericLiteralExpression{Value: 2}},
+			},
+		},
+		ExpressionStatement{
+			Expression: BinaryExpression{
+				Left:     IdentifierExpression{Symbol: "x"},
+				Operator: '*',
+				Right:    StringLiteralExpression{Value: "a"},
+			},
+		},
+		BlockStatement{
+			Statements: []Statement{
+				ExpressionStatement{
+					Expression: AssignmentExpression{
+						Target: IdentifierExpression{Symbol: "x"},
+						Value:  NumericLiteralExpression{Value: 4},
+					},
+				},
+			},
+		},
+	},
+}
+
+type recordingVisitor struct {
+	visited *[]string
+}
+
+func (v recordingVisitor) Visit(node Node) Visitor {
+	if node == nil {
+		*v.visited = append(*v.visited, "end")
+		return nil
+	}
+
+	*v.visited = append(*v.visited, fmt.Sprintf("%T", node))
+	return v
+}
+
+func Test_GivenProgram_WhenWalk_ThenShouldVisitNodesInDepthFirstOrder(t *testing.T) {
+	visited := []string{}
+	Walk(recordingVisitor{visited: &visited}, walkTestProgram)
+
+	assert.Equal(t, []string{
+		"ast.Program",
+		"ast.VariableDeclaration",
+		"ast.BinaryExpression",
+		"ast.NumericLiteralExpression",
+		"end",
+		"ast.UnaryExpression",
+		"ast.NumericLiteralExpression",
+		"end",
+		"end",
+		"end",
+		"end",
+		"ast.ExpressionStatement",
+		"ast.BinaryExpression",
+		"ast.IdentifierExpression",
+		"end",
+		"ast.StringLiteralExpression",
+		"end",
+		"end",
+		"end",
+		"ast.BlockStatement",
+		"ast.ExpressionStatement",
+		"ast.AssignmentExpression",
+		"ast.IdentifierExpression",
+		"end",
+		"ast.NumericLiteralExpression",
+		"end",
+		"end",
+		"end",
+		"end",
+		"end",
+	}, visited)
+}
+
+func Test_GivenProgram_WhenInspectReturnsFalse_ThenShouldSkipChildren(t *testing.T) {
+	visited := []string{}
+	Inspect(walkTestProgram, func(node Node) bool {
+		if node == nil {
+			return false
+		}
+
+		visited = append(visited, fmt.Sprintf("%T", node))
+		_, isDeclaration := node.(VariableDeclaration)
+		return !isDeclaration
+	})
+
+	assert.Equal(t, []string{
+		"ast.Program",
+		"ast.VariableDeclaration",
+		"ast.ExpressionStatement",
+		"ast.BinaryExpression",
+		"ast.IdentifierExpression",
+		"ast.StringLiteralExpression",
+		"ast.BlockStatement",
+		"ast.ExpressionStatement",
+		"ast.AssignmentExpression",
+		"ast.IdentifierExpression",
+		"ast.NumericLiteralExpression",
+	}, visited)
+}
+
+func Test_GivenProgram_WhenRewrite_ThenShouldReplaceNodesBottomUp(t *testing.T) {
+	rewritten := Rewrite(walkTestProgram, func(node Node) Node {
+		switch n := node.(type) {
+		case IdentifierExpression:
+			return IdentifierExpression{Symbol: n.Symbol + "_renamed"}
+		case UnaryExpression:
+			if operand, ok := n.Operand.(NumericLiteralExpression); ok && n.Operator == '-' {
+				return NumericLiteralExpression{Value: -operand.Value}
+			}
+		case BinaryExpression:
+			left, leftOk := n.Left.(NumericLiteralExpression)
+			right, rightOk := n.Right.(NumericLiteralExpression)
+			if leftOk && rightOk && n.Operator == '+' {
+				return NumericLiteralExpression{Value: left.Value + right.Value}
+			}
+		}
+		return node
+	})
+
+	assert.Equal(t, Program{
+		Statements: []Statement{
+			VariableDeclaration{
+				Identifier: "x",
+				Value:      NumericLiteralExpression{Value: -1},
+			},
+			ExpressionStatement{
+				Expression: BinaryExpression{
+					Left:     IdentifierExpression{Symbol: "x_renamed"},
+					Operator: '*',
+					Right:    StringLiteralExpression{Value: "a"},
+				},
+			},
+			BlockStatement{
+				Statements: []Statement{
+					ExpressionStatement{
+						Expression: AssignmentExpression{
+							Target: IdentifierExpression{Symbol: "x_renamed"},
+							Value:  NumericLiteralExpression{Value: 4},
+						},
+					},
+				},
+			},
+		},
+	}, rewritten)
+	assert.Equal(t, IdentifierExpression{Symbol: "x"}, walkTestProgram.Statements[1].(ExpressionStatement).Expression.(BinaryExpression).Left)
+}
+
+func Test_GivenInvalidReplacement_WhenRewrite_ThenShouldPanic(t *testing.T) {
+	assert.PanicsWithValue(t, "ast.Rewrite: cannot replace expression ast.IdentifierExpression with ast.ExpressionStatement", func() {
+		Rewrite(walkTestProgram, func(node Node) Node {
+			if identifier, ok := node.(IdentifierExpression); ok {
+				return ExpressionStatement{Expression: identifier}
+			}
+			return node
+		})
+	})
+}
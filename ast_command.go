@@ -0,0 +1,57 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/joaovictorjs/adam-script/parser"
+	"github.com/joaovictorjs/adam-script/render"
+)
+
+func runAst(args []string) int {
+	flags := flag.NewFlagSet("ast", flag.ExitOnError)
+	formatName := flags.String("format", string(render.FormatSExpr), "output format: json, sexpr or dot")
+	flags.Parse(args)
+
+	format, err := render.ParseFormat(*formatName)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+
+	name, source, err := readSource(flags.Args())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	program, err := parser.NewParser(string(source)).Parse()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %s\n", name, err)
+		return 1
+	}
+
+	rendered, err := render.Render(program, format)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	fmt.Print(rendered)
+	return 0
+}
+
+func readSource(args []string) (string, []byte, error) {
+	switch len(args) {
+	case 0:
+		source, err := io.ReadAll(os.Stdin)
+		return "<standard input>", source, err
+	case 1:
+		source, err := os.ReadFile(args[0])
+		return args[0], source, err
+	default:
+		return "", nil, fmt.Errorf("Expected at most one source file but got %d.", len(args))
+	}
+}
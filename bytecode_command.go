@@ -0,0 +1,177 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/joaovictorjs/adam-script/ast"
+	"github.com/joaovictorjs/adam-script/compiler"
+	"github.com/joaovictorjs/adam-script/diagnostic"
+	"github.com/joaovictorjs/adam-script/optimize"
+	"github.com/joaovictorjs/adam-script/parser"
+	"github.com/joaovictorjs/adam-script/sandbox"
+	"github.com/joaovictorjs/adam-script/stdlib"
+	"github.com/joaovictorjs/adam-script/value"
+	"github.com/joaovictorjs/adam-script/vm"
+)
+
+const moduleExtension = ".adamc"
+
+const optimizeUsage = "fold constant expressions and simplify identities before compiling, modules are used as they are"
+
+func runBuild(args []string) int {
+	flags := flag.NewFlagSet("build", flag.ExitOnError)
+	output := flags.String("o", "", "output file, defaults to the source file with the "+moduleExtension+" extension")
+	fromAST := flags.Bool("ast", false, "read the source file as a JSON AST, as printed by 'adam ast -format json'")
+	optimized := flags.Bool("O", false, optimizeUsage)
+	flags.Parse(args)
+
+	if flags.NArg() != 1 {
+		fmt.Fprintf(os.Stderr, "Expected exactly one source file but got %d.\n", flags.NArg())
+		return 2
+	}
+
+	path := flags.Arg(0)
+	function, _, err := loadFunction(path, *fromAST, *optimized)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %s\n", path, err)
+		return 1
+	}
+
+	if *output == "" {
+		*output = strings.TrimSuffix(path, filepath.Ext(path)) + moduleExtension
+	}
+
+	var buffer bytes.Buffer
+	if err := compiler.Encode(&buffer, function); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	if err := os.WriteFile(*output, buffer.Bytes(), 0o644); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	return 0
+}
+
+func runDisasm(args []string) int {
+	flags := flag.NewFlagSet("disasm", flag.ExitOnError)
+	optimized := flags.Bool("O", false, optimizeUsage)
+	flags.Parse(args)
+
+	if flags.NArg() != 1 {
+		fmt.Fprintf(os.Stderr, "Expected exactly one source or %s file but got %d.\n", moduleExtension, flags.NArg())
+		return 2
+	}
+
+	path := flags.Arg(0)
+	function, source, err := loadFunction(path, false, *optimized)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %s\n", path, err)
+		return 1
+	}
+
+	fmt.Print(compiler.Disassemble(function, source))
+	return 0
+}
+
+func runRun(args []string) int {
+	flags := flag.NewFlagSet("run", flag.ExitOnError)
+	var permissions sandbox.Permissions
+	flags.Var(&permissions.Read, "allow-read", "allow reading files, optionally only beneath a comma separated list of paths")
+	flags.Var(&permissions.Write, "allow-write", "allow writing files, optionally only beneath a comma separated list of paths")
+	flags.Var(&permissions.Env, "allow-env", "allow reading environment variables, optionally only a comma separated list of names")
+	allowAll := flags.Bool("allow-all", false, "allow every capability")
+	root := flags.String("root", ".", "directory the fs module resolves paths against")
+	fromAST := flags.Bool("ast", false, "read the file as a JSON AST, as printed by 'adam ast -format json'")
+	optimized := flags.Bool("O", false, optimizeUsage)
+	flags.Parse(args)
+
+	if *allowAll {
+		permissions = sandbox.AllowAll()
+	}
+
+	if flags.NArg() != 1 {
+		fmt.Fprintf(os.Stderr, "Expected exactly one source or %s file but got %d.\n", moduleExtension, flags.NArg())
+		return 2
+	}
+
+	path := flags.Arg(0)
+	function, _, err := loadFunction(path, *fromAST, *optimized)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %s\n", path, err)
+		return 1
+	}
+
+	fsys, err := stdlib.DirFS(*root)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	machine := vm.New()
+	if err := stdlib.Install(machine, stdlib.Options{Permissions: permissions, FS: fsys}); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	result, err := machine.Run(context.Background(), function)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %s\n", path, err)
+		return 1
+	}
+
+	if _, ok := result.(value.Null); !ok {
+		fmt.Println(value.Inspect(result))
+	}
+	return 0
+}
+
+// loadFunction compiles the source or JSON AST at path or decodes it when it
+// is a module. With optimized set the program goes through the optimize pass
+// first and its warnings are reported on stderr. The source is returned for
+// disassembly, it is empty for modules and JSON ASTs.
+func loadFunction(path string, fromAST bool, optimized bool) (*compiler.Function, string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if !fromAST && filepath.Ext(path) == moduleExtension {
+		function, err := compiler.Decode(bytes.NewReader(data))
+		return function, "", err
+	}
+
+	var program ast.Program
+	source := ""
+	if fromAST {
+		program, err = ast.UnmarshalProgram(data)
+	} else {
+		source = string(data)
+		program, err = parser.NewParser(source).Parse()
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	if optimized {
+		var diagnostics []diagnostic.Diagnostic
+		program, diagnostics = optimize.Optimize(program)
+		for _, d := range diagnostics {
+			if fromAST {
+				fmt.Fprintf(os.Stderr, "%s: %s\n", path, d)
+			} else {
+				fmt.Fprintf(os.Stderr, "%s:%s\n", path, d.Format(source))
+			}
+		}
+	}
+
+	function, err := compiler.Compile(program)
+	return function, source, err
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"cmp"
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/joaovictorjs/adam-script/diagnostic"
+	"github.com/joaovictorjs/adam-script/parser"
+	"github.com/joaovictorjs/adam-script/resolver"
+	"github.com/joaovictorjs/adam-script/stdlib"
+	"github.com/joaovictorjs/adam-script/types"
+)
+
+func runCheck(args []string) int {
+	flags := flag.NewFlagSet("check", flag.ExitOnError)
+	strict := flags.Bool("strict", false, "also check type annotations and operand types")
+	flags.Parse(args)
+
+	name, source, err := readSource(flags.Args())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	program, err := parser.NewParser(string(source)).Parse()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %s\n", name, err)
+		return 1
+	}
+
+	resolution := resolver.Resolve(program, resolver.WithGlobals(stdlib.Names()...))
+	diagnostics := resolution.Diagnostics
+	if *strict {
+		diagnostics = append(diagnostics, types.Check(program, resolution).Diagnostics...)
+		slices.SortStableFunc(diagnostics, func(a, b diagnostic.Diagnostic) int {
+			return cmp.Compare(a.Span.Start, b.Span.Start)
+		})
+	}
+
+	for _, d := range diagnostics {
+		fmt.Printf("%s:%s\n", name, d.Format(string(source)))
+	}
+
+	if diagnostic.HasErrors(diagnostics) {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,43 @@
+package compiler
+
+import (
+	"encoding/binary"
+
+	"github.com/joaovictorjs/adam-script/ast"
+	"github.com/joaovictorjs/adam-script/value"
+)
+
+type Chunk struct {
+	Code      []byte
+	Constants []value.Value
+	Spans     []ast.Span
+}
+
+func (c *Chunk) ReadUint16(offset int) int {
+	return int(binary.BigEndian.Uint16(c.Code[offset:]))
+}
+
+func (c *Chunk) write(b byte, span ast.Span) {
+	c.Code = append(c.Code, b)
+	c.Spans = append(c.Spans, span)
+}
+
+func (c *Chunk) writeUint16(v int, span ast.Span) {
+	c.write(byte(v>>8), span)
+	c.write(byte(v), span)
+}
+
+type Function struct {
+	Name     string
+	Arity    int
+	Upvalues int
+	Chunk    Chunk
+}
+
+func (f *Function) Type() string {
+	return "function"
+}
+
+func (f *Function) String() string {
+	return "<fn " + f.Name + ">"
+}
@@ -0,0 +1,620 @@
+package compiler
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+
+	"github.com/joaovictorjs/adam-script/ast"
+	"github.com/joaovictorjs/adam-script/value"
+)
+
+const (
+	maxLocals     = math.MaxUint8 + 1
+	maxUpvalues   = math.MaxUint8 + 1
+	maxArguments  = math.MaxUint8
+	maxProperties = math.MaxUint16
+	maxConstants  = math.MaxUint16 + 1
+	maxJump       = math.MaxUint16
+)
+
+const ScriptName = "<script>"
+
+const noResult = -1
+
+type local struct {
+	name     string
+	depth    int
+	constant bool
+	captured bool
+	declared bool
+}
+
+type upvalue struct {
+	index    int
+	isLocal  bool
+	constant bool
+}
+
+type compiler struct {
+	enclosing  *compiler
+	function   *Function
+	locals     []local
+	upvalues   []upvalue
+	scopeDepth int
+}
+
+func Compile(program ast.Program) (*Function, error) {
+	c := newCompiler(nil, ScriptName, 0)
+	if err := c.compileStatements(program.Statements, true); err != nil {
+		return nil, err
+	}
+	c.emit(OpReturn, ast.Span{Start: program.Span.End, End: program.Span.End})
+	return c.function, nil
+}
+
+func newCompiler(enclosing *compiler, name string, scopeDepth int) *compiler {
+	return &compiler{
+		enclosing:  enclosing,
+		function:   &Function{Name: name},
+		locals:     []local{{depth: scopeDepth, declared: true}},
+		scopeDepth: scopeDepth,
+	}
+}
+
+func (c *compiler) compileStatements(statements []ast.Statement, wantValue bool) error {
+	if err := c.reserveLocals(statements); err != nil {
+		return err
+	}
+
+	for _, statement := range statements {
+		if declaration, ok := statement.(ast.FunctionDeclaration); ok {
+			if err := c.compileFunctionDeclaration(declaration); err != nil {
+				return err
+			}
+		}
+	}
+
+	for index, statement := range statements {
+		last := wantValue && index == len(statements)-1
+		if err := c.compileStatement(statement, last); err != nil {
+			return err
+		}
+	}
+
+	if wantValue && len(statements) == 0 {
+		c.emit(OpNull, ast.Span{})
+	}
+	return nil
+}
+
+func (c *compiler) compileStatement(statement ast.Statement, wantValue bool) error {
+	span := ast.SpanOf(statement)
+	switch s := statement.(type) {
+	case ast.ExpressionStatement:
+		if err := c.compileExpression(s.Expression); err != nil {
+			return err
+		}
+		if !wantValue {
+			c.emit(OpPop, span)
+		}
+	case ast.VariableDeclaration:
+		if err := c.compileExpression(s.Value); err != nil {
+			return err
+		}
+		if err := c.defineVariable(s.Identifier, s.Constant, span); err != nil {
+			return err
+		}
+		if wantValue {
+			c.emit(OpNull, span)
+		}
+	case ast.BlockStatement:
+		return c.compileBlock(s, wantValue)
+	case ast.FunctionDeclaration:
+		if wantValue {
+			c.emit(OpNull, span)
+		}
+	case ast.ReturnStatement:
+		if s.Value == nil {
+			c.emit(OpNull, span)
+		} else if err := c.compileExpression(s.Value); err != nil {
+			return err
+		}
+		c.emit(OpReturn, span)
+	case ast.TryStatement:
+		return c.compileTry(s, wantValue)
+	default:
+		return fmt.Errorf("Unsupported statement '%T'.", statement)
+	}
+	return nil
+}
+
+func (c *compiler) compileBlock(block ast.BlockStatement, wantValue bool) error {
+	if !wantValue {
+		return c.compileScope(block.Statements, "", noResult, block.Span)
+	}
+
+	result, err := c.addResult(block.Span)
+	if err != nil {
+		return err
+	}
+	if err := c.compileScope(block.Statements, "", result, block.Span); err != nil {
+		return err
+	}
+
+	c.locals = c.locals[:result]
+	return nil
+}
+
+// compileTry protects the body with a handler that the VM jumps to when a
+// catchable error is raised, with the stack unwound to its height at OpTry
+// and the error value pushed where the catch parameter's local lives.
+func (c *compiler) compileTry(statement ast.TryStatement, wantValue bool) error {
+	span := statement.Span
+	result := noResult
+	if wantValue {
+		var err error
+		if result, err = c.addResult(span); err != nil {
+			return err
+		}
+	}
+
+	handler := c.emitJump(OpTry, span)
+	if err := c.compileScope(statement.Body.Statements, "", result, statement.Body.Span); err != nil {
+		return err
+	}
+	c.emit(OpEndTry, statement.Body.Span)
+	end := c.emitJump(OpJump, statement.Body.Span)
+
+	if err := c.patchJump(handler); err != nil {
+		return err
+	}
+	if err := c.compileScope(statement.Handler.Statements, statement.Parameter.Name, result, statement.Handler.Span); err != nil {
+		return err
+	}
+	if err := c.patchJump(end); err != nil {
+		return err
+	}
+
+	if wantValue {
+		c.locals = c.locals[:result]
+	}
+	return nil
+}
+
+func (c *compiler) addResult(span ast.Span) (int, error) {
+	c.emit(OpNull, span)
+	if err := c.addLocal("", false); err != nil {
+		return 0, err
+	}
+	return len(c.locals) - 1, nil
+}
+
+func (c *compiler) compileScope(statements []ast.Statement, parameter string, result int, span ast.Span) error {
+	c.beginScope()
+	if parameter != "" {
+		if err := c.addLocal(parameter, false); err != nil {
+			return err
+		}
+		c.locals[len(c.locals)-1].declared = true
+	}
+
+	if err := c.compileStatements(statements, result != noResult); err != nil {
+		return err
+	}
+	if result != noResult {
+		c.emitByte(OpSetLocal, result, span)
+		c.emit(OpPop, span)
+	}
+	c.endScope(span)
+	return nil
+}
+
+func (c *compiler) compileFunctionDeclaration(declaration ast.FunctionDeclaration) error {
+	span := declaration.Span
+	fc := newCompiler(c, declaration.Name, 1)
+	fc.function.Arity = len(declaration.Parameters)
+	for _, parameter := range declaration.Parameters {
+		if err := fc.addLocal(parameter.Name, false); err != nil {
+			return err
+		}
+		fc.locals[len(fc.locals)-1].declared = true
+	}
+
+	if err := fc.compileStatements(declaration.Body.Statements, false); err != nil {
+		return err
+	}
+	fc.emit(OpNull, declaration.Body.Span)
+	fc.emit(OpReturn, declaration.Body.Span)
+	fc.function.Upvalues = len(fc.upvalues)
+
+	index, err := c.addConstant(fc.function)
+	if err != nil {
+		return err
+	}
+	c.emitUint16(OpClosure, index, span)
+	for _, upvalue := range fc.upvalues {
+		isLocal := 0
+		if upvalue.isLocal {
+			isLocal = 1
+		}
+		c.function.Chunk.write(byte(isLocal), span)
+		c.function.Chunk.write(byte(upvalue.index), span)
+	}
+
+	return c.defineVariable(declaration.Name, false, span)
+}
+
+func (c *compiler) compileExpression(expression ast.Expression) error {
+	span := ast.SpanOf(expression)
+	switch e := expression.(type) {
+	case ast.NumericLiteralExpression:
+		return c.emitConstant(value.Number(e.Value), span)
+	case ast.StringLiteralExpression:
+		return c.emitConstant(value.String(e.Value), span)
+	case ast.IdentifierExpression:
+		return c.compileVariable(e.Symbol, false, span)
+	case ast.AssignmentExpression:
+		if member, ok := e.Target.(ast.MemberExpression); ok {
+			if err := c.compileExpression(member.Object); err != nil {
+				return err
+			}
+			if err := c.compileExpression(e.Value); err != nil {
+				return err
+			}
+			return c.emitName(OpSetMember, member.Property, span)
+		}
+
+		if err := c.compileExpression(e.Value); err != nil {
+			return err
+		}
+		return c.compileVariable(e.Target.(ast.IdentifierExpression).Symbol, true, span)
+	case ast.MemberExpression:
+		if err := c.compileExpression(e.Object); err != nil {
+			return err
+		}
+		return c.emitName(OpGetMember, e.Property, span)
+	case ast.BinaryExpression:
+		if err := c.compileExpression(e.Left); err != nil {
+			return err
+		}
+		if err := c.compileExpression(e.Right); err != nil {
+			return err
+		}
+
+		opcode, ok := binaryOpcodes[e.Operator]
+		if !ok {
+			return fmt.Errorf("Unsupported operator '%c'.", e.Operator)
+		}
+		c.emit(opcode, span)
+	case ast.UnaryExpression:
+		if err := c.compileExpression(e.Operand); err != nil {
+			return err
+		}
+		if e.Operator != '-' {
+			return fmt.Errorf("Unsupported operator '%c'.", e.Operator)
+		}
+		c.emit(OpNegate, span)
+	case ast.CallExpression:
+		if err := c.compileExpression(e.Callee); err != nil {
+			return err
+		}
+		if len(e.Arguments) > maxArguments {
+			return fmt.Errorf("Too many arguments in call, the limit is %d.", maxArguments)
+		}
+		for _, argument := range e.Arguments {
+			if err := c.compileExpression(argument); err != nil {
+				return err
+			}
+		}
+		c.emitByte(OpCall, len(e.Arguments), span)
+	case ast.ObjectExpression:
+		if len(e.Properties) > maxProperties {
+			return fmt.Errorf("Too many properties in object, the limit is %d.", maxProperties)
+		}
+		for _, property := range e.Properties {
+			if err := c.emitConstant(value.String(property.Key), property.Span); err != nil {
+				return err
+			}
+			if err := c.compileExpression(property.Value); err != nil {
+				return err
+			}
+		}
+		c.emitUint16(OpMap, len(e.Properties), span)
+	default:
+		return fmt.Errorf("Unsupported expression '%T'.", expression)
+	}
+	return nil
+}
+
+func (c *compiler) compileVariable(name string, assign bool, span ast.Span) error {
+	if slot, ok := c.resolveLocal(name); ok {
+		if !assign {
+			c.emitByte(OpGetLocal, slot, span)
+			return nil
+		}
+		if c.locals[slot].constant {
+			return fmt.Errorf("Cannot assign to constant '%s'.", name)
+		}
+		c.emitByte(OpSetLocal, slot, span)
+		return nil
+	}
+
+	indexes, declared, err := c.resolveUpvalues(name)
+	if err != nil {
+		return err
+	}
+	if assign {
+		for _, index := range indexes {
+			if c.upvalues[index].constant {
+				return fmt.Errorf("Cannot assign to constant '%s'.", name)
+			}
+		}
+	}
+
+	last, global := OpGetUpvalue, OpGetGlobal
+	if assign {
+		last, global = OpSetUpvalue, OpSetGlobal
+	}
+
+	// Every upvalue but a declared last one may still be undefined, the
+	// first defined one is used and the global is the last resort.
+	found := []int{}
+	for i, index := range indexes {
+		if declared && i == len(indexes)-1 {
+			c.emitByte(last, index, span)
+			break
+		}
+		c.emitByte(OpGetUpvalue, index, span)
+		found = append(found, c.emitJump(OpJumpIfDefined, span))
+	}
+	if !declared {
+		if err := c.emitName(global, name, span); err != nil {
+			return err
+		}
+	}
+	if len(found) == 0 {
+		return nil
+	}
+
+	done := []int{}
+	if assign {
+		done = append(done, c.emitJump(OpJump, span))
+	}
+	for i, jump := range found {
+		if err := c.patchJump(jump); err != nil {
+			return err
+		}
+		if assign {
+			c.emit(OpPop, span)
+			c.emitByte(OpSetUpvalue, indexes[i], span)
+			if i < len(found)-1 {
+				done = append(done, c.emitJump(OpJump, span))
+			}
+		}
+	}
+	for _, jump := range done {
+		if err := c.patchJump(jump); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *compiler) resolveLocal(name string) (int, bool) {
+	return c.findLocal(name, false)
+}
+
+func (c *compiler) findLocal(name string, includeReserved bool) (int, bool) {
+	for slot := len(c.locals) - 1; slot > 0; slot-- {
+		if c.locals[slot].name == name && (c.locals[slot].declared || includeReserved) {
+			return slot, true
+		}
+	}
+	return 0, false
+}
+
+// resolveUpvalues captures every enclosing local name can refer to, from
+// the innermost out. Locals reserved for a declaration that was not compiled
+// yet may still be undefined when the function runs, so the search goes on
+// until a declared one, which reports declared, or runs out of enclosing
+// functions, which leaves the global.
+func (c *compiler) resolveUpvalues(name string) ([]int, bool, error) {
+	if c.enclosing == nil {
+		return nil, false, nil
+	}
+
+	indexes := []int{}
+	enclosing := c.enclosing
+	for slot := len(enclosing.locals) - 1; slot > 0; slot-- {
+		if enclosing.locals[slot].name != name {
+			continue
+		}
+
+		enclosing.locals[slot].captured = true
+		index, err := c.addUpvalue(slot, true, enclosing.locals[slot].constant)
+		if err != nil {
+			return nil, false, err
+		}
+		indexes = append(indexes, index)
+		if enclosing.locals[slot].declared {
+			return indexes, true, nil
+		}
+	}
+
+	outer, declared, err := enclosing.resolveUpvalues(name)
+	if err != nil {
+		return nil, false, err
+	}
+	for _, index := range outer {
+		index, err := c.addUpvalue(index, false, enclosing.upvalues[index].constant)
+		if err != nil {
+			return nil, false, err
+		}
+		indexes = append(indexes, index)
+	}
+	return indexes, declared, nil
+}
+
+func (c *compiler) addUpvalue(index int, isLocal bool, constant bool) (int, error) {
+	for i, existing := range c.upvalues {
+		if existing.index == index && existing.isLocal == isLocal {
+			return i, nil
+		}
+	}
+
+	if len(c.upvalues) >= maxUpvalues {
+		return 0, fmt.Errorf("Too many captured variables in function '%s'.", c.function.Name)
+	}
+
+	c.upvalues = append(c.upvalues, upvalue{index: index, isLocal: isLocal, constant: constant})
+	return len(c.upvalues) - 1, nil
+}
+
+func (c *compiler) reserveLocals(statements []ast.Statement) error {
+	if c.scopeDepth == 0 {
+		return nil
+	}
+
+	for _, statement := range statements {
+		switch s := statement.(type) {
+		case ast.VariableDeclaration:
+			if err := c.addLocal(s.Identifier, s.Constant); err != nil {
+				return err
+			}
+		case ast.FunctionDeclaration:
+			if err := c.addLocal(s.Name, false); err != nil {
+				return err
+			}
+		default:
+			continue
+		}
+		c.emit(OpUndefined, ast.SpanOf(statement))
+	}
+	return nil
+}
+
+func (c *compiler) defineVariable(name string, constant bool, span ast.Span) error {
+	if c.scopeDepth == 0 {
+		return c.defineGlobal(name, constant, span)
+	}
+
+	slot, _ := c.findLocal(name, true)
+	c.locals[slot].declared = true
+	c.emitByte(OpSetLocal, slot, span)
+	c.emit(OpPop, span)
+	return nil
+}
+
+func (c *compiler) defineGlobal(name string, constant bool, span ast.Span) error {
+	index, err := c.addConstant(value.String(name))
+	if err != nil {
+		return err
+	}
+
+	flag := 0
+	if constant {
+		flag = 1
+	}
+	c.emitUint16(OpDefineGlobal, index, span)
+	c.function.Chunk.write(byte(flag), span)
+	return nil
+}
+
+func (c *compiler) addLocal(name string, constant bool) error {
+	if name != "" {
+		for slot := len(c.locals) - 1; slot > 0 && c.locals[slot].depth == c.scopeDepth; slot-- {
+			if c.locals[slot].name == name {
+				return fmt.Errorf("Variable '%s' is already declared.", name)
+			}
+		}
+	}
+
+	if len(c.locals) >= maxLocals {
+		return fmt.Errorf("Too many local variables in function '%s'.", c.function.Name)
+	}
+
+	c.locals = append(c.locals, local{name: name, depth: c.scopeDepth, constant: constant})
+	return nil
+}
+
+func (c *compiler) beginScope() {
+	c.scopeDepth++
+}
+
+func (c *compiler) endScope(span ast.Span) {
+	c.scopeDepth--
+	for len(c.locals) > 1 && c.locals[len(c.locals)-1].depth > c.scopeDepth {
+		if c.locals[len(c.locals)-1].captured {
+			c.emit(OpCloseUpvalue, span)
+		} else {
+			c.emit(OpPop, span)
+		}
+		c.locals = c.locals[:len(c.locals)-1]
+	}
+}
+
+func (c *compiler) addConstant(v value.Value) (int, error) {
+	chunk := &c.function.Chunk
+	if str, ok := v.(value.String); ok {
+		for index, constant := range chunk.Constants {
+			if existing, ok := constant.(value.String); ok && existing == str {
+				return index, nil
+			}
+		}
+	}
+
+	if len(chunk.Constants) >= maxConstants {
+		return 0, fmt.Errorf("Too many constants in function '%s'.", c.function.Name)
+	}
+
+	chunk.Constants = append(chunk.Constants, v)
+	return len(chunk.Constants) - 1, nil
+}
+
+func (c *compiler) emit(opcode Opcode, span ast.Span) {
+	c.function.Chunk.write(byte(opcode), span)
+}
+
+func (c *compiler) emitByte(opcode Opcode, operand int, span ast.Span) {
+	c.emit(opcode, span)
+	c.function.Chunk.write(byte(operand), span)
+}
+
+func (c *compiler) emitUint16(opcode Opcode, operand int, span ast.Span) {
+	c.emit(opcode, span)
+	c.function.Chunk.writeUint16(operand, span)
+}
+
+func (c *compiler) emitJump(opcode Opcode, span ast.Span) int {
+	c.emitUint16(opcode, 0, span)
+	return len(c.function.Chunk.Code) - 2
+}
+
+func (c *compiler) patchJump(operand int) error {
+	jump := len(c.function.Chunk.Code) - operand - 2
+	if jump > maxJump {
+		return fmt.Errorf("Too much code to jump over in function '%s'.", c.function.Name)
+	}
+
+	binary.BigEndian.PutUint16(c.function.Chunk.Code[operand:], uint16(jump))
+	return nil
+}
+
+func (c *compiler) emitName(opcode Opcode, name string, span ast.Span) error {
+	index, err := c.addConstant(value.String(name))
+	if err != nil {
+		return err
+	}
+	c.emitUint16(opcode, index, span)
+	return nil
+}
+
+func (c *compiler) emitConstant(v value.Value, span ast.Span) error {
+	index, err := c.addConstant(v)
+	if err != nil {
+		return err
+	}
+	c.emitUint16(OpConstant, index, span)
+	return nil
+}
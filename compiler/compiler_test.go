@@ -0,0 +1,181 @@
+package compiler
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/joaovictorjs/adam-script/parser"
+	"github.com/joaovictorjs/adam-script/value"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GivenSource_WhenCompile_ThenShouldEmitCorrectBytecode(t *testing.T) {
+	type TestCase struct {
+		name              string
+		source            string
+		expectedCode      []byte
+		expectedConstants []value.Value
+	}
+
+	testcases := []TestCase{
+		{
+			name:         "empty program",
+			source:       "",
+			expectedCode: []byte{byte(OpNull), byte(OpReturn)},
+		},
+		{
+			name:   "binary expression",
+			source: "1 + 2 * 3",
+			expectedCode: []byte{
+				byte(OpConstant), 0, 0,
+				byte(OpConstant), 0, 1,
+				byte(OpConstant), 0, 2,
+				byte(OpMultiply),
+				byte(OpAdd),
+				byte(OpReturn),
+			},
+			expectedConstants: []value.Value{value.Number(1), value.Number(2), value.Number(3)},
+		},
+		{
+			name:   "global declaration",
+			source: "const x = \"x\"; x",
+			expectedCode: []byte{
+				byte(OpConstant), 0, 0,
+				byte(OpDefineGlobal), 0, 0, 1,
+				byte(OpGetGlobal), 0, 0,
+				byte(OpReturn),
+			},
+			expectedConstants: []value.Value{value.String("x")},
+		},
+		{
+			name:   "local variable in block",
+			source: "{ let x = 1; x = 2 }",
+			expectedCode: []byte{
+				byte(OpNull),
+				byte(OpUndefined),
+				byte(OpConstant), 0, 0,
+				byte(OpSetLocal), 2,
+				byte(OpPop),
+				byte(OpConstant), 0, 1,
+				byte(OpSetLocal), 2,
+				byte(OpSetLocal), 1,
+				byte(OpPop),
+				byte(OpPop),
+				byte(OpReturn),
+			},
+			expectedConstants: []value.Value{value.Number(1), value.Number(2)},
+		},
+	}
+
+	for _, test := range testcases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			program, err := parser.NewParser(test.source).Parse()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			function, err := Compile(program)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, ScriptName, function.Name)
+			assert.Equal(t, test.expectedCode, function.Chunk.Code)
+			assert.Equal(t, test.expectedConstants, function.Chunk.Constants)
+			assert.Len(t, function.Chunk.Spans, len(function.Chunk.Code))
+		})
+	}
+}
+
+func Test_GivenClosure_WhenCompile_ThenShouldCaptureUpvalues(t *testing.T) {
+	program, err := parser.NewParser("fn outer(a) { fn inner() { return a } return inner }").Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	script, err := Compile(program)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	outer := script.Chunk.Constants[0].(*Function)
+	assert.Equal(t, "outer", outer.Name)
+	assert.Equal(t, 1, outer.Arity)
+	assert.Equal(t, 0, outer.Upvalues)
+
+	inner := outer.Chunk.Constants[0].(*Function)
+	assert.Equal(t, "inner", inner.Name)
+	assert.Equal(t, 1, inner.Upvalues)
+	assert.Equal(t, []byte{byte(OpGetUpvalue), 0, byte(OpReturn), byte(OpNull), byte(OpReturn)}, inner.Chunk.Code)
+	assert.Equal(t, []byte{byte(OpUndefined), byte(OpClosure), 0, 0, 1, 1}, outer.Chunk.Code[:6])
+}
+
+func Test_GivenClosureOverLaterDeclaration_WhenCompile_ThenShouldFallBackWhileItIsUndefined(t *testing.T) {
+	program, err := parser.NewParser("fn outer() { let y = 1; { fn inner() { y = y } let y = 2; return inner } }").Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	script, err := Compile(program)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	outer := script.Chunk.Constants[0].(*Function)
+	inner := outer.Chunk.Constants[1].(*Function)
+	assert.Equal(t, 2, inner.Upvalues)
+	assert.Equal(t, []byte{
+		byte(OpGetUpvalue), 0,
+		byte(OpJumpIfDefined), 0, 2,
+		byte(OpGetUpvalue), 1,
+		byte(OpGetUpvalue), 0,
+		byte(OpJumpIfDefined), 0, 5,
+		byte(OpSetUpvalue), 1,
+		byte(OpJump), 0, 3,
+		byte(OpPop),
+		byte(OpSetUpvalue), 0,
+		byte(OpPop),
+		byte(OpNull),
+		byte(OpReturn),
+	}, inner.Chunk.Code)
+}
+
+func Test_GivenInvalidSource_WhenCompile_ThenShouldReturnCorrectError(t *testing.T) {
+	type TestCase struct {
+		name          string
+		source        string
+		expectedError error
+	}
+
+	testcases := []TestCase{
+		{
+			name:          "assignment to local constant",
+			source:        "{ const x = 1; x = 2 }",
+			expectedError: fmt.Errorf("Cannot assign to constant 'x'."),
+		},
+		{
+			name:          "assignment to captured constant",
+			source:        "fn f() { const x = 1; fn g() { x = 2; return } return g }",
+			expectedError: fmt.Errorf("Cannot assign to constant 'x'."),
+		},
+		{
+			name:          "local redeclaration",
+			source:        "{ let x = 1; let x = 2 }",
+			expectedError: fmt.Errorf("Variable 'x' is already declared."),
+		},
+	}
+
+	for _, test := range testcases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			program, err := parser.NewParser(test.source).Parse()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = Compile(program)
+			assert.Equal(t, test.expectedError, err)
+		})
+	}
+}
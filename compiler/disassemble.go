@@ -0,0 +1,122 @@
+package compiler
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/joaovictorjs/adam-script/diagnostic"
+	"github.com/joaovictorjs/adam-script/value"
+)
+
+func Disassemble(function *Function, source string) string {
+	var builder strings.Builder
+	disassemble(&builder, function, source)
+	return builder.String()
+}
+
+func disassemble(builder *strings.Builder, function *Function, source string) {
+	fmt.Fprintf(builder, "== %s ==\n", function.Name)
+
+	chunk := &function.Chunk
+	previous := ""
+	for offset := 0; offset < len(chunk.Code); {
+		position := chunk.position(offset, source)
+		if position == previous {
+			position = "|"
+		} else {
+			if source != "" {
+				fmt.Fprintf(builder, "          ; %s\n", sourceLine(source, chunk.Spans[offset].Start))
+			}
+			previous = position
+		}
+
+		instruction, next := chunk.instruction(offset)
+		fmt.Fprintf(builder, "%04d %4s %s\n", offset, position, instruction)
+		offset = next
+	}
+
+	for _, constant := range chunk.Constants {
+		if nested, ok := constant.(*Function); ok {
+			builder.WriteString("\n")
+			disassemble(builder, nested, source)
+		}
+	}
+}
+
+func (c *Chunk) instruction(offset int) (string, int) {
+	opcode := Opcode(c.Code[offset])
+	name := fmt.Sprintf("%-16s", opcode)
+	next := offset + 1
+
+	switch opcode {
+	case OpConstant, OpGetGlobal, OpSetGlobal, OpGetMember, OpSetMember:
+		index := c.ReadUint16(next)
+		return fmt.Sprintf("%s %4d %s", name, index, inspect(c.Constants[index])), next + 2
+	case OpDefineGlobal:
+		index := c.ReadUint16(next)
+		kind := "let"
+		if c.Code[next+2] == 1 {
+			kind = "const"
+		}
+		return fmt.Sprintf("%s %4d %s %s", name, index, inspect(c.Constants[index]), kind), next + 3
+	case OpGetLocal, OpSetLocal, OpGetUpvalue, OpSetUpvalue, OpCall:
+		return fmt.Sprintf("%s %4d", name, c.Code[next]), next + 1
+	case OpMap:
+		return fmt.Sprintf("%s %4d", name, c.ReadUint16(next)), next + 2
+	case OpJump, OpJumpIfDefined, OpTry:
+		jump := c.ReadUint16(next)
+		return fmt.Sprintf("%s %4d -> %04d", name, jump, next+2+jump), next + 2
+	case OpClosure:
+		index := c.ReadUint16(next)
+		function := c.Constants[index].(*Function)
+		next += 2
+
+		var captures []string
+		for range function.Upvalues {
+			kind := "upvalue"
+			if c.Code[next] == 1 {
+				kind = "local"
+			}
+			captures = append(captures, fmt.Sprintf("%s %d", kind, c.Code[next+1]))
+			next += 2
+		}
+
+		text := fmt.Sprintf("%s %4d %s", name, index, function)
+		if len(captures) > 0 {
+			text += " [" + strings.Join(captures, ", ") + "]"
+		}
+		return text, next
+	default:
+		return opcode.String(), next
+	}
+}
+
+func (c *Chunk) position(offset int, source string) string {
+	if offset >= len(c.Spans) {
+		return "?"
+	}
+
+	if source == "" {
+		return fmt.Sprintf("@%d", c.Spans[offset].Start)
+	}
+
+	line, _ := diagnostic.Position(source, c.Spans[offset].Start)
+	return fmt.Sprint(line)
+}
+
+func sourceLine(source string, offset int) string {
+	offset = min(max(offset, 0), len(source))
+	start := strings.LastIndex(source[:offset], "\n") + 1
+	end := strings.IndexByte(source[offset:], '\n')
+	if end < 0 {
+		return strings.TrimSpace(source[start:])
+	}
+	return strings.TrimSpace(source[start : offset+end])
+}
+
+func inspect(v value.Value) string {
+	if function, ok := v.(*Function); ok {
+		return function.String()
+	}
+	return value.Inspect(v)
+}
@@ -0,0 +1,43 @@
+package compiler
+
+import (
+	"testing"
+
+	"github.com/joaovictorjs/adam-script/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GivenFunction_WhenDisassemble_ThenShouldListInstructionsWithLines(t *testing.T) {
+	source := "let x = 1;\nfn get() {\n\treturn x\n}\nget()"
+	program, err := parser.NewParser(source).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	function, err := Compile(program)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `== <script> ==
+          ; fn get() {
+0000    2 CLOSURE             0 <fn get>
+0003    | DEFINE_GLOBAL       1 "get" let
+          ; let x = 1;
+0007    1 CONSTANT            2 1
+0010    | DEFINE_GLOBAL       3 "x" let
+          ; get()
+0014    5 GET_GLOBAL          1 "get"
+0017    | CALL                0
+0019    | RETURN
+
+== get ==
+          ; return x
+0000    3 GET_GLOBAL          0 "x"
+0003    | RETURN
+          ; fn get() {
+0004    2 NULL
+0005    | RETURN
+`
+	assert.Equal(t, expected, Disassemble(function, source))
+}
@@ -0,0 +1,275 @@
+package compiler
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"math"
+
+	"github.com/joaovictorjs/adam-script/ast"
+	"github.com/joaovictorjs/adam-script/value"
+)
+
+// An .adamc module is laid out as the magic header, a big-endian uint16
+// version, a uint32 payload length, the payload and the CRC-32 of the
+// payload. The payload holds the script function: name, arity, upvalue
+// count, code, constant pool and a run-length encoded line table mapping
+// each code byte to its source span. Nested functions live in the
+// constant pool.
+const (
+	ModuleMagic   = "ADMC"
+	ModuleVersion = 1
+)
+
+const (
+	constantNumber byte = iota
+	constantString
+	constantNull
+	constantFunction
+)
+
+const headerSize = len(ModuleMagic) + 2 + 4
+
+func Encode(w io.Writer, function *Function) error {
+	var payload []byte
+	payload = appendFunction(payload, function)
+
+	header := make([]byte, 0, headerSize)
+	header = append(header, ModuleMagic...)
+	header = binary.BigEndian.AppendUint16(header, ModuleVersion)
+	header = binary.BigEndian.AppendUint32(header, uint32(len(payload)))
+
+	data := append(header, payload...)
+	data = binary.BigEndian.AppendUint32(data, crc32.ChecksumIEEE(payload))
+	_, err := w.Write(data)
+	return err
+}
+
+func Decode(r io.Reader) (*Function, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) < len(ModuleMagic) || string(data[:len(ModuleMagic)]) != ModuleMagic {
+		return nil, fmt.Errorf("Invalid module header, expected an .adamc file.")
+	}
+
+	if len(data) >= len(ModuleMagic)+2 {
+		version := binary.BigEndian.Uint16(data[len(ModuleMagic):])
+		if version != ModuleVersion {
+			return nil, fmt.Errorf("Unsupported module version %d, expected %d.", version, ModuleVersion)
+		}
+	}
+
+	if len(data) < headerSize {
+		return nil, fmt.Errorf("Corrupted module, unexpected end of file.")
+	}
+
+	length := int(binary.BigEndian.Uint32(data[len(ModuleMagic)+2:]))
+	if len(data) != headerSize+length+4 {
+		return nil, fmt.Errorf("Corrupted module, expected %d bytes but got %d.", headerSize+length+4, len(data))
+	}
+
+	payload := data[headerSize : headerSize+length]
+	if crc32.ChecksumIEEE(payload) != binary.BigEndian.Uint32(data[headerSize+length:]) {
+		return nil, fmt.Errorf("Corrupted module, checksum mismatch.")
+	}
+
+	d := &decoder{data: payload}
+	function, err := d.function()
+	if err != nil {
+		return nil, err
+	}
+
+	if d.offset != len(d.data) {
+		return nil, fmt.Errorf("Corrupted module, %d trailing bytes.", len(d.data)-d.offset)
+	}
+	if function.Upvalues != 0 || function.Arity != 0 {
+		return nil, fmt.Errorf("Corrupted module, script function '%s' cannot take arguments or capture upvalues.", function.Name)
+	}
+	return function, nil
+}
+
+func appendFunction(data []byte, function *Function) []byte {
+	data = appendString(data, function.Name)
+	data = binary.AppendUvarint(data, uint64(function.Arity))
+	data = binary.AppendUvarint(data, uint64(function.Upvalues))
+
+	chunk := &function.Chunk
+	data = binary.AppendUvarint(data, uint64(len(chunk.Code)))
+	data = append(data, chunk.Code...)
+
+	data = binary.AppendUvarint(data, uint64(len(chunk.Constants)))
+	for _, constant := range chunk.Constants {
+		switch c := constant.(type) {
+		case value.Number:
+			data = append(data, constantNumber)
+			data = binary.BigEndian.AppendUint64(data, math.Float64bits(float64(c)))
+		case value.String:
+			data = append(data, constantString)
+			data = appendString(data, string(c))
+		case value.Null:
+			data = append(data, constantNull)
+		case *Function:
+			data = append(data, constantFunction)
+			data = appendFunction(data, c)
+		default:
+			panic(fmt.Sprintf("compiler: unexpected constant type %T", c))
+		}
+	}
+
+	var runs [][3]int
+	for _, span := range chunk.Spans {
+		if last := len(runs) - 1; last >= 0 && runs[last][1] == span.Start && runs[last][2] == span.End {
+			runs[last][0]++
+			continue
+		}
+		runs = append(runs, [3]int{1, span.Start, span.End})
+	}
+
+	data = binary.AppendUvarint(data, uint64(len(runs)))
+	for _, run := range runs {
+		data = binary.AppendUvarint(data, uint64(run[0]))
+		data = binary.AppendUvarint(data, uint64(run[1]))
+		data = binary.AppendUvarint(data, uint64(run[2]))
+	}
+	return data
+}
+
+func appendString(data []byte, s string) []byte {
+	data = binary.AppendUvarint(data, uint64(len(s)))
+	return append(data, s...)
+}
+
+type decoder struct {
+	data   []byte
+	offset int
+}
+
+func (d *decoder) function() (*Function, error) {
+	name, err := d.string()
+	if err != nil {
+		return nil, err
+	}
+
+	function := &Function{Name: name}
+	if function.Arity, err = d.count(); err != nil {
+		return nil, err
+	}
+	if function.Upvalues, err = d.count(); err != nil {
+		return nil, err
+	}
+
+	chunk := &function.Chunk
+	if chunk.Code, err = d.bytes(); err != nil {
+		return nil, err
+	}
+
+	constants, err := d.count()
+	if err != nil {
+		return nil, err
+	}
+	for range constants {
+		constant, err := d.constant()
+		if err != nil {
+			return nil, err
+		}
+		chunk.Constants = append(chunk.Constants, constant)
+	}
+
+	runs, err := d.count()
+	if err != nil {
+		return nil, err
+	}
+	for range runs {
+		var run [3]int
+		for i := range run {
+			if run[i], err = d.count(); err != nil {
+				return nil, err
+			}
+		}
+		if run[0] > len(chunk.Code)-len(chunk.Spans) {
+			break
+		}
+		for range run[0] {
+			chunk.Spans = append(chunk.Spans, ast.Span{Start: run[1], End: run[2]})
+		}
+	}
+
+	if len(chunk.Spans) != len(chunk.Code) {
+		return nil, fmt.Errorf("Corrupted module, line table of function '%s' does not match its code.", name)
+	}
+	if err := verify(function); err != nil {
+		return nil, err
+	}
+	return function, nil
+}
+
+func (d *decoder) constant() (value.Value, error) {
+	tag, err := d.byte()
+	if err != nil {
+		return nil, err
+	}
+
+	switch tag {
+	case constantNumber:
+		if len(d.data)-d.offset < 8 {
+			return nil, d.truncated()
+		}
+		bits := binary.BigEndian.Uint64(d.data[d.offset:])
+		d.offset += 8
+		return value.Number(math.Float64frombits(bits)), nil
+	case constantString:
+		s, err := d.string()
+		return value.String(s), err
+	case constantNull:
+		return value.Null{}, nil
+	case constantFunction:
+		return d.function()
+	default:
+		return nil, fmt.Errorf("Corrupted module, unknown constant tag %d.", tag)
+	}
+}
+
+func (d *decoder) string() (string, error) {
+	b, err := d.bytes()
+	return string(b), err
+}
+
+func (d *decoder) bytes() ([]byte, error) {
+	length, err := d.count()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(d.data)-d.offset < length {
+		return nil, d.truncated()
+	}
+	b := d.data[d.offset : d.offset+length]
+	d.offset += length
+	return b, nil
+}
+
+func (d *decoder) count() (int, error) {
+	v, n := binary.Uvarint(d.data[d.offset:])
+	if n <= 0 || v > math.MaxInt32 {
+		return 0, d.truncated()
+	}
+	d.offset += n
+	return int(v), nil
+}
+
+func (d *decoder) byte() (byte, error) {
+	if d.offset >= len(d.data) {
+		return 0, d.truncated()
+	}
+	b := d.data[d.offset]
+	d.offset++
+	return b, nil
+}
+
+func (d *decoder) truncated() error {
+	return fmt.Errorf("Corrupted module, unexpected end of file.")
+}
@@ -0,0 +1,215 @@
+package compiler
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/joaovictorjs/adam-script/ast"
+	"github.com/joaovictorjs/adam-script/parser"
+	"github.com/joaovictorjs/adam-script/value"
+	"github.com/stretchr/testify/assert"
+)
+
+func encodeSource(t *testing.T, source string) (*Function, []byte) {
+	t.Helper()
+	program, err := parser.NewParser(source).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	function, err := Compile(program)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buffer bytes.Buffer
+	if err := Encode(&buffer, function); err != nil {
+		t.Fatal(err)
+	}
+	return function, buffer.Bytes()
+}
+
+func Test_GivenEncodedModule_WhenDecode_ThenShouldRestoreFunction(t *testing.T) {
+	function, data := encodeSource(t, "const s = \"a\"; fn f(n) { let m = n; fn g() { return m * 5 / 2 } return g } f(1)() + 0")
+
+	decoded, err := Decode(bytes.NewReader(data))
+	assert.NoError(t, err)
+	assert.Equal(t, function, decoded)
+	assert.Equal(t, ModuleMagic, string(data[:4]))
+}
+
+func Test_GivenInvalidModule_WhenDecode_ThenShouldReturnCorrectError(t *testing.T) {
+	_, data := encodeSource(t, "let x = 1; x + 2")
+
+	type TestCase struct {
+		name          string
+		data          func() []byte
+		expectedError error
+	}
+
+	testcases := []TestCase{
+		{
+			name:          "wrong magic",
+			data:          func() []byte { return []byte("let x = 1") },
+			expectedError: fmt.Errorf("Invalid module header, expected an .adamc file."),
+		},
+		{
+			name: "version mismatch",
+			data: func() []byte {
+				changed := bytes.Clone(data)
+				changed[5] = ModuleVersion + 1
+				return changed
+			},
+			expectedError: fmt.Errorf("Unsupported module version %d, expected %d.", ModuleVersion+1, ModuleVersion),
+		},
+		{
+			name:          "truncated header",
+			data:          func() []byte { return data[:7] },
+			expectedError: fmt.Errorf("Corrupted module, unexpected end of file."),
+		},
+		{
+			name:          "truncated payload",
+			data:          func() []byte { return data[:len(data)-5] },
+			expectedError: fmt.Errorf("Corrupted module, expected %d bytes but got %d.", len(data), len(data)-5),
+		},
+		{
+			name: "flipped payload byte",
+			data: func() []byte {
+				changed := bytes.Clone(data)
+				changed[headerSize+3] ^= 0xff
+				return changed
+			},
+			expectedError: fmt.Errorf("Corrupted module, checksum mismatch."),
+		},
+	}
+
+	for _, test := range testcases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := Decode(bytes.NewReader(test.data()))
+			assert.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func encodeFunction(t *testing.T, function *Function) []byte {
+	t.Helper()
+	var buffer bytes.Buffer
+	if err := Encode(&buffer, function); err != nil {
+		t.Fatal(err)
+	}
+	return buffer.Bytes()
+}
+
+func handmade(name string, constants []value.Value, code ...byte) *Function {
+	return &Function{Name: name, Chunk: Chunk{Code: code, Constants: constants, Spans: make([]ast.Span, len(code))}}
+}
+
+func Test_GivenInvalidBytecode_WhenDecode_ThenShouldReturnCorrectError(t *testing.T) {
+	one := []value.Value{value.Number(1)}
+	nested := handmade("f", nil, byte(OpGetUpvalue), 0, byte(OpReturn))
+	nested.Upvalues = 1
+
+	type TestCase struct {
+		name          string
+		function      *Function
+		expectedError error
+	}
+
+	testcases := []TestCase{
+		{
+			name:          "constant out of range",
+			function:      handmade("script", one, byte(OpConstant), 0, 9, byte(OpReturn)),
+			expectedError: fmt.Errorf("Corrupted module, constant 9 out of range at offset 0 of function 'script'."),
+		},
+		{
+			name:          "unknown opcode",
+			function:      handmade("script", nil, byte(OpNull), 200, byte(OpReturn)),
+			expectedError: fmt.Errorf("Corrupted module, unknown opcode 200 at offset 1 of function 'script'."),
+		},
+		{
+			name:          "truncated instruction",
+			function:      handmade("script", one, byte(OpNull), byte(OpConstant), 0),
+			expectedError: fmt.Errorf("Corrupted module, truncated instruction CONSTANT at offset 1 of function 'script'."),
+		},
+		{
+			name:          "name of wrong type",
+			function:      handmade("script", one, byte(OpGetGlobal), 0, 0, byte(OpReturn)),
+			expectedError: fmt.Errorf("Corrupted module, GET_GLOBAL expects a name at offset 0 of function 'script'."),
+		},
+		{
+			name:          "closure of a number",
+			function:      handmade("script", one, byte(OpClosure), 0, 0, byte(OpReturn)),
+			expectedError: fmt.Errorf("Corrupted module, CLOSURE of a non-function constant at offset 0 of function 'script'."),
+		},
+		{
+			name:          "constant of a function",
+			function:      handmade("script", []value.Value{handmade("f", nil, byte(OpNull), byte(OpReturn))}, byte(OpConstant), 0, 0, byte(OpReturn)),
+			expectedError: fmt.Errorf("Corrupted module, CONSTANT of a function at offset 0 of function 'script'."),
+		},
+		{
+			name:          "local slot out of range",
+			function:      handmade("script", nil, byte(OpGetLocal), 3, byte(OpReturn)),
+			expectedError: fmt.Errorf("Corrupted module, local slot 3 out of range at offset 0 of function 'script'."),
+		},
+		{
+			name:          "upvalue out of range",
+			function:      handmade("script", []value.Value{handmade("f", nil, byte(OpGetUpvalue), 0, byte(OpReturn))}, byte(OpClosure), 0, 0, byte(OpReturn)),
+			expectedError: fmt.Errorf("Corrupted module, upvalue 0 out of range at offset 0 of function 'f'."),
+		},
+		{
+			name:          "captured local out of range",
+			function:      handmade("script", []value.Value{nested}, byte(OpClosure), 0, 0, 1, 7, byte(OpReturn)),
+			expectedError: fmt.Errorf("Corrupted module, local slot 7 out of range at offset 0 of function 'script'."),
+		},
+		{
+			name:          "jump past the end",
+			function:      handmade("script", nil, byte(OpJump), 0, 40, byte(OpNull), byte(OpReturn)),
+			expectedError: fmt.Errorf("Corrupted module, jump to offset 43 outside of the code at offset 0 of function 'script'."),
+		},
+		{
+			name:          "jump into an operand",
+			function:      handmade("script", one, byte(OpJump), 0, 1, byte(OpConstant), 0, 0, byte(OpReturn)),
+			expectedError: fmt.Errorf("Corrupted module, jump to offset 4 outside of the code at offset 0 of function 'script'."),
+		},
+		{
+			name:          "try target past the end",
+			function:      handmade("script", nil, byte(OpTry), 0, 9, byte(OpNull), byte(OpReturn)),
+			expectedError: fmt.Errorf("Corrupted module, jump to offset 12 outside of the code at offset 0 of function 'script'."),
+		},
+		{
+			name:          "conditional jump keeping the value where it is popped",
+			function:      handmade("script", nil, byte(OpUndefined), byte(OpJumpIfDefined), 0, 0, byte(OpNull), byte(OpReturn)),
+			expectedError: fmt.Errorf("Corrupted module, inconsistent stack at offset 4 of function 'script'."),
+		},
+		{
+			name:          "end try without try",
+			function:      handmade("script", nil, byte(OpEndTry), byte(OpNull), byte(OpReturn)),
+			expectedError: fmt.Errorf("Corrupted module, END_TRY without TRY at offset 0 of function 'script'."),
+		},
+		{
+			name:          "stack underflow",
+			function:      handmade("script", nil, byte(OpPop), byte(OpNull), byte(OpReturn)),
+			expectedError: fmt.Errorf("Corrupted module, stack underflow at offset 0 of function 'script'."),
+		},
+		{
+			name:          "falling off the end",
+			function:      handmade("script", nil, byte(OpNull)),
+			expectedError: fmt.Errorf("Corrupted module, code ends without RETURN at offset 0 of function 'script'."),
+		},
+		{
+			name:          "script with upvalues",
+			function:      &Function{Name: "script", Upvalues: 1, Chunk: handmade("script", nil, byte(OpNull), byte(OpReturn)).Chunk},
+			expectedError: fmt.Errorf("Corrupted module, script function 'script' cannot take arguments or capture upvalues."),
+		},
+	}
+
+	for _, test := range testcases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := Decode(bytes.NewReader(encodeFunction(t, test.function)))
+			assert.Equal(t, test.expectedError, err)
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package compiler
+
+import "strconv"
+
+type Opcode byte
+
+const (
+	OpConstant Opcode = iota
+	OpNull
+	OpPop
+	OpAdd
+	OpSubtract
+	OpMultiply
+	OpDivide
+	OpDefineGlobal
+	OpGetGlobal
+	OpSetGlobal
+	OpGetLocal
+	OpSetLocal
+	OpGetUpvalue
+	OpSetUpvalue
+	OpCloseUpvalue
+	OpClosure
+	OpCall
+	OpReturn
+	OpJump
+	OpGetMember
+	OpSetMember
+	OpTry
+	OpEndTry
+	OpMap
+	OpNegate
+	OpUndefined
+	OpJumpIfDefined
+)
+
+var opcodeNames = map[Opcode]string{
+	OpConstant:      "CONSTANT",
+	OpNull:          "NULL",
+	OpPop:           "POP",
+	OpAdd:           "ADD",
+	OpSubtract:      "SUBTRACT",
+	OpMultiply:      "MULTIPLY",
+	OpDivide:        "DIVIDE",
+	OpDefineGlobal:  "DEFINE_GLOBAL",
+	OpGetGlobal:     "GET_GLOBAL",
+	OpSetGlobal:     "SET_GLOBAL",
+	OpGetLocal:      "GET_LOCAL",
+	OpSetLocal:      "SET_LOCAL",
+	OpGetUpvalue:    "GET_UPVALUE",
+	OpSetUpvalue:    "SET_UPVALUE",
+	OpCloseUpvalue:  "CLOSE_UPVALUE",
+	OpClosure:       "CLOSURE",
+	OpCall:          "CALL",
+	OpReturn:        "RETURN",
+	OpJump:          "JUMP",
+	OpGetMember:     "GET_MEMBER",
+	OpSetMember:     "SET_MEMBER",
+	OpTry:           "TRY",
+	OpEndTry:        "END_TRY",
+	OpMap:           "MAP",
+	OpNegate:        "NEGATE",
+	OpUndefined:     "UNDEFINED",
+	OpJumpIfDefined: "JUMP_IF_DEFINED",
+}
+
+func (o Opcode) String() string {
+	if name, ok := opcodeNames[o]; ok {
+		return name
+	}
+	return "Opcode(" + strconv.Itoa(int(o)) + ")"
+}
+
+var binaryOpcodes = map[uint8]Opcode{
+	'+': OpAdd,
+	'-': OpSubtract,
+	'*': OpMultiply,
+	'/': OpDivide,
+}
+
+var opcodeOperators = map[Opcode]uint8{
+	OpAdd:      '+',
+	OpSubtract: '-',
+	OpMultiply: '*',
+	OpDivide:   '/',
+}
+
+func (o Opcode) Operator() (uint8, bool) {
+	operator, ok := opcodeOperators[o]
+	return operator, ok
+}
@@ -0,0 +1,235 @@
+package compiler
+
+import (
+	"fmt"
+
+	"github.com/joaovictorjs/adam-script/value"
+)
+
+// verify checks the code of a decoded function before the VM runs it, so a
+// crafted or damaged module fails to load instead of crashing the VM. Every
+// path through the code is walked with the height of the frame's stack and
+// the number of open try blocks, which have to agree wherever paths meet.
+// Nested functions are verified as they are decoded.
+func verify(function *Function) error {
+	v := &verifier{function: function, chunk: &function.Chunk}
+
+	starts := map[int]bool{}
+	for offset := 0; offset < len(v.chunk.Code); {
+		starts[offset] = true
+		next, err := v.operands(offset)
+		if err != nil {
+			return err
+		}
+		offset = next
+	}
+	if len(v.chunk.Code) == 0 {
+		return v.corrupted(0, "missing code")
+	}
+
+	// The frame starts with the callee in slot 0 followed by the arguments.
+	states := map[int]state{0: {height: function.Arity + 1}}
+	pending := []int{0}
+	for len(pending) > 0 {
+		offset := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+
+		successors, err := v.step(offset, states[offset])
+		if err != nil {
+			return err
+		}
+		for _, successor := range successors {
+			if successor.offset == len(v.chunk.Code) {
+				return v.corrupted(offset, "code ends without %s", OpReturn)
+			}
+			if !starts[successor.offset] {
+				return v.corrupted(offset, "jump to offset %d outside of the code", successor.offset)
+			}
+			if seen, ok := states[successor.offset]; ok {
+				if seen != successor.state {
+					return v.corrupted(successor.offset, "inconsistent stack")
+				}
+				continue
+			}
+			states[successor.offset] = successor.state
+			pending = append(pending, successor.offset)
+		}
+	}
+	return nil
+}
+
+type verifier struct {
+	function *Function
+	chunk    *Chunk
+}
+
+type state struct {
+	height int
+	tries  int
+}
+
+type successor struct {
+	offset int
+	state
+}
+
+func (v *verifier) corrupted(offset int, format string, arguments ...any) error {
+	return fmt.Errorf("Corrupted module, %s at offset %d of function '%s'.", fmt.Sprintf(format, arguments...), offset, v.function.Name)
+}
+
+// operands checks what can be checked of the instruction at offset without
+// knowing the stack, and returns the offset of the next one.
+func (v *verifier) operands(offset int) (int, error) {
+	code := v.chunk.Code
+	opcode := Opcode(code[offset])
+	next := offset + 1
+
+	size := 0
+	switch opcode {
+	case OpNull, OpUndefined, OpPop, OpAdd, OpSubtract, OpMultiply, OpDivide, OpNegate, OpCloseUpvalue, OpReturn, OpEndTry:
+	case OpGetLocal, OpSetLocal, OpGetUpvalue, OpSetUpvalue, OpCall:
+		size = 1
+	case OpConstant, OpGetGlobal, OpSetGlobal, OpGetMember, OpSetMember, OpJump, OpJumpIfDefined, OpTry, OpMap, OpClosure:
+		size = 2
+	case OpDefineGlobal:
+		size = 3
+	default:
+		return 0, v.corrupted(offset, "unknown opcode %d", opcode)
+	}
+	if next+size > len(code) {
+		return 0, v.corrupted(offset, "truncated instruction %s", opcode)
+	}
+
+	switch opcode {
+	case OpConstant:
+		constant, err := v.constant(offset)
+		if err != nil {
+			return 0, err
+		}
+		if _, ok := constant.(*Function); ok {
+			return 0, v.corrupted(offset, "%s of a function", opcode)
+		}
+	case OpGetGlobal, OpSetGlobal, OpGetMember, OpSetMember, OpDefineGlobal:
+		constant, err := v.constant(offset)
+		if err != nil {
+			return 0, err
+		}
+		if _, ok := constant.(value.String); !ok {
+			return 0, v.corrupted(offset, "%s expects a name", opcode)
+		}
+		if opcode == OpDefineGlobal && code[next+2] > 1 {
+			return 0, v.corrupted(offset, "invalid %s flag %d", opcode, code[next+2])
+		}
+	case OpGetUpvalue, OpSetUpvalue:
+		if index := int(code[next]); index >= v.function.Upvalues {
+			return 0, v.corrupted(offset, "upvalue %d out of range", index)
+		}
+	case OpClosure:
+		constant, err := v.constant(offset)
+		if err != nil {
+			return 0, err
+		}
+		nested, ok := constant.(*Function)
+		if !ok {
+			return 0, v.corrupted(offset, "%s of a non-function constant", opcode)
+		}
+		if next+2+2*nested.Upvalues > len(code) {
+			return 0, v.corrupted(offset, "truncated instruction %s", opcode)
+		}
+		for i := range nested.Upvalues {
+			isLocal, index := code[next+2+2*i], int(code[next+3+2*i])
+			if isLocal > 1 {
+				return 0, v.corrupted(offset, "invalid capture kind %d", isLocal)
+			}
+			if isLocal == 0 && index >= v.function.Upvalues {
+				return 0, v.corrupted(offset, "upvalue %d out of range", index)
+			}
+		}
+		size += 2 * nested.Upvalues
+	}
+	return next + size, nil
+}
+
+func (v *verifier) constant(offset int) (value.Value, error) {
+	index := v.chunk.ReadUint16(offset + 1)
+	if index >= len(v.chunk.Constants) {
+		return nil, v.corrupted(offset, "constant %d out of range", index)
+	}
+	return v.chunk.Constants[index], nil
+}
+
+// step applies the instruction at offset to the state it is reached with
+// and returns the instructions that can run next.
+func (v *verifier) step(offset int, current state) ([]successor, error) {
+	code := v.chunk.Code
+	opcode := Opcode(code[offset])
+	next, _ := v.operands(offset)
+
+	pops, pushes := 0, 0
+	switch opcode {
+	case OpConstant, OpNull, OpUndefined, OpGetGlobal, OpGetUpvalue:
+		pushes = 1
+	case OpPop, OpDefineGlobal, OpCloseUpvalue, OpJumpIfDefined:
+		pops = 1
+	case OpAdd, OpSubtract, OpMultiply, OpDivide, OpSetMember:
+		pops, pushes = 2, 1
+	case OpGetMember, OpNegate:
+		pops, pushes = 1, 1
+	case OpSetGlobal, OpSetUpvalue:
+		pops, pushes = 1, 1
+	case OpGetLocal, OpSetLocal:
+		if slot := int(code[offset+1]); slot >= current.height {
+			return nil, v.corrupted(offset, "local slot %d out of range", slot)
+		}
+		if opcode == OpGetLocal {
+			pushes = 1
+		} else {
+			pops, pushes = 1, 1
+		}
+	case OpClosure:
+		nested := v.chunk.Constants[v.chunk.ReadUint16(offset+1)].(*Function)
+		for i := range nested.Upvalues {
+			if code[offset+3+2*i] == 1 && int(code[offset+4+2*i]) >= current.height {
+				return nil, v.corrupted(offset, "local slot %d out of range", code[offset+4+2*i])
+			}
+		}
+		pushes = 1
+	case OpCall:
+		pops, pushes = int(code[offset+1])+1, 1
+	case OpMap:
+		pops, pushes = 2*v.chunk.ReadUint16(offset+1), 1
+	case OpReturn:
+		pops = 1
+	case OpEndTry:
+		if current.tries == 0 {
+			return nil, v.corrupted(offset, "%s without %s", opcode, OpTry)
+		}
+	}
+
+	// Slot 0 holds the callee, which only OpReturn removes with the frame.
+	if current.height-pops < 1 {
+		return nil, v.corrupted(offset, "stack underflow")
+	}
+	after := state{height: current.height - pops + pushes, tries: current.tries}
+
+	switch opcode {
+	case OpReturn:
+		return nil, nil
+	case OpJump:
+		return []successor{{next + v.chunk.ReadUint16(offset+1), after}}, nil
+	case OpJumpIfDefined:
+		// The value is only popped when it is undefined and the jump is not
+		// taken.
+		taken := state{height: current.height, tries: current.tries}
+		return []successor{{next, after}, {next + v.chunk.ReadUint16(offset+1), taken}}, nil
+	case OpTry:
+		// The handler runs with the stack unwound to its height here and the
+		// error pushed, after the VM dropped the handler itself.
+		handler := state{height: current.height + 1, tries: current.tries}
+		after.tries++
+		return []successor{{next, after}, {next + v.chunk.ReadUint16(offset+1), handler}}, nil
+	case OpEndTry:
+		after.tries--
+	}
+	return []successor{{next, after}}, nil
+}
@@ -0,0 +1,106 @@
+package conformance
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/joaovictorjs/adam-script/ast"
+	"github.com/joaovictorjs/adam-script/compiler"
+	"github.com/joaovictorjs/adam-script/interpreter"
+	"github.com/joaovictorjs/adam-script/optimize"
+	"github.com/joaovictorjs/adam-script/parser"
+	"github.com/joaovictorjs/adam-script/value"
+	"github.com/joaovictorjs/adam-script/vm"
+	"github.com/stretchr/testify/assert"
+)
+
+type evaluator struct {
+	name     string
+	evaluate func(program ast.Program) (value.Value, error)
+}
+
+var evaluators = []evaluator{
+	{
+		name: "interpreter",
+		evaluate: func(program ast.Program) (value.Value, error) {
+			return interpreter.NewInterpreter().Evaluate(program)
+		},
+	},
+	{
+		name: "vm",
+		evaluate: func(program ast.Program) (value.Value, error) {
+			function, err := compiler.Compile(program)
+			if err != nil {
+				return nil, err
+			}
+
+			// Going through a module also checks that the verifier accepts
+			// everything the compiler emits.
+			var buffer bytes.Buffer
+			if err := compiler.Encode(&buffer, function); err != nil {
+				return nil, err
+			}
+			if function, err = compiler.Decode(&buffer); err != nil {
+				return nil, err
+			}
+			return vm.New().Run(context.Background(), function)
+		},
+	},
+	{
+		name: "optimized",
+		evaluate: func(program ast.Program) (value.Value, error) {
+			optimized, _ := optimize.Optimize(program)
+			function, err := compiler.Compile(optimized)
+			if err != nil {
+				return nil, err
+			}
+			return vm.New().Run(context.Background(), function)
+		},
+	},
+}
+
+func Test_GivenConformanceCases_WhenEvaluate_ThenEveryEvaluatorShouldAgree(t *testing.T) {
+	sources, err := filepath.Glob(filepath.Join("testdata", "*.adam"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, source := range sources {
+		data, err := os.ReadFile(source)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		header, _, _ := strings.Cut(string(data), "\n")
+		kind, expected, ok := strings.Cut(strings.TrimPrefix(header, "// "), ": ")
+		if !ok || (kind != "result" && kind != "error") {
+			t.Fatalf("Missing '// result:' or '// error:' header in '%s'.", source)
+		}
+
+		program, err := parser.NewParser(string(data)).Parse()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, e := range evaluators {
+			name := strings.TrimSuffix(filepath.Base(source), ".adam") + "/" + e.name
+			t.Run(name, func(t *testing.T) {
+				t.Parallel()
+				result, err := e.evaluate(program)
+				if kind == "error" {
+					assert.EqualError(t, err, expected)
+					return
+				}
+
+				if err != nil {
+					t.Fatal(err)
+				}
+				assert.Equal(t, expected, value.Inspect(result))
+			})
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package diagnostic
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/joaovictorjs/adam-script/ast"
+)
+
+type Severity int
+
+const (
+	Error Severity = iota
+	Warning
+	Hint
+)
+
+func (s Severity) String() string {
+	switch s {
+	case Error:
+		return "error"
+	case Warning:
+		return "warning"
+	case Hint:
+		return "hint"
+	default:
+		return fmt.Sprintf("Severity(%d)", int(s))
+	}
+}
+
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+type Diagnostic struct {
+	Severity Severity
+	Code     string
+	Message  string
+	Span     ast.Span
+}
+
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("%s[%s]: %s", d.Severity, d.Code, d.Message)
+}
+
+func (d Diagnostic) Format(source string) string {
+	line, column := Position(source, d.Span.Start)
+	return fmt.Sprintf("%d:%d: %s", line, column, d)
+}
+
+func Position(source string, offset int) (int, int) {
+	offset = min(max(offset, 0), len(source))
+	before := source[:offset]
+	line := strings.Count(before, "\n") + 1
+	column := offset - strings.LastIndex(before, "\n")
+	return line, column
+}
+
+func HasErrors(diagnostics []Diagnostic) bool {
+	for _, d := range diagnostics {
+		if d.Severity == Error {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,51 @@
+package diagnostic
+
+import (
+	"testing"
+
+	"github.com/joaovictorjs/adam-script/ast"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GivenDiagnostic_WhenFormat_ThenShouldIncludeLineAndColumn(t *testing.T) {
+	type TestCase struct {
+		name           string
+		source         string
+		span           ast.Span
+		expectedOutput string
+	}
+
+	testcases := []TestCase{
+		{
+			name:           "first line",
+			source:         "1 / 0",
+			span:           ast.Span{Start: 0, End: 5},
+			expectedOutput: "1:1: warning[division-by-zero]: Division by zero.",
+		},
+		{
+			name:           "later line",
+			source:         "let x = 1;\nlet y = x / 0;",
+			span:           ast.Span{Start: 19, End: 24},
+			expectedOutput: "2:9: warning[division-by-zero]: Division by zero.",
+		},
+		{
+			name:           "offset past the end",
+			source:         "ab",
+			span:           ast.Span{Start: 10, End: 10},
+			expectedOutput: "1:3: warning[division-by-zero]: Division by zero.",
+		},
+	}
+
+	for _, test := range testcases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			d := Diagnostic{
+				Severity: Warning,
+				Code:     "division-by-zero",
+				Message:  "Division by zero.",
+				Span:     test.span,
+			}
+			assert.Equal(t, test.expectedOutput, d.Format(test.source))
+		})
+	}
+}
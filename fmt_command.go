@@ -0,0 +1,140 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/joaovictorjs/adam-script/format"
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+type fmtOptions struct {
+	write bool
+	list  bool
+	diff  bool
+}
+
+func runFmt(args []string) int {
+	flags := flag.NewFlagSet("fmt", flag.ExitOnError)
+	options := fmtOptions{}
+	flags.BoolVar(&options.write, "w", false, "write result to the source file instead of stdout")
+	flags.BoolVar(&options.list, "l", false, "list files whose formatting differs")
+	flags.BoolVar(&options.diff, "d", false, "display diffs instead of rewriting files")
+	flags.Parse(args)
+
+	if flags.NArg() == 0 {
+		if options.write {
+			fmt.Fprintln(os.Stderr, "Cannot use -w with standard input.")
+			return 2
+		}
+
+		source, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		return formatSource("<standard input>", source, options)
+	}
+
+	exitCode := 0
+	for _, root := range flags.Args() {
+		err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if entry.IsDir() || (path != root && filepath.Ext(path) != ".adam") {
+				return nil
+			}
+
+			if code := formatFile(path, options); code != 0 {
+				exitCode = code
+			}
+			return nil
+		})
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			exitCode = 1
+		}
+	}
+	return exitCode
+}
+
+func formatFile(path string, options fmtOptions) int {
+	source, err := os.ReadFile(path)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	code := formatSource(path, source, options)
+	if code != 0 || !options.write {
+		return code
+	}
+
+	formatted, _ := format.Source(source)
+	if bytes.Equal(source, formatted) {
+		return 0
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	if err := os.WriteFile(path, formatted, info.Mode().Perm()); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	return 0
+}
+
+func formatSource(name string, source []byte, options fmtOptions) int {
+	formatted, err := format.Source(source)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %s\n", name, err)
+		return 1
+	}
+
+	changed := !bytes.Equal(source, formatted)
+	if options.list && changed {
+		fmt.Println(name)
+	}
+
+	if options.diff && changed {
+		diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        splitLines(source),
+			B:        splitLines(formatted),
+			FromFile: name + ".orig",
+			ToFile:   name,
+			Context:  3,
+		})
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		fmt.Print(diff)
+	}
+
+	if !options.list && !options.write && !options.diff {
+		os.Stdout.Write(formatted)
+	}
+	return 0
+}
+
+func splitLines(source []byte) []string {
+	lines := strings.SplitAfter(string(source), "\n")
+	last := len(lines) - 1
+	if lines[last] == "" {
+		return lines[:last]
+	}
+
+	lines[last] += "\n\\ No newline at end of file\n"
+	return lines
+}
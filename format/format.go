@@ -0,0 +1,193 @@
+package format
+
+import (
+	"strings"
+
+	"github.com/joaovictorjs/adam-script/lexer"
+	"github.com/joaovictorjs/adam-script/parser"
+)
+
+const indentation = "\t"
+
+type formatter struct {
+	builder     strings.Builder
+	previous    lexer.Token
+	declaring   bool
+	hasPrevious bool
+	atLineStart bool
+	depth       int
+
+	// objects tracks which open braces start object literals, which stay on
+	// one line, and previousObject whether the previous token was one of
+	// their braces.
+	objects        []bool
+	previousObject bool
+
+	// previousNegation reports whether the previous token was a unary minus,
+	// which stays attached to its operand.
+	previousNegation bool
+}
+
+func Source(source []byte) ([]byte, error) {
+	if _, err := parser.NewParser(string(source)).Parse(); err != nil {
+		return nil, err
+	}
+
+	tokens := lexer.NewLexer(string(source), lexer.WithTrivia()).GenerateTokens()
+	f := &formatter{atLineStart: true}
+	for _, token := range tokens {
+		f.writeToken(token)
+	}
+
+	formatted := strings.TrimRight(f.builder.String(), "\n")
+	if formatted != "" {
+		formatted += "\n"
+	}
+	return []byte(formatted), nil
+}
+
+func (f *formatter) writeToken(token lexer.Token) {
+	object := false
+	switch token.Kind {
+	case lexer.LBrace:
+		object = f.hasPrevious && !f.atStatementStart() && opensObject(f.previous)
+		f.objects = append(f.objects, object)
+	case lexer.RBrace:
+		if len(f.objects) > 0 {
+			object = f.objects[len(f.objects)-1]
+			f.objects = f.objects[:len(f.objects)-1]
+		}
+	}
+
+	closing := token.Kind == lexer.EOF || (token.Kind == lexer.RBrace && !object)
+	if closing && f.hasPrevious && !f.atStatementStart() {
+		f.builder.WriteString(";")
+		f.previous = lexer.Token{Kind: lexer.Semicolon, Lexeme: ";"}
+	}
+
+	newlines := 0
+	for i, trivia := range token.LeadingTrivia {
+		if trivia.Kind == lexer.Whitespace {
+			newlines = strings.Count(trivia.Text, "\n")
+			continue
+		}
+
+		ownLine := newlines > 0 || (i == 0 && !f.hasPrevious)
+		f.writeComment(strings.TrimRight(trivia.Text, " \t\r"), ownLine, newlines > 1)
+		newlines = 0
+	}
+
+	if token.Kind == lexer.EOF {
+		return
+	}
+
+	if token.Kind == lexer.RBrace && !object {
+		f.depth--
+	}
+
+	negation := token.Kind == lexer.Minus && (f.atStatementStart() || opensObject(f.previous))
+	emptyBlock := token.Kind == lexer.RBrace && f.previous.Kind == lexer.LBrace && !f.atLineStart
+	switch {
+	case emptyBlock:
+	case f.atStatementStart() && token.Kind != lexer.Catch:
+		f.newline()
+		if newlines > 1 && f.hasPrevious && f.previous.Kind != lexer.LBrace {
+			f.blankLine()
+		}
+		f.builder.WriteString(strings.Repeat(indentation, f.depth))
+	case f.atLineStart:
+		f.builder.WriteString(strings.Repeat(indentation, f.depth+1))
+	case f.declaring && token.Kind == lexer.LParen:
+	case object && token.Kind == lexer.RBrace, f.previousObject && f.previous.Kind == lexer.LBrace:
+	case f.previousNegation:
+	case needsSpace(f.previous, token):
+		f.builder.WriteString(" ")
+	}
+
+	f.declaring = token.Kind == lexer.Fn || (f.declaring && token.Kind == lexer.Identifier)
+
+	f.builder.WriteString(token.Lexeme)
+	if token.Kind == lexer.LBrace && !object {
+		f.depth++
+	}
+	f.previous = token
+	f.previousObject = object
+	f.previousNegation = negation
+	f.hasPrevious = true
+	f.atLineStart = false
+}
+
+func (f *formatter) writeComment(text string, ownLine bool, blankLineBefore bool) {
+	if ownLine {
+		f.newline()
+		if blankLineBefore && f.builder.Len() > 0 {
+			f.blankLine()
+		}
+
+		depth := f.depth
+		if !f.atStatementStart() {
+			depth++
+		}
+		f.builder.WriteString(strings.Repeat(indentation, depth))
+	} else {
+		f.builder.WriteString(" ")
+	}
+
+	f.builder.WriteString(text)
+	f.builder.WriteString("\n")
+	f.atLineStart = true
+}
+
+func (f *formatter) atStatementStart() bool {
+	if !f.hasPrevious {
+		return true
+	}
+
+	switch f.previous.Kind {
+	case lexer.Semicolon:
+		return true
+	case lexer.LBrace, lexer.RBrace:
+		return !f.previousObject
+	default:
+		return false
+	}
+}
+
+func (f *formatter) newline() {
+	if !f.atLineStart {
+		f.builder.WriteString("\n")
+		f.atLineStart = true
+	}
+}
+
+func (f *formatter) blankLine() {
+	if !strings.HasSuffix(f.builder.String(), "\n\n") {
+		f.builder.WriteString("\n")
+	}
+}
+
+func needsSpace(previous lexer.Token, current lexer.Token) bool {
+	switch current.Kind {
+	case lexer.RParen, lexer.Semicolon, lexer.Comma, lexer.Colon, lexer.Dot:
+		return false
+	case lexer.LParen:
+		if isCall(previous, current) {
+			return false
+		}
+	}
+	return previous.Kind != lexer.LParen && previous.Kind != lexer.Dot
+}
+
+func opensObject(previous lexer.Token) bool {
+	switch previous.Kind {
+	case lexer.LParen, lexer.Comma, lexer.Equals, lexer.Colon, lexer.Return, lexer.Plus, lexer.Minus, lexer.Star, lexer.Slash:
+		return true
+	default:
+		return false
+	}
+}
+
+func isCall(previous lexer.Token, current lexer.Token) bool {
+	adjacent := previous.Position+len(previous.Lexeme) == current.Position
+	return adjacent && (previous.Kind == lexer.Identifier || previous.Kind == lexer.RParen)
+}
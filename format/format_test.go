@@ -0,0 +1,142 @@
+package format
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GivenSource_WhenFormat_ThenShouldReturnCanonicalLayout(t *testing.T) {
+	type TestCase struct {
+		name           string
+		source         string
+		expectedSource string
+	}
+
+	testcases := []TestCase{
+		{
+			name:           "empty source",
+			source:         "",
+			expectedSource: "",
+		},
+		{
+			name:           "spacing around operators",
+			source:         "1+2*3",
+			expectedSource: "1 + 2 * 3;\n",
+		},
+		{
+			name:           "negation stays attached to its operand",
+			source:         "- x*(-2)-- 1.5;return_ = {a: -1}",
+			expectedSource: "-x * (-2) - -1.5;\nreturn_ = {a: -1};\n",
+		},
+		{
+			name:           "parentheses are preserved without inner spaces",
+			source:         "( ( 1+2 ) )*3",
+			expectedSource: "((1 + 2)) * 3;\n",
+		},
+		{
+			name:           "one statement per line",
+			source:         "let x=1;const y = x*2 ; y",
+			expectedSource: "let x = 1;\nconst y = x * 2;\ny;\n",
+		},
+		{
+			name:           "expressions split across lines are joined",
+			source:         "let total = 1 +\n  2 +\n  3;",
+			expectedSource: "let total = 1 + 2 + 3;\n",
+		},
+		{
+			name:           "blank lines are preserved and collapsed",
+			source:         "let x = 1;\n\n\n\nlet y = 2;\nlet z = 3;",
+			expectedSource: "let x = 1;\n\nlet y = 2;\nlet z = 3;\n",
+		},
+		{
+			name:           "leading comments",
+			source:         "// header\n\n// about x\nlet x = 1;",
+			expectedSource: "// header\n\n// about x\nlet x = 1;\n",
+		},
+		{
+			name:           "trailing comments",
+			source:         "let x = 1;   // one   \nlet y = 2 // two",
+			expectedSource: "let x = 1; // one\nlet y = 2; // two\n",
+		},
+		{
+			name:           "comment inside an expression",
+			source:         "let x = 1 + // first\n2;",
+			expectedSource: "let x = 1 + // first\n\t2;\n",
+		},
+		{
+			name:           "own line comment inside an expression",
+			source:         "let x = 1 +\n// second\n2;",
+			expectedSource: "let x = 1 +\n\t// second\n\t2;\n",
+		},
+		{
+			name:           "comments at the end of the file",
+			source:         "x;\n\n// the end\n",
+			expectedSource: "x;\n\n// the end\n",
+		},
+		{
+			name:           "blocks are indented",
+			source:         "let x = 1; { x = x+1; { let y = x } }",
+			expectedSource: "let x = 1;\n{\n\tx = x + 1;\n\t{\n\t\tlet y = x;\n\t}\n}\n",
+		},
+		{
+			name:           "empty blocks",
+			source:         "{\n\n}\n{ }",
+			expectedSource: "{}\n{}\n",
+		},
+		{
+			name:           "comments inside blocks",
+			source:         "{\n// inner\nx // trailing\n}",
+			expectedSource: "{\n\t// inner\n\tx; // trailing\n}\n",
+		},
+		{
+			name:           "functions and calls",
+			source:         "fn add (a:number,b) :number {return a+b}\nadd(1 ,add( 2,3 ))",
+			expectedSource: "fn add(a: number, b): number {\n\treturn a + b;\n}\nadd(1, add(2, 3));\n",
+		},
+		{
+			name:           "members",
+			source:         "user . Name = user.Greet( \"x\" ).length",
+			expectedSource: "user.Name = user.Greet(\"x\").length;\n",
+		},
+		{
+			name:           "try and catch",
+			source:         "try{ risky() }\ncatch( e ){ e.message }",
+			expectedSource: "try {\n\trisky();\n} catch (e) {\n\te.message;\n}\n",
+		},
+		{
+			name:           "object literals stay inline",
+			source:         "let x = { a:1,\"b c\" : { d : 2 } };\nfn f() { return {}; }\nprint( {a: x} )",
+			expectedSource: "let x = {a: 1, \"b c\": {d: 2}};\nfn f() {\n\treturn {};\n}\nprint({a: x});\n",
+		},
+		{
+			name:           "strings are kept verbatim",
+			source:         `let s = "a\"b"+"c"`,
+			expectedSource: "let s = \"a\\\"b\" + \"c\";\n",
+		},
+	}
+
+	for _, test := range testcases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			formatted, err := Source([]byte(test.source))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, test.expectedSource, string(formatted))
+
+			again, err := Source(formatted)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, string(formatted), string(again))
+		})
+	}
+}
+
+func Test_GivenInvalidSource_WhenFormat_ThenShouldReturnParseError(t *testing.T) {
+	_, err := Source([]byte("let x = ;"))
+	assert.Equal(t, fmt.Errorf("Unexpected token ';' at position 8."), err)
+}
@@ -0,0 +1,55 @@
+package interpreter
+
+import (
+	"fmt"
+
+	"github.com/joaovictorjs/adam-script/value"
+)
+
+type binding struct {
+	value    value.Value
+	constant bool
+}
+
+type Environment struct {
+	parent   *Environment
+	bindings map[string]*binding
+}
+
+func NewEnvironment(parent *Environment) *Environment {
+	return &Environment{
+		parent:   parent,
+		bindings: map[string]*binding{},
+	}
+}
+
+func (e *Environment) Declare(name string, v value.Value, constant bool) error {
+	if _, ok := e.bindings[name]; ok {
+		return fmt.Errorf("Variable '%s' is already declared.", name)
+	}
+
+	e.bindings[name] = &binding{value: v, constant: constant}
+	return nil
+}
+
+func (e *Environment) Assign(name string, v value.Value) error {
+	for env := e; env != nil; env = env.parent {
+		if b, ok := env.bindings[name]; ok {
+			if b.constant {
+				return fmt.Errorf("Cannot assign to constant '%s'.", name)
+			}
+			b.value = v
+			return nil
+		}
+	}
+	return fmt.Errorf("Undefined variable '%s'.", name)
+}
+
+func (e *Environment) Lookup(name string) (value.Value, error) {
+	for env := e; env != nil; env = env.parent {
+		if b, ok := env.bindings[name]; ok {
+			return b.value, nil
+		}
+	}
+	return nil, fmt.Errorf("Undefined variable '%s'.", name)
+}
@@ -0,0 +1,31 @@
+package interpreter
+
+import (
+	"github.com/joaovictorjs/adam-script/ast"
+	"github.com/joaovictorjs/adam-script/value"
+)
+
+type Function struct {
+	declaration ast.FunctionDeclaration
+	closure     *Environment
+}
+
+func (f *Function) Type() string {
+	return "function"
+}
+
+func (f *Function) String() string {
+	return "<fn " + f.declaration.Name + ">"
+}
+
+func (f *Function) Arity() int {
+	return len(f.declaration.Parameters)
+}
+
+type returnSignal struct {
+	value value.Value
+}
+
+func (returnSignal) Error() string {
+	return "Return statement outside of function."
+}
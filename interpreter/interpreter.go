@@ -0,0 +1,261 @@
+package interpreter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/joaovictorjs/adam-script/ast"
+	"github.com/joaovictorjs/adam-script/value"
+	"github.com/joaovictorjs/adam-script/vm"
+)
+
+type Interpreter struct {
+	environment *Environment
+	depth       int
+}
+
+func NewInterpreter() *Interpreter {
+	return &Interpreter{
+		environment: NewEnvironment(nil),
+	}
+}
+
+// SetGlobal declares name in the global environment, or assigns it when a
+// previous evaluation already declared it.
+func (i *Interpreter) SetGlobal(name string, v value.Value) error {
+	if _, err := i.environment.Lookup(name); err != nil {
+		return i.environment.Declare(name, v, false)
+	}
+	return i.environment.Assign(name, v)
+}
+
+func (i *Interpreter) Evaluate(program ast.Program) (value.Value, error) {
+	return i.evaluateStatements(program.Statements)
+}
+
+func (i *Interpreter) evaluateStatements(statements []ast.Statement) (value.Value, error) {
+	for _, statement := range statements {
+		if declaration, ok := statement.(ast.FunctionDeclaration); ok {
+			function := &Function{declaration: declaration, closure: i.environment}
+			if err := i.environment.Declare(declaration.Name, function, false); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	var result value.Value = value.Null{}
+	for _, statement := range statements {
+		v, err := i.evaluateStatement(statement)
+		if err != nil {
+			return nil, err
+		}
+		result = v
+	}
+	return result, nil
+}
+
+func (i *Interpreter) evaluateStatement(statement ast.Statement) (value.Value, error) {
+	switch s := statement.(type) {
+	case ast.BlockStatement:
+		return i.evaluateBlock(s)
+	case ast.ExpressionStatement:
+		return i.evaluateExpression(s.Expression)
+	case ast.VariableDeclaration:
+		v, err := i.evaluateExpression(s.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := i.environment.Declare(s.Identifier, v, s.Constant); err != nil {
+			return nil, err
+		}
+		return value.Null{}, nil
+	case ast.FunctionDeclaration:
+		return value.Null{}, nil
+	case ast.ReturnStatement:
+		var result value.Value = value.Null{}
+		if s.Value != nil {
+			v, err := i.evaluateExpression(s.Value)
+			if err != nil {
+				return nil, err
+			}
+			result = v
+		}
+		return nil, returnSignal{value: result}
+	case ast.TryStatement:
+		return i.evaluateTry(s)
+	default:
+		return nil, fmt.Errorf("Unsupported statement '%T'.", statement)
+	}
+}
+
+func (i *Interpreter) evaluateBlock(block ast.BlockStatement) (value.Value, error) {
+	previous := i.environment
+	i.environment = NewEnvironment(previous)
+	defer func() {
+		i.environment = previous
+	}()
+
+	return i.evaluateStatements(block.Statements)
+}
+
+func (i *Interpreter) evaluateTry(statement ast.TryStatement) (value.Value, error) {
+	result, err := i.evaluateBlock(statement.Body)
+	// Like the VM, try leaves the call depth limit to the host.
+	var signal returnSignal
+	var callDepth *vm.CallDepthError
+	if err == nil || errors.As(err, &signal) || errors.As(err, &callDepth) {
+		return result, err
+	}
+
+	previous := i.environment
+	i.environment = NewEnvironment(previous)
+	defer func() {
+		i.environment = previous
+	}()
+
+	if err := i.environment.Declare(statement.Parameter.Name, value.FromError(err), false); err != nil {
+		return nil, err
+	}
+	return i.evaluateStatements(statement.Handler.Statements)
+}
+
+func (i *Interpreter) call(callee value.Value, arguments []value.Value) (value.Value, error) {
+	if native, ok := callee.(*vm.Native); ok {
+		return native.Function(context.Background(), arguments)
+	}
+
+	function, ok := callee.(*Function)
+	if !ok {
+		return nil, fmt.Errorf("Value of type '%s' is not callable.", callee.Type())
+	}
+
+	if len(arguments) != function.Arity() {
+		return nil, fmt.Errorf("Function '%s' expects %d arguments but got %d.", function.declaration.Name, function.Arity(), len(arguments))
+	}
+
+	// The script itself counts as the first frame, as it does in the VM.
+	if i.depth+1 >= vm.DefaultMaxCallDepth {
+		return nil, &vm.CallDepthError{Limit: vm.DefaultMaxCallDepth}
+	}
+	i.depth++
+	defer func() {
+		i.depth--
+	}()
+
+	environment := NewEnvironment(function.closure)
+	for index, parameter := range function.declaration.Parameters {
+		if err := environment.Declare(parameter.Name, arguments[index], false); err != nil {
+			return nil, err
+		}
+	}
+
+	previous := i.environment
+	i.environment = environment
+	defer func() {
+		i.environment = previous
+	}()
+
+	_, err := i.evaluateStatements(function.declaration.Body.Statements)
+	var signal returnSignal
+	if errors.As(err, &signal) {
+		return signal.value, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return value.Null{}, nil
+}
+
+func (i *Interpreter) evaluateExpression(expression ast.Expression) (value.Value, error) {
+	switch e := expression.(type) {
+	case ast.NumericLiteralExpression:
+		return value.Number(e.Value), nil
+	case ast.StringLiteralExpression:
+		return value.String(e.Value), nil
+	case ast.IdentifierExpression:
+		return i.environment.Lookup(e.Symbol)
+	case ast.AssignmentExpression:
+		if member, ok := e.Target.(ast.MemberExpression); ok {
+			return i.assignMember(member, e.Value)
+		}
+
+		v, err := i.evaluateExpression(e.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := i.environment.Assign(e.Target.(ast.IdentifierExpression).Symbol, v); err != nil {
+			return nil, err
+		}
+		return v, nil
+	case ast.MemberExpression:
+		object, err := i.evaluateExpression(e.Object)
+		if err != nil {
+			return nil, err
+		}
+		return value.Member(object, e.Property)
+	case ast.CallExpression:
+		callee, err := i.evaluateExpression(e.Callee)
+		if err != nil {
+			return nil, err
+		}
+
+		arguments := make([]value.Value, 0, len(e.Arguments))
+		for _, argument := range e.Arguments {
+			v, err := i.evaluateExpression(argument)
+			if err != nil {
+				return nil, err
+			}
+			arguments = append(arguments, v)
+		}
+		return i.call(callee, arguments)
+	case ast.BinaryExpression:
+		left, err := i.evaluateExpression(e.Left)
+		if err != nil {
+			return nil, err
+		}
+
+		right, err := i.evaluateExpression(e.Right)
+		if err != nil {
+			return nil, err
+		}
+		return value.Binary(e.Operator, left, right)
+	case ast.UnaryExpression:
+		operand, err := i.evaluateExpression(e.Operand)
+		if err != nil {
+			return nil, err
+		}
+		return value.Unary(e.Operator, operand)
+	case ast.ObjectExpression:
+		object := make(value.Map, len(e.Properties))
+		for _, property := range e.Properties {
+			v, err := i.evaluateExpression(property.Value)
+			if err != nil {
+				return nil, err
+			}
+			object[property.Key] = v
+		}
+		return object, nil
+	default:
+		return nil, fmt.Errorf("Unsupported expression '%T'.", expression)
+	}
+}
+
+func (i *Interpreter) assignMember(member ast.MemberExpression, expression ast.Expression) (value.Value, error) {
+	object, err := i.evaluateExpression(member.Object)
+	if err != nil {
+		return nil, err
+	}
+
+	v, err := i.evaluateExpression(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := value.SetMember(object, member.Property, v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
@@ -0,0 +1,205 @@
+package interpreter
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/joaovictorjs/adam-script/parser"
+	"github.com/joaovictorjs/adam-script/value"
+	"github.com/joaovictorjs/adam-script/vm"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GivenSource_WhenEvaluate_ThenShouldReturnCorrectValue(t *testing.T) {
+	type TestCase struct {
+		name          string
+		source        string
+		expectedValue value.Value
+	}
+
+	testcases := []TestCase{
+		{
+			name:          "single number",
+			source:        "5",
+			expectedValue: value.Number(5),
+		},
+		{
+			name:          "operator precedence",
+			source:        "1 + 2 * 3",
+			expectedValue: value.Number(7),
+		},
+		{
+			name:          "parenthesized expression",
+			source:        "(1 + 2) * 3",
+			expectedValue: value.Number(9),
+		},
+		{
+			name:          "left associative subtraction",
+			source:        "10 - 4 - 3",
+			expectedValue: value.Number(3),
+		},
+		{
+			name:          "division",
+			source:        "7 / 2",
+			expectedValue: value.Number(3.5),
+		},
+		{
+			name:          "string literal",
+			source:        `"hello"`,
+			expectedValue: value.String("hello"),
+		},
+		{
+			name:          "string concatenation",
+			source:        `"hello" + " " + "world"`,
+			expectedValue: value.String("hello world"),
+		},
+		{
+			name:          "declaration evaluates to null",
+			source:        "let x = 1",
+			expectedValue: value.Null{},
+		},
+		{
+			name:          "declared variables",
+			source:        "let x = 2; const y = x * 3; y + x",
+			expectedValue: value.Number(8),
+		},
+		{
+			name:          "assignment evaluates to the assigned value",
+			source:        "let x = 1; let y = 2; x = y = x + y",
+			expectedValue: value.Number(3),
+		},
+		{
+			name:          "block evaluates to its last statement",
+			source:        "let x = 1; { let y = 2; x = x + y; y * 10 }",
+			expectedValue: value.Number(20),
+		},
+		{
+			name:          "block declarations shadow outer variables",
+			source:        "let x = 1; { let x = 5; x = 6 } x",
+			expectedValue: value.Number(1),
+		},
+		{
+			name:          "function call",
+			source:        "fn add(a: number, b: number): number { return a + b } add(1, add(2, 3))",
+			expectedValue: value.Number(6),
+		},
+		{
+			name:          "functions are hoisted",
+			source:        "let x = twice(3); fn twice(n) { return double(n) } fn double(n) { return n * 2 } x",
+			expectedValue: value.Number(6),
+		},
+		{
+			name:          "functions close over their environment",
+			source:        "let count = 0; fn increment() { count = count + 1; return } increment(); increment(); count",
+			expectedValue: value.Number(2),
+		},
+		{
+			name:          "function without return evaluates to null",
+			source:        "fn noop() { 1 } noop()",
+			expectedValue: value.Null{},
+		},
+		{
+			name:          "empty program",
+			source:        "",
+			expectedValue: value.Null{},
+		},
+	}
+
+	for _, test := range testcases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			program, err := parser.NewParser(test.source).Parse()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			result, err := NewInterpreter().Evaluate(program)
+			if err != nil {
+				t.Error(err)
+			}
+
+			assert.Equal(t, test.expectedValue, result)
+		})
+	}
+}
+
+func Test_GivenInvalidSource_WhenEvaluate_ThenShouldReturnCorrectError(t *testing.T) {
+	type TestCase struct {
+		name          string
+		source        string
+		expectedError error
+	}
+
+	testcases := []TestCase{
+		{
+			name:          "undefined variable",
+			source:        "x + 1",
+			expectedError: fmt.Errorf("Undefined variable 'x'."),
+		},
+		{
+			name:          "redeclared variable",
+			source:        "let x = 1; const x = 2",
+			expectedError: fmt.Errorf("Variable 'x' is already declared."),
+		},
+		{
+			name:          "assignment to constant",
+			source:        "const x = 1; { x = 2 }",
+			expectedError: fmt.Errorf("Cannot assign to constant 'x'."),
+		},
+		{
+			name:          "assignment to undefined variable",
+			source:        "x = 1",
+			expectedError: fmt.Errorf("Undefined variable 'x'."),
+		},
+		{
+			name:          "block variables are not visible outside",
+			source:        "{ let y = 1 } y",
+			expectedError: fmt.Errorf("Undefined variable 'y'."),
+		},
+		{
+			name:          "calling a number",
+			source:        "let x = 1; x()",
+			expectedError: fmt.Errorf("Value of type 'number' is not callable."),
+		},
+		{
+			name:          "wrong number of arguments",
+			source:        "fn f(a, b) { return a } f(1)",
+			expectedError: fmt.Errorf("Function 'f' expects 2 arguments but got 1."),
+		},
+		{
+			name:          "subtracting strings",
+			source:        `"a" - "b"`,
+			expectedError: fmt.Errorf("Invalid operands 'string' and 'string' for operator '-'."),
+		},
+		{
+			name:          "adding string and number",
+			source:        `"a" + 1`,
+			expectedError: fmt.Errorf("Invalid operands 'string' and 'number' for operator '+'."),
+		},
+	}
+
+	for _, test := range testcases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			program, err := parser.NewParser(test.source).Parse()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = NewInterpreter().Evaluate(program)
+			assert.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_GivenRunawayRecursion_WhenEvaluate_ThenShouldReturnCallDepthError(t *testing.T) {
+	program, err := parser.NewParser("fn f() { return f() } try { f() } catch (e) { e }").Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	interpreter := NewInterpreter()
+	_, err = interpreter.Evaluate(program)
+	assert.Equal(t, &vm.CallDepthError{Limit: vm.DefaultMaxCallDepth}, err)
+	assert.Zero(t, interpreter.depth)
+}
@@ -1,6 +1,7 @@
 package lexer
 
 import (
+	"strconv"
 	"unicode"
 )
 
@@ -22,28 +23,97 @@ const (
 	Equals
 	Semicolon
 	StringLiteral
+	LBrace
+	RBrace
+	Colon
+	Comma
+	Fn
+	Return
+	Dot
+	Try
+	Catch
 )
 
-var keywords = map[string]TokenKind{"let": Let, "const": Const}
+var keywords = map[string]TokenKind{"let": Let, "const": Const, "fn": Fn, "return": Return, "try": Try, "catch": Catch}
 
-type Token struct {
-	Kind     TokenKind
-	Lexeme   string
+var tokenKindNames = map[TokenKind]string{
+	EOF:            "EOF",
+	NumericLiteral: "NumericLiteral",
+	Plus:           "Plus",
+	Minus:          "Minus",
+	Star:           "Star",
+	Slash:          "Slash",
+	LParen:         "LParen",
+	RParen:         "RParen",
+	Unknown:        "Unknown",
+	Let:            "Let",
+	Const:          "Const",
+	Identifier:     "Identifier",
+	Equals:         "Equals",
+	Semicolon:      "Semicolon",
+	StringLiteral:  "StringLiteral",
+	LBrace:         "LBrace",
+	RBrace:         "RBrace",
+	Colon:          "Colon",
+	Comma:          "Comma",
+	Fn:             "Fn",
+	Return:         "Return",
+	Dot:            "Dot",
+	Try:            "Try",
+	Catch:          "Catch",
+}
+
+func (k TokenKind) String() string {
+	if name, ok := tokenKindNames[k]; ok {
+		return name
+	}
+	return "TokenKind(" + strconv.Itoa(int(k)) + ")"
+}
+
+type TriviaKind int
+
+const (
+	Whitespace TriviaKind = iota
+	Comment
+)
+
+type Trivia struct {
+	Kind     TriviaKind
+	Text     string
 	Position int
 }
 
+type Token struct {
+	Kind          TokenKind
+	Lexeme        string
+	Position      int
+	LeadingTrivia []Trivia
+}
+
 type Lexer struct {
-	source string
-	max    int
-	index  int
+	source       string
+	max          int
+	index        int
+	retainTrivia bool
 }
 
-func NewLexer(source string) *Lexer {
+type Option func(*Lexer)
+
+func WithTrivia() Option {
+	return func(l *Lexer) {
+		l.retainTrivia = true
+	}
+}
+
+func NewLexer(source string, options ...Option) *Lexer {
 	lexer := &Lexer{
 		source: source,
 		max:    len(source),
 		index:  0,
 	}
+	for _, option := range options {
+		option(lexer)
+	}
 	return lexer
 }
 
@@ -60,7 +130,13 @@ func (l *Lexer) GenerateTokens() []Token {
 }
 
 func (l *Lexer) nextToken() Token {
-	l.skipWhitespaces()
+	trivia := l.lexTrivia()
+	token := l.lexToken()
+	token.LeadingTrivia = trivia
+	return token
+}
+
+func (l *Lexer) lexToken() Token {
 	if l.index >= l.max {
 		tk := Token{Kind: EOF, Lexeme: "", Position: l.index}
 		return tk
@@ -77,7 +153,7 @@ func (l *Lexer) nextToken() Token {
 		return tk
 	}
 
-	if unicode.IsLetter(current) {
+	if unicode.IsLetter(current) || current == '_' {
 		tk := l.lexMultichar()
 		return tk
 	}
@@ -100,6 +176,16 @@ func (l *Lexer) nextToken() Token {
 		kind = Equals
 	case ';':
 		kind = Semicolon
+	case '{':
+		kind = LBrace
+	case '}':
+		kind = RBrace
+	case ':':
+		kind = Colon
+	case ',':
+		kind = Comma
+	case '.':
+		kind = Dot
 	default:
 		kind = Unknown
 	}
@@ -171,7 +257,31 @@ func (l *Lexer) lexMultichar() Token {
 	return token
 }
 
-func (l *Lexer) skipWhitespaces() {
+func (l *Lexer) lexTrivia() []Trivia {
+	var trivia []Trivia
+	for l.index < l.max {
+		start := l.index
+		kind := Whitespace
+		if l.skipWhitespaces() == 0 {
+			if !l.skipComment() {
+				break
+			}
+			kind = Comment
+		}
+
+		if l.retainTrivia {
+			trivia = append(trivia, Trivia{
+				Kind:     kind,
+				Text:     l.source[start:l.index],
+				Position: start,
+			})
+		}
+	}
+	return trivia
+}
+
+func (l *Lexer) skipWhitespaces() int {
+	start := l.index
 	for l.index < l.max {
 		char := l.source[l.index]
 		if char == ' ' || char == '\n' || char == '\t' || char == '\r' {
@@ -180,16 +290,37 @@ func (l *Lexer) skipWhitespaces() {
 		}
 		break
 	}
+	return l.index - start
+}
+
+func (l *Lexer) skipComment() bool {
+	if l.index+1 >= l.max || l.source[l.index] != '/' || l.source[l.index+1] != '/' {
+		return false
+	}
+
+	for l.index < l.max && l.source[l.index] != '\n' {
+		l.index++
+	}
+	return true
 }
 
 func (l *Lexer) lexNumericLiteral() Token {
 	start := l.index
-	for l.index < l.max {
-		current := rune(l.source[l.index])
-		if !unicode.IsDigit(current) {
-			break
-		}
+	l.skipDigits()
+	if l.index < l.max && l.source[l.index] == '.' && l.isDigitAt(l.index+1) {
 		l.index++
+		l.skipDigits()
+	}
+
+	if l.index < l.max && (l.source[l.index] == 'e' || l.source[l.index] == 'E') {
+		exponent := l.index + 1
+		if exponent < l.max && (l.source[exponent] == '+' || l.source[exponent] == '-') {
+			exponent++
+		}
+		if l.isDigitAt(exponent) {
+			l.index = exponent
+			l.skipDigits()
+		}
 	}
 
 	lexeme := l.source[start:l.index]
@@ -200,3 +331,13 @@ func (l *Lexer) lexNumericLiteral() Token {
 	}
 	return token
 }
+
+func (l *Lexer) skipDigits() {
+	for l.isDigitAt(l.index) {
+		l.index++
+	}
+}
+
+func (l *Lexer) isDigitAt(index int) bool {
+	return index < l.max && unicode.IsDigit(rune(l.source[index]))
+}
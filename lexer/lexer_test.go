@@ -30,6 +30,43 @@ func Test_GivenSource_WhenGenerateTokens_ThenShouldReturnCorrectTokenSequence(t
 				{Kind: EOF, Lexeme: "", Position: 3},
 			},
 		},
+		{
+			name:   "decimal fraction",
+			source: "3.25",
+			expectedTokens: []Token{
+				{Kind: NumericLiteral, Lexeme: "3.25", Position: 0},
+				{Kind: EOF, Lexeme: "", Position: 4},
+			},
+		},
+		{
+			name:   "exponents",
+			source: "1e300 5E-324 2.5e+3",
+			expectedTokens: []Token{
+				{Kind: NumericLiteral, Lexeme: "1e300", Position: 0},
+				{Kind: NumericLiteral, Lexeme: "5E-324", Position: 6},
+				{Kind: NumericLiteral, Lexeme: "2.5e+3", Position: 13},
+				{Kind: EOF, Lexeme: "", Position: 19},
+			},
+		},
+		{
+			name:   "dot without fraction digits",
+			source: "1.a",
+			expectedTokens: []Token{
+				{Kind: NumericLiteral, Lexeme: "1", Position: 0},
+				{Kind: Dot, Lexeme: ".", Position: 1},
+				{Kind: Identifier, Lexeme: "a", Position: 2},
+				{Kind: EOF, Lexeme: "", Position: 3},
+			},
+		},
+		{
+			name:   "exponent without digits",
+			source: "2e",
+			expectedTokens: []Token{
+				{Kind: NumericLiteral, Lexeme: "2", Position: 0},
+				{Kind: Identifier, Lexeme: "e", Position: 1},
+				{Kind: EOF, Lexeme: "", Position: 2},
+			},
+		},
 		{
 			name:   "plus operator",
 			source: "+",
@@ -269,6 +306,14 @@ func Test_GivenSource_WhenGenerateTokens_ThenShouldReturnCorrectTokenSequence(t
 				{Kind: EOF, Lexeme: "", Position: 6},
 			},
 		},
+		{
+			name:   "identifier with leading underscore",
+			source: "_unused",
+			expectedTokens: []Token{
+				{Kind: Identifier, Lexeme: "_unused", Position: 0},
+				{Kind: EOF, Lexeme: "", Position: 7},
+			},
+		},
 		{
 			name:   "identifier with numbers",
 			source: "var123",
@@ -582,6 +627,64 @@ func Test_GivenSource_WhenGenerateTokens_ThenShouldReturnCorrectTokenSequence(t
 				{Kind: EOF, Lexeme: "", Position: 11},
 			},
 		},
+		{
+			name:   "function declaration",
+			source: "fn f(a: number, b) { return a }",
+			expectedTokens: []Token{
+				{Kind: Fn, Lexeme: "fn", Position: 0},
+				{Kind: Identifier, Lexeme: "f", Position: 3},
+				{Kind: LParen, Lexeme: "(", Position: 4},
+				{Kind: Identifier, Lexeme: "a", Position: 5},
+				{Kind: Colon, Lexeme: ":", Position: 6},
+				{Kind: Identifier, Lexeme: "number", Position: 8},
+				{Kind: Comma, Lexeme: ",", Position: 14},
+				{Kind: Identifier, Lexeme: "b", Position: 16},
+				{Kind: RParen, Lexeme: ")", Position: 17},
+				{Kind: LBrace, Lexeme: "{", Position: 19},
+				{Kind: Return, Lexeme: "return", Position: 21},
+				{Kind: Identifier, Lexeme: "a", Position: 28},
+				{Kind: RBrace, Lexeme: "}", Position: 30},
+				{Kind: EOF, Lexeme: "", Position: 31},
+			},
+		},
+		{
+			name:   "braces",
+			source: "{ x }",
+			expectedTokens: []Token{
+				{Kind: LBrace, Lexeme: "{", Position: 0},
+				{Kind: Identifier, Lexeme: "x", Position: 2},
+				{Kind: RBrace, Lexeme: "}", Position: 4},
+				{Kind: EOF, Lexeme: "", Position: 5},
+			},
+		},
+		{
+			name:   "line comment",
+			source: "// comment",
+			expectedTokens: []Token{
+				{Kind: EOF, Lexeme: "", Position: 10},
+			},
+		},
+		{
+			name:   "trailing line comment",
+			source: "1 + 2 // sum\n3",
+			expectedTokens: []Token{
+				{Kind: NumericLiteral, Lexeme: "1", Position: 0},
+				{Kind: Plus, Lexeme: "+", Position: 2},
+				{Kind: NumericLiteral, Lexeme: "2", Position: 4},
+				{Kind: NumericLiteral, Lexeme: "3", Position: 13},
+				{Kind: EOF, Lexeme: "", Position: 14},
+			},
+		},
+		{
+			name:   "division is not a comment",
+			source: "4 / 2",
+			expectedTokens: []Token{
+				{Kind: NumericLiteral, Lexeme: "4", Position: 0},
+				{Kind: Slash, Lexeme: "/", Position: 2},
+				{Kind: NumericLiteral, Lexeme: "2", Position: 4},
+				{Kind: EOF, Lexeme: "", Position: 5},
+			},
+		},
 	}
 
 	for _, testcase := range testcases {
@@ -593,3 +696,34 @@ func Test_GivenSource_WhenGenerateTokens_ThenShouldReturnCorrectTokenSequence(t
 		})
 	}
 }
+
+func Test_GivenSourceWithTrivia_WhenGenerateTokensWithTrivia_ThenShouldAttachLeadingTrivia(t *testing.T) {
+	lexer := NewLexer("// header\n\nlet x = 1; // one\n", WithTrivia())
+	tokens := lexer.GenerateTokens()
+
+	assert.Equal(t, []Token{
+		{
+			Kind:     Let,
+			Lexeme:   "let",
+			Position: 11,
+			LeadingTrivia: []Trivia{
+				{Kind: Comment, Text: "// header", Position: 0},
+				{Kind: Whitespace, Text: "\n\n", Position: 9},
+			},
+		},
+		{Kind: Identifier, Lexeme: "x", Position: 15, LeadingTrivia: []Trivia{{Kind: Whitespace, Text: " ", Position: 14}}},
+		{Kind: Equals, Lexeme: "=", Position: 17, LeadingTrivia: []Trivia{{Kind: Whitespace, Text: " ", Position: 16}}},
+		{Kind: NumericLiteral, Lexeme: "1", Position: 19, LeadingTrivia: []Trivia{{Kind: Whitespace, Text: " ", Position: 18}}},
+		{Kind: Semicolon, Lexeme: ";", Position: 20},
+		{
+			Kind:     EOF,
+			Lexeme:   "",
+			Position: 29,
+			LeadingTrivia: []Trivia{
+				{Kind: Whitespace, Text: " ", Position: 21},
+				{Kind: Comment, Text: "// one", Position: 22},
+				{Kind: Whitespace, Text: "\n", Position: 28},
+			},
+		},
+	}, tokens)
+}
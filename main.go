@@ -1,10 +1,94 @@
 package main
 
 import (
+	"context"
+	"flag"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+
 	"github.com/joaovictorjs/adam-script/repl"
 )
 
 func main() {
-	repl := repl.NewREPL()
+	args := os.Args[1:]
+	if len(args) == 0 {
+		args = []string{"repl"}
+	}
+
+	switch args[0] {
+	case "repl":
+		runRepl(args[1:])
+	case "fmt":
+		os.Exit(runFmt(args[1:]))
+	case "ast":
+		os.Exit(runAst(args[1:]))
+	case "check":
+		os.Exit(runCheck(args[1:]))
+	case "run":
+		os.Exit(runRun(args[1:]))
+	case "build":
+		os.Exit(runBuild(args[1:]))
+	case "disasm":
+		os.Exit(runDisasm(args[1:]))
+	case "help", "-h", "--help":
+		printUsage()
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown command '%s'.\n", args[0])
+		printUsage()
+		os.Exit(2)
+	}
+}
+
+func runRepl(args []string) {
+	flags := flag.NewFlagSet("repl", flag.ExitOnError)
+	jsonMode := flags.Bool("json", false, "answer each input line with one JSON object, without banner, colors or prompts")
+	listen := flags.String("listen", "", "serve independent sessions on 'unix:/path' or 'tcp:host:port' without authentication, an empty host means loopback")
+	flags.Parse(args)
+
+	options := repl.Options{JSON: *jsonMode, ErrorOutput: os.Stderr}
+	if *listen != "" {
+		serveRepl(*listen, options)
+		return
+	}
+
+	repl := repl.NewREPL(os.Stdin, os.Stdout, options)
 	repl.Run()
 }
+
+func serveRepl(address string, options repl.Options) {
+	listener, err := repl.Listen(address)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	server := repl.NewServer(listener, options)
+	fmt.Fprintf(os.Stderr, "Serving REPL sessions on %s.\n", server.Addr())
+	if tcp, ok := server.Addr().(*net.TCPAddr); ok && !tcp.IP.IsLoopback() {
+		fmt.Fprintln(os.Stderr, "Warning: sessions are not authenticated and can be opened from other machines.")
+	}
+	if err := server.Serve(ctx); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func printUsage() {
+	fmt.Fprintln(os.Stderr, "Usage: adam <command> [arguments]")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "Commands:")
+	fmt.Fprintln(os.Stderr, "  repl    Start an interactive session (default)")
+	fmt.Fprintln(os.Stderr, "  fmt     Format AdamScript source files")
+	fmt.Fprintln(os.Stderr, "  ast     Print the AST of a source file as json, sexpr or dot")
+	fmt.Fprintln(os.Stderr, "  check   Report name resolution and, with --strict, type errors")
+	fmt.Fprintln(os.Stderr, "  run     Execute a source, .adamc or, with --ast, JSON AST file on the virtual machine")
+	fmt.Fprintln(os.Stderr, "  build   Compile a source file into an .adamc module, optimized with -O")
+	fmt.Fprintln(os.Stderr, "  disasm  Print the bytecode of a source or .adamc file")
+	fmt.Fprintln(os.Stderr, "  help    Show this message")
+}
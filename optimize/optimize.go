@@ -0,0 +1,141 @@
+package optimize
+
+import (
+	"github.com/joaovictorjs/adam-script/ast"
+	"github.com/joaovictorjs/adam-script/diagnostic"
+)
+
+type optimizer struct {
+	diagnostics []diagnostic.Diagnostic
+}
+
+func Optimize(program ast.Program) (ast.Program, []diagnostic.Diagnostic) {
+	o := &optimizer{}
+	optimized := ast.Rewrite(program, o.rewrite).(ast.Program)
+	return optimized, o.diagnostics
+}
+
+func (o *optimizer) rewrite(node ast.Node) ast.Node {
+	if unary, ok := node.(ast.UnaryExpression); ok {
+		if operand, ok := unary.Operand.(ast.NumericLiteralExpression); ok && unary.Operator == '-' {
+			return ast.NumericLiteralExpression{Value: -operand.Value, Span: unary.Span}
+		}
+		return node
+	}
+
+	binary, ok := node.(ast.BinaryExpression)
+	if !ok {
+		return node
+	}
+
+	if binary.Operator == '/' && isZero(binary.Right) {
+		o.diagnostics = append(o.diagnostics, diagnostic.Diagnostic{
+			Severity: diagnostic.Warning,
+			Code:     "division-by-zero",
+			Message:  "Division by zero.",
+			Span:     binary.Span,
+		})
+		return node
+	}
+
+	if folded, ok := fold(binary); ok {
+		return folded
+	}
+
+	if simplified, ok := simplify(binary); ok {
+		return simplified
+	}
+	return node
+}
+
+func fold(binary ast.BinaryExpression) (ast.Expression, bool) {
+	left, leftOk := binary.Left.(ast.NumericLiteralExpression)
+	right, rightOk := binary.Right.(ast.NumericLiteralExpression)
+	if leftOk && rightOk {
+		var value float64
+		switch binary.Operator {
+		case '+':
+			value = left.Value + right.Value
+		case '-':
+			value = left.Value - right.Value
+		case '*':
+			value = left.Value * right.Value
+		case '/':
+			value = left.Value / right.Value
+		default:
+			return nil, false
+		}
+		return ast.NumericLiteralExpression{Value: value, Span: binary.Span}, true
+	}
+
+	leftString, leftOk := binary.Left.(ast.StringLiteralExpression)
+	rightString, rightOk := binary.Right.(ast.StringLiteralExpression)
+	if leftOk && rightOk && binary.Operator == '+' {
+		return ast.StringLiteralExpression{Value: leftString.Value + rightString.Value, Span: binary.Span}, true
+	}
+	return nil, false
+}
+
+// x + 0 is left alone because it turns -0 into 0.
+func simplify(binary ast.BinaryExpression) (ast.Expression, bool) {
+	switch binary.Operator {
+	case '*':
+		if isOne(binary.Right) && isNumeric(binary.Left) {
+			return withSpan(binary.Left, binary.Span), true
+		}
+		if isOne(binary.Left) && isNumeric(binary.Right) {
+			return withSpan(binary.Right, binary.Span), true
+		}
+	case '/':
+		if isOne(binary.Right) && isNumeric(binary.Left) {
+			return withSpan(binary.Left, binary.Span), true
+		}
+	case '-':
+		if isZero(binary.Right) && isNumeric(binary.Left) {
+			return withSpan(binary.Left, binary.Span), true
+		}
+	}
+	return nil, false
+}
+
+// withSpan copies a kept operand with the span of the expression it
+// replaces, so positions keep pointing at the whole rewritten expression.
+func withSpan(expression ast.Expression, span ast.Span) ast.Expression {
+	switch e := expression.(type) {
+	case ast.NumericLiteralExpression:
+		e.Span = span
+		return e
+	case ast.UnaryExpression:
+		e.Span = span
+		return e
+	case ast.BinaryExpression:
+		e.Span = span
+		return e
+	default:
+		return expression
+	}
+}
+
+func isNumeric(expression ast.Expression) bool {
+	switch e := expression.(type) {
+	case ast.NumericLiteralExpression, ast.UnaryExpression:
+		return true
+	case ast.BinaryExpression:
+		if e.Operator == '+' {
+			return isNumeric(e.Left) && isNumeric(e.Right)
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+func isZero(expression ast.Expression) bool {
+	literal, ok := expression.(ast.NumericLiteralExpression)
+	return ok && literal.Value == 0
+}
+
+func isOne(expression ast.Expression) bool {
+	literal, ok := expression.(ast.NumericLiteralExpression)
+	return ok && literal.Value == 1
+}
@@ -0,0 +1,162 @@
+package optimize
+
+import (
+	"testing"
+
+	"github.com/joaovictorjs/adam-script/ast"
+	"github.com/joaovictorjs/adam-script/diagnostic"
+	"github.com/joaovictorjs/adam-script/parser"
+	"github.com/joaovictorjs/adam-script/render"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GivenSource_WhenOptimize_ThenShouldReturnSimplifiedProgram(t *testing.T) {
+	type TestCase struct {
+		name                string
+		source              string
+		expectedSExpr       string
+		expectedDiagnostics []diagnostic.Diagnostic
+	}
+
+	testcases := []TestCase{
+		{
+			name:          "constant arithmetic",
+			source:        "2 * (3 + 4)",
+			expectedSExpr: "14\n",
+		},
+		{
+			name:          "fractional result",
+			source:        "7 / 2 - 1",
+			expectedSExpr: "2.5\n",
+		},
+		{
+			name:          "constant strings",
+			source:        `"a" + "b" + "c"`,
+			expectedSExpr: "\"abc\"\n",
+		},
+		{
+			name:          "partially constant expression",
+			source:        "x + 2 * 3",
+			expectedSExpr: "(+ x 6)\n",
+		},
+		{
+			name:          "left associative chain is not reassociated",
+			source:        "x + 1 + 2",
+			expectedSExpr: "(+ (+ x 1) 2)\n",
+		},
+		{
+			name:          "multiplication by one of a numeric expression",
+			source:        "(x - y) * 1",
+			expectedSExpr: "(- x y)\n",
+		},
+		{
+			name:          "one times a numeric expression",
+			source:        "1 * (x / y)",
+			expectedSExpr: "(/ x y)\n",
+		},
+		{
+			name:          "division by one of a numeric expression",
+			source:        "(x * 2) / (3 - 2)",
+			expectedSExpr: "(* x 2)\n",
+		},
+		{
+			name:          "subtraction of zero from a numeric expression",
+			source:        "(x * y) - 0",
+			expectedSExpr: "(* x y)\n",
+		},
+		{
+			name:          "identity on an identifier is kept",
+			source:        "x * 1",
+			expectedSExpr: "(* x 1)\n",
+		},
+		{
+			name:          "addition of zero is kept",
+			source:        "(x * y) + 0",
+			expectedSExpr: "(+ (* x y) 0)\n",
+		},
+		{
+			name:          "negated constant",
+			source:        "-(2 * 3.5) + x",
+			expectedSExpr: "(+ -7 x)\n",
+		},
+		{
+			name:          "multiplication by one of a negation",
+			source:        "-x * 1",
+			expectedSExpr: "(- x)\n",
+		},
+		{
+			name:          "declarations are optimized",
+			source:        "let x = 60 * 60; x",
+			expectedSExpr: "(let x 3600)\nx\n",
+		},
+		{
+			name:          "division by zero",
+			source:        "let x = 1 / (2 - 2);",
+			expectedSExpr: "(let x (/ 1 0))\n",
+			expectedDiagnostics: []diagnostic.Diagnostic{
+				{
+					Severity: diagnostic.Warning,
+					Code:     "division-by-zero",
+					Message:  "Division by zero.",
+					Span:     ast.Span{Start: 8, End: 19},
+				},
+			},
+		},
+	}
+
+	for _, test := range testcases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			program, err := parser.NewParser(test.source).Parse()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			optimized, diagnostics := Optimize(program)
+			assert.Equal(t, test.expectedSExpr, render.SExpr(optimized))
+			assert.Equal(t, test.expectedDiagnostics, diagnostics)
+		})
+	}
+}
+
+func Test_GivenFoldedExpression_WhenOptimize_ThenShouldKeepOriginalSpan(t *testing.T) {
+	program, err := parser.NewParser("let x = 2 * (3 + 4);").Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	optimized, _ := Optimize(program)
+	declaration := optimized.Statements[0].(ast.VariableDeclaration)
+	assert.Equal(t, ast.NumericLiteralExpression{Value: 14, Span: ast.Span{Start: 8, End: 19}}, declaration.Value)
+}
+
+func Test_GivenSource_WhenOptimize_ThenShouldKeepSpanOfRewrittenExpression(t *testing.T) {
+	type TestCase struct {
+		name         string
+		source       string
+		expectedSpan ast.Span
+	}
+
+	testcases := []TestCase{
+		{name: "folded constant", source: "x; 2 * (3 + 4)", expectedSpan: ast.Span{Start: 3, End: 14}},
+		{name: "folded negation", source: "x; -(1 + 2)", expectedSpan: ast.Span{Start: 3, End: 11}},
+		{name: "multiplication by one", source: "x; (x - y) * 1", expectedSpan: ast.Span{Start: 3, End: 14}},
+		{name: "one times", source: "x; 1 * -x", expectedSpan: ast.Span{Start: 3, End: 9}},
+		{name: "division by one", source: "x; (x * 2) / 1", expectedSpan: ast.Span{Start: 3, End: 14}},
+		{name: "subtraction of zero", source: "x; (x * y) - 0", expectedSpan: ast.Span{Start: 3, End: 14}},
+	}
+
+	for _, test := range testcases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			program, err := parser.NewParser(test.source).Parse()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			optimized, _ := Optimize(program)
+			statement := optimized.Statements[1].(ast.ExpressionStatement)
+			assert.Equal(t, test.expectedSpan, ast.SpanOf(statement.Expression))
+		})
+	}
+}
@@ -4,15 +4,18 @@ import (
 	"fmt"
 	"slices"
 	"strconv"
+	"strings"
 
 	"github.com/joaovictorjs/adam-script/ast"
 	"github.com/joaovictorjs/adam-script/lexer"
 )
 
 type Parser struct {
-	tokens []lexer.Token
-	max    int
-	index  int
+	tokens        []lexer.Token
+	max           int
+	index         int
+	end           int
+	functionDepth int
 }
 
 func NewParser(source string) *Parser {
@@ -32,6 +35,7 @@ func (p *Parser) Parse() (ast.Program, error) {
 	for {
 		token := p.peek()
 		if token.Kind == lexer.EOF {
+			program.Span = ast.Span{Start: 0, End: token.Position}
 			return program, nil
 		}
 
@@ -45,18 +49,314 @@ func (p *Parser) Parse() (ast.Program, error) {
 }
 
 func (p *Parser) parseStatement() (ast.Statement, error) {
-	expr, err := p.parseAdditiveExpression()
+	token := p.peek()
+	start := token.Position
+
+	switch token.Kind {
+	case lexer.Let, lexer.Const:
+		declaration, err := p.parseVariableDeclaration()
+		if err != nil {
+			return nil, err
+		}
+
+		p.skipSemicolon()
+		declaration.Span = p.spanFrom(start)
+		return declaration, nil
+	case lexer.LBrace:
+		return p.parseBlockStatement()
+	case lexer.Fn:
+		return p.parseFunctionDeclaration()
+	case lexer.Try:
+		return p.parseTryStatement()
+	case lexer.Return:
+		if p.functionDepth == 0 {
+			p.advance()
+			return nil, handleUnexpectedToken(token)
+		}
+		return p.parseReturnStatement()
+	default:
+		expr, err := p.parseExpression()
+		if err != nil {
+			return nil, err
+		}
+
+		p.skipSemicolon()
+		statement := ast.ExpressionStatement{
+			Expression: expr,
+			Span:       p.spanFrom(start),
+		}
+		return statement, nil
+	}
+}
+
+func (p *Parser) skipSemicolon() {
+	token := p.peek()
+	if p.isExpected(token, lexer.Semicolon) {
+		p.advance()
+	}
+}
+
+func (p *Parser) parseBlockStatement() (ast.BlockStatement, error) {
+	start := p.peek().Position
+	p.advance()
+
+	block := ast.BlockStatement{}
+	for {
+		token := p.peek()
+		if token.Kind == lexer.RBrace {
+			break
+		}
+		if token.Kind == lexer.EOF {
+			return block, handleUnexpectedToken(token)
+		}
+
+		statement, err := p.parseStatement()
+		if err != nil {
+			return block, err
+		}
+		block.Statements = append(block.Statements, statement)
+	}
+
+	p.advance()
+	block.Span = p.spanFrom(start)
+	return block, nil
+}
+
+func (p *Parser) parseFunctionDeclaration() (ast.FunctionDeclaration, error) {
+	start := p.peek().Position
+	p.advance()
+
+	token := p.peek()
+	if !p.isExpected(token, lexer.Identifier) {
+		return ast.FunctionDeclaration{}, handleUnexpectedToken(token)
+	}
+
+	declaration := ast.FunctionDeclaration{Name: token.Lexeme}
+	p.advance()
+	token = p.peek()
+	if !p.isExpected(token, lexer.LParen) {
+		return ast.FunctionDeclaration{}, handleUnexpectedToken(token)
+	}
+
+	p.advance()
+	for p.peek().Kind != lexer.RParen {
+		if len(declaration.Parameters) > 0 {
+			token = p.peek()
+			if !p.isExpected(token, lexer.Comma) {
+				return ast.FunctionDeclaration{}, handleUnexpectedToken(token)
+			}
+			p.advance()
+		}
+
+		parameter, err := p.parseParameter()
+		if err != nil {
+			return ast.FunctionDeclaration{}, err
+		}
+		declaration.Parameters = append(declaration.Parameters, parameter)
+	}
+
+	p.advance()
+	returnType, err := p.parseTypeAnnotation()
 	if err != nil {
-		return nil, err
+		return ast.FunctionDeclaration{}, err
+	}
+	declaration.ReturnType = returnType
+
+	token = p.peek()
+	if !p.isExpected(token, lexer.LBrace) {
+		return ast.FunctionDeclaration{}, handleUnexpectedToken(token)
 	}
 
-	statement := ast.ExpressionStatement{
-		Expression: expr,
+	p.functionDepth++
+	body, err := p.parseBlockStatement()
+	p.functionDepth--
+	if err != nil {
+		return ast.FunctionDeclaration{}, err
+	}
+
+	declaration.Body = body
+	declaration.Span = p.spanFrom(start)
+	return declaration, nil
+}
+
+func (p *Parser) parseTryStatement() (ast.TryStatement, error) {
+	start := p.peek().Position
+	p.advance()
+
+	token := p.peek()
+	if !p.isExpected(token, lexer.LBrace) {
+		return ast.TryStatement{}, handleUnexpectedToken(token)
 	}
+
+	body, err := p.parseBlockStatement()
+	if err != nil {
+		return ast.TryStatement{}, err
+	}
+
+	statement := ast.TryStatement{Body: body}
+	for _, kind := range []lexer.TokenKind{lexer.Catch, lexer.LParen} {
+		token = p.peek()
+		if !p.isExpected(token, kind) {
+			return ast.TryStatement{}, handleUnexpectedToken(token)
+		}
+		p.advance()
+	}
+
+	token = p.peek()
+	if !p.isExpected(token, lexer.Identifier) {
+		return ast.TryStatement{}, handleUnexpectedToken(token)
+	}
+	p.advance()
+	statement.Parameter = ast.Parameter{Name: token.Lexeme, Span: p.spanFrom(token.Position)}
+
+	token = p.peek()
+	if !p.isExpected(token, lexer.RParen) {
+		return ast.TryStatement{}, handleUnexpectedToken(token)
+	}
+	p.advance()
+
+	token = p.peek()
+	if !p.isExpected(token, lexer.LBrace) {
+		return ast.TryStatement{}, handleUnexpectedToken(token)
+	}
+
+	handler, err := p.parseBlockStatement()
+	if err != nil {
+		return ast.TryStatement{}, err
+	}
+
+	statement.Handler = handler
+	statement.Span = p.spanFrom(start)
 	return statement, nil
 }
 
+func (p *Parser) parseParameter() (ast.Parameter, error) {
+	token := p.peek()
+	if !p.isExpected(token, lexer.Identifier) {
+		return ast.Parameter{}, handleUnexpectedToken(token)
+	}
+
+	p.advance()
+	parameterType, err := p.parseTypeAnnotation()
+	if err != nil {
+		return ast.Parameter{}, err
+	}
+
+	parameter := ast.Parameter{
+		Name: token.Lexeme,
+		Type: parameterType,
+		Span: p.spanFrom(token.Position),
+	}
+	return parameter, nil
+}
+
+func (p *Parser) parseTypeAnnotation() (string, error) {
+	if p.peek().Kind != lexer.Colon {
+		return "", nil
+	}
+
+	p.advance()
+	token := p.peek()
+	if !p.isExpected(token, lexer.Identifier) {
+		return "", handleUnexpectedToken(token)
+	}
+
+	p.advance()
+	return token.Lexeme, nil
+}
+
+func (p *Parser) parseReturnStatement() (ast.ReturnStatement, error) {
+	start := p.peek().Position
+	p.advance()
+
+	statement := ast.ReturnStatement{}
+	if !p.isExpected(p.peek(), lexer.Semicolon, lexer.RBrace, lexer.EOF) {
+		value, err := p.parseExpression()
+		if err != nil {
+			return ast.ReturnStatement{}, err
+		}
+		statement.Value = value
+	}
+
+	p.skipSemicolon()
+	statement.Span = p.spanFrom(start)
+	return statement, nil
+}
+
+func (p *Parser) parseVariableDeclaration() (ast.VariableDeclaration, error) {
+	constant := p.peek().Kind == lexer.Const
+	p.advance()
+
+	token := p.peek()
+	if !p.isExpected(token, lexer.Identifier) {
+		return ast.VariableDeclaration{}, handleUnexpectedToken(token)
+	}
+
+	identifier := token.Lexeme
+	p.advance()
+	variableType, err := p.parseTypeAnnotation()
+	if err != nil {
+		return ast.VariableDeclaration{}, err
+	}
+
+	token = p.peek()
+	if !p.isExpected(token, lexer.Equals) {
+		return ast.VariableDeclaration{}, handleUnexpectedToken(token)
+	}
+
+	p.advance()
+	value, err := p.parseExpression()
+	if err != nil {
+		return ast.VariableDeclaration{}, err
+	}
+
+	declaration := ast.VariableDeclaration{
+		Constant:   constant,
+		Identifier: identifier,
+		Type:       variableType,
+		Value:      value,
+	}
+	return declaration, nil
+}
+
+func (p *Parser) parseExpression() (ast.Expression, error) {
+	return p.parseAssignmentExpression()
+}
+
+func (p *Parser) parseAssignmentExpression() (ast.Expression, error) {
+	start := p.peek().Position
+	left, err := p.parseAdditiveExpression()
+	if err != nil {
+		return nil, err
+	}
+
+	token := p.peek()
+	if token.Kind != lexer.Equals {
+		return left, nil
+	}
+
+	switch left.(type) {
+	case ast.IdentifierExpression, ast.MemberExpression:
+	default:
+		return nil, handleUnexpectedToken(token)
+	}
+
+	p.advance()
+	value, err := p.parseAssignmentExpression()
+	if err != nil {
+		return nil, err
+	}
+
+	assignment := ast.AssignmentExpression{
+		Target: left,
+		Value:  value,
+		Span:   p.spanFrom(start),
+	}
+	return assignment, nil
+}
+
 func (p *Parser) parseAdditiveExpression() (ast.Expression, error) {
+	start := p.peek().Position
 	left, err := p.parseMultiplicativeExpression()
 	if err != nil {
 		return nil, err
@@ -69,7 +369,7 @@ func (p *Parser) parseAdditiveExpression() (ast.Expression, error) {
 		}
 
 		operator := token.Lexeme[0]
-		p.index++
+		p.advance()
 		right, err := p.parseMultiplicativeExpression()
 		if err != nil {
 			return nil, err
@@ -79,6 +379,7 @@ func (p *Parser) parseAdditiveExpression() (ast.Expression, error) {
 			Left:     left,
 			Operator: operator,
 			Right:    right,
+			Span:     p.spanFrom(start),
 		}
 	}
 
@@ -86,7 +387,8 @@ func (p *Parser) parseAdditiveExpression() (ast.Expression, error) {
 }
 
 func (p *Parser) parseMultiplicativeExpression() (ast.Expression, error) {
-	left, err := p.parsePrimaryExpression()
+	start := p.peek().Position
+	left, err := p.parseUnaryExpression()
 	if err != nil {
 		return nil, err
 	}
@@ -98,8 +400,8 @@ func (p *Parser) parseMultiplicativeExpression() (ast.Expression, error) {
 		}
 
 		operator := token.Lexeme[0]
-		p.index++
-		right, err := p.parsePrimaryExpression()
+		p.advance()
+		right, err := p.parseUnaryExpression()
 		if err != nil {
 			return nil, err
 		}
@@ -108,12 +410,110 @@ func (p *Parser) parseMultiplicativeExpression() (ast.Expression, error) {
 			Left:     left,
 			Operator: operator,
 			Right:    right,
+			Span:     p.spanFrom(start),
 		}
 	}
 
 	return left, nil
 }
 
+func (p *Parser) parseUnaryExpression() (ast.Expression, error) {
+	token := p.peek()
+	if token.Kind != lexer.Minus {
+		return p.parsePostfixExpression()
+	}
+
+	p.advance()
+	operand, err := p.parseUnaryExpression()
+	if err != nil {
+		return nil, err
+	}
+
+	expr := ast.UnaryExpression{
+		Operator: token.Lexeme[0],
+		Operand:  operand,
+		Span:     p.spanFrom(token.Position),
+	}
+	return expr, nil
+}
+
+func (p *Parser) parsePostfixExpression() (ast.Expression, error) {
+	start := p.peek().Position
+	expr, err := p.parsePrimaryExpression()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		token := p.peek()
+		switch {
+		case token.Kind == lexer.LParen && token.Position == p.end:
+			expr, err = p.parseCallExpression(expr, start)
+		case token.Kind == lexer.Dot:
+			expr, err = p.parseMemberExpression(expr, start)
+		default:
+			return expr, nil
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (p *Parser) parseMemberExpression(object ast.Expression, start int) (ast.Expression, error) {
+	p.advance()
+	token := p.peek()
+	if !p.isExpected(token, lexer.Identifier) {
+		return nil, handleUnexpectedToken(token)
+	}
+
+	p.advance()
+	if !p.isValidCalleeFollower(p.peek()) {
+		return nil, handleUnexpectedToken(p.peek())
+	}
+
+	expr := ast.MemberExpression{
+		Object:   object,
+		Property: token.Lexeme,
+		Span:     p.spanFrom(start),
+	}
+	return expr, nil
+}
+
+func (p *Parser) parseCallExpression(callee ast.Expression, start int) (ast.Expression, error) {
+	p.advance()
+	arguments := []ast.Expression{}
+	for p.peek().Kind != lexer.RParen {
+		if len(arguments) > 0 {
+			token := p.peek()
+			if !p.isExpected(token, lexer.Comma) {
+				return nil, handleUnexpectedToken(token)
+			}
+			p.advance()
+		}
+
+		argument, err := p.parseExpression()
+		if err != nil {
+			return nil, err
+		}
+		arguments = append(arguments, argument)
+	}
+
+	p.advance()
+	token := p.peek()
+	if !p.isValidCalleeFollower(token) {
+		return nil, handleUnexpectedToken(token)
+	}
+
+	expr := ast.CallExpression{
+		Callee:    callee,
+		Arguments: arguments,
+		Span:      p.spanFrom(start),
+	}
+	return expr, nil
+}
+
 func (p *Parser) parsePrimaryExpression() (ast.Expression, error) {
 	token := p.peek()
 
@@ -122,10 +522,11 @@ func (p *Parser) parsePrimaryExpression() (ast.Expression, error) {
 		{
 			expr := ast.IdentifierExpression{
 				Symbol: token.Lexeme,
+				Span:   tokenSpan(token),
 			}
-			p.index++
+			p.advance()
 			token = p.peek()
-			if !p.isValidExpressionFollower(token) {
+			if !p.isValidCalleeFollower(token) {
 				err := handleUnexpectedToken(token)
 				return nil, err
 			}
@@ -138,20 +539,37 @@ func (p *Parser) parsePrimaryExpression() (ast.Expression, error) {
 				return nil, err
 			}
 
-			p.index++
+			expr := ast.NumericLiteralExpression{
+				Value: valueAsFloat,
+				Span:  tokenSpan(token),
+			}
+			p.advance()
 			token = p.peek()
 			if !p.isValidExpressionFollower(token) {
 				err := handleUnexpectedToken(token)
 				return nil, err
 			}
 
-			expr := ast.NumericLiteralExpression{Value: valueAsFloat}
+			return expr, nil
+		}
+	case lexer.StringLiteral:
+		{
+			expr := ast.StringLiteralExpression{
+				Value: unquote(token.Lexeme),
+				Span:  tokenSpan(token),
+			}
+			p.advance()
+			token = p.peek()
+			if !p.isValidExpressionFollower(token) {
+				err := handleUnexpectedToken(token)
+				return nil, err
+			}
 			return expr, nil
 		}
 	case lexer.LParen:
 		{
-			p.index++
-			expr, err := p.parseAdditiveExpression()
+			p.advance()
+			expr, err := p.parseExpression()
 			if err != nil {
 				return nil, err
 			}
@@ -162,7 +580,7 @@ func (p *Parser) parsePrimaryExpression() (ast.Expression, error) {
 				return nil, err
 			}
 
-			p.index++
+			p.advance()
 			token = p.peek()
 			if !p.isValidExpressionFollower(token) {
 				return nil, handleUnexpectedToken(token)
@@ -170,15 +588,81 @@ func (p *Parser) parsePrimaryExpression() (ast.Expression, error) {
 
 			return expr, nil
 		}
+	case lexer.LBrace:
+		return p.parseObjectExpression()
 	default:
 		{
-			p.index++
+			p.advance()
 			err := handleUnexpectedToken(token)
 			return nil, err
 		}
 	}
 }
 
+func (p *Parser) parseObjectExpression() (ast.Expression, error) {
+	start := p.peek().Position
+	p.advance()
+
+	expr := ast.ObjectExpression{}
+	for p.peek().Kind != lexer.RBrace {
+		if len(expr.Properties) > 0 {
+			token := p.peek()
+			if !p.isExpected(token, lexer.Comma) {
+				return nil, handleUnexpectedToken(token)
+			}
+			p.advance()
+		}
+
+		property, err := p.parseProperty()
+		if err != nil {
+			return nil, err
+		}
+		expr.Properties = append(expr.Properties, property)
+	}
+
+	p.advance()
+	token := p.peek()
+	if !p.isValidCalleeFollower(token) {
+		return nil, handleUnexpectedToken(token)
+	}
+
+	expr.Span = p.spanFrom(start)
+	return expr, nil
+}
+
+func (p *Parser) parseProperty() (ast.Property, error) {
+	token := p.peek()
+	var key string
+	switch token.Kind {
+	case lexer.Identifier:
+		key = token.Lexeme
+	case lexer.StringLiteral:
+		key = unquote(token.Lexeme)
+	default:
+		p.advance()
+		return ast.Property{}, handleUnexpectedToken(token)
+	}
+
+	p.advance()
+	colon := p.peek()
+	if !p.isExpected(colon, lexer.Colon) {
+		return ast.Property{}, handleUnexpectedToken(colon)
+	}
+
+	p.advance()
+	value, err := p.parseExpression()
+	if err != nil {
+		return ast.Property{}, err
+	}
+
+	property := ast.Property{
+		Key:   key,
+		Value: value,
+		Span:  p.spanFrom(token.Position),
+	}
+	return property, nil
+}
+
 func handleUnexpectedToken(token lexer.Token) error {
 	return fmt.Errorf("Unexpected token '%s' at position %d.", token.Lexeme, token.Position)
 }
@@ -191,15 +675,63 @@ func (p *Parser) isValidExpressionFollower(token lexer.Token) bool {
 		lexer.Star,
 		lexer.Slash,
 		lexer.RParen,
+		lexer.Comma,
+		lexer.Equals,
+		lexer.Semicolon,
+		lexer.RBrace,
 		lexer.EOF,
 	)
 }
 
+func (p *Parser) isValidCalleeFollower(token lexer.Token) bool {
+	isCall := token.Kind == lexer.LParen && token.Position == p.end
+	return isCall || token.Kind == lexer.Dot || p.isValidExpressionFollower(token)
+}
+
+func unquote(lexeme string) string {
+	var builder strings.Builder
+	content := lexeme[1 : len(lexeme)-1]
+	for i := 0; i < len(content); i++ {
+		char := content[i]
+		if char != '\\' || i+1 >= len(content) {
+			builder.WriteByte(char)
+			continue
+		}
+
+		i++
+		switch content[i] {
+		case 'n':
+			builder.WriteByte('\n')
+		case 't':
+			builder.WriteByte('\t')
+		case 'r':
+			builder.WriteByte('\r')
+		default:
+			builder.WriteByte(content[i])
+		}
+	}
+	return builder.String()
+}
+
 func (p *Parser) isExpected(token lexer.Token, expected ...lexer.TokenKind) bool {
 	isExpected := slices.Contains(expected, token.Kind)
 	return isExpected
 }
 
+func (p *Parser) advance() {
+	token := p.peek()
+	p.end = token.Position + len(token.Lexeme)
+	p.index++
+}
+
+func (p *Parser) spanFrom(start int) ast.Span {
+	return ast.Span{Start: start, End: p.end}
+}
+
+func tokenSpan(token lexer.Token) ast.Span {
+	return ast.Span{Start: token.Position, End: token.Position + len(token.Lexeme)}
+}
+
 func (p *Parser) peek() lexer.Token {
 	if p.index < p.max {
 		return p.tokens[p.index]
@@ -5,96 +5,215 @@ import (
 	"testing"
 
 	"github.com/joaovictorjs/adam-script/ast"
+	"github.com/joaovictorjs/adam-script/printer"
 	"github.com/stretchr/testify/assert"
 )
 
-func Test_GivenSource_WhenParse_ThenShouldReturnCorrectProgram(t *testing.T) {
-	type TestCase struct {
-		name            string
-		source          string
-		expectedProgram ast.Program
-	}
+type parseTestCase struct {
+	name            string
+	source          string
+	expectedProgram ast.Program
+}
 
-	testcases := []TestCase{
-		{
-			name:   "single number",
-			source: "5",
-			expectedProgram: ast.Program{
-				Statements: []ast.Statement{
-					ast.ExpressionStatement{
-						Expression: ast.NumericLiteralExpression{
-							Value: 5,
-						},
+var parseTestCases = []parseTestCase{
+	{
+		name:   "single number",
+		source: "5",
+		expectedProgram: ast.Program{
+			Statements: []ast.Statement{
+				ast.ExpressionStatement{
+					Expression: ast.NumericLiteralExpression{
+						Value: 5,
 					},
 				},
 			},
 		},
-		{
-			name:   "simple addition",
-			source: "1 + 2",
-			expectedProgram: ast.Program{
-				Statements: []ast.Statement{
-					ast.ExpressionStatement{
-						Expression: ast.BinaryExpression{
-							Left: ast.NumericLiteralExpression{
-								Value: 1,
+	},
+	{
+		name:   "decimal number",
+		source: "2.5e-3",
+		expectedProgram: ast.Program{
+			Statements: []ast.Statement{
+				ast.ExpressionStatement{
+					Expression: ast.NumericLiteralExpression{
+						Value: 0.0025,
+					},
+				},
+			},
+		},
+	},
+	{
+		name:   "negation binds tighter than multiplication",
+		source: "-x * --2",
+		expectedProgram: ast.Program{
+			Statements: []ast.Statement{
+				ast.ExpressionStatement{
+					Expression: ast.BinaryExpression{
+						Left: ast.UnaryExpression{
+							Operator: '-',
+							Operand: ast.IdentifierExpression{
+								Symbol: "x",
 							},
-							Operator: '+',
-							Right: ast.NumericLiteralExpression{
-								Value: 2,
+						},
+						Operator: '*',
+						Right: ast.UnaryExpression{
+							Operator: '-',
+							Operand: ast.UnaryExpression{
+								Operator: '-',
+								Operand: ast.NumericLiteralExpression{
+									Value: 2,
+								},
 							},
 						},
 					},
 				},
 			},
 		},
-		{
-			name:   "simple subtraction",
-			source: "10 - 5",
-			expectedProgram: ast.Program{
-				Statements: []ast.Statement{
-					ast.ExpressionStatement{
-						Expression: ast.BinaryExpression{
-							Left: ast.NumericLiteralExpression{
-								Value: 10,
-							},
+	},
+	{
+		name:   "negated call",
+		source: "1 - -f(2)",
+		expectedProgram: ast.Program{
+			Statements: []ast.Statement{
+				ast.ExpressionStatement{
+					Expression: ast.BinaryExpression{
+						Left: ast.NumericLiteralExpression{
+							Value: 1,
+						},
+						Operator: '-',
+						Right: ast.UnaryExpression{
 							Operator: '-',
-							Right: ast.NumericLiteralExpression{
-								Value: 5,
+							Operand: ast.CallExpression{
+								Callee: ast.IdentifierExpression{
+									Symbol: "f",
+								},
+								Arguments: []ast.Expression{
+									ast.NumericLiteralExpression{
+										Value: 2,
+									},
+								},
 							},
 						},
 					},
 				},
 			},
 		},
-		{
-			name:   "simple multiplication",
-			source: "3 * 4",
-			expectedProgram: ast.Program{
-				Statements: []ast.Statement{
-					ast.ExpressionStatement{
-						Expression: ast.BinaryExpression{
+	},
+	{
+		name:   "simple addition",
+		source: "1 + 2",
+		expectedProgram: ast.Program{
+			Statements: []ast.Statement{
+				ast.ExpressionStatement{
+					Expression: ast.BinaryExpression{
+						Left: ast.NumericLiteralExpression{
+							Value: 1,
+						},
+						Operator: '+',
+						Right: ast.NumericLiteralExpression{
+							Value: 2,
+						},
+					},
+				},
+			},
+		},
+	},
+	{
+		name:   "simple subtraction",
+		source: "10 - 5",
+		expectedProgram: ast.Program{
+			Statements: []ast.Statement{
+				ast.ExpressionStatement{
+					Expression: ast.BinaryExpression{
+						Left: ast.NumericLiteralExpression{
+							Value: 10,
+						},
+						Operator: '-',
+						Right: ast.NumericLiteralExpression{
+							Value: 5,
+						},
+					},
+				},
+			},
+		},
+	},
+	{
+		name:   "simple multiplication",
+		source: "3 * 4",
+		expectedProgram: ast.Program{
+			Statements: []ast.Statement{
+				ast.ExpressionStatement{
+					Expression: ast.BinaryExpression{
+						Left: ast.NumericLiteralExpression{
+							Value: 3,
+						},
+						Operator: '*',
+						Right: ast.NumericLiteralExpression{
+							Value: 4,
+						},
+					},
+				},
+			},
+		},
+	},
+	{
+		name:   "simple division",
+		source: "8 / 2",
+		expectedProgram: ast.Program{
+			Statements: []ast.Statement{
+				ast.ExpressionStatement{
+					Expression: ast.BinaryExpression{
+						Left: ast.NumericLiteralExpression{
+							Value: 8,
+						},
+						Operator: '/',
+						Right: ast.NumericLiteralExpression{
+							Value: 2,
+						},
+					},
+				},
+			},
+		},
+	},
+	{
+		name:   "operator precedence multiplication before addition",
+		source: "1 + 2 * 3",
+		expectedProgram: ast.Program{
+			Statements: []ast.Statement{
+				ast.ExpressionStatement{
+					Expression: ast.BinaryExpression{
+						Left: ast.NumericLiteralExpression{
+							Value: 1,
+						},
+						Operator: '+',
+						Right: ast.BinaryExpression{
 							Left: ast.NumericLiteralExpression{
-								Value: 3,
+								Value: 2,
 							},
 							Operator: '*',
 							Right: ast.NumericLiteralExpression{
-								Value: 4,
+								Value: 3,
 							},
 						},
 					},
 				},
 			},
 		},
-		{
-			name:   "simple division",
-			source: "8 / 2",
-			expectedProgram: ast.Program{
-				Statements: []ast.Statement{
-					ast.ExpressionStatement{
-						Expression: ast.BinaryExpression{
+	},
+	{
+		name:   "operator precedence division before subtraction",
+		source: "10 - 6 / 2",
+		expectedProgram: ast.Program{
+			Statements: []ast.Statement{
+				ast.ExpressionStatement{
+					Expression: ast.BinaryExpression{
+						Left: ast.NumericLiteralExpression{
+							Value: 10,
+						},
+						Operator: '-',
+						Right: ast.BinaryExpression{
 							Left: ast.NumericLiteralExpression{
-								Value: 8,
+								Value: 6,
 							},
 							Operator: '/',
 							Right: ast.NumericLiteralExpression{
@@ -105,113 +224,109 @@ func Test_GivenSource_WhenParse_ThenShouldReturnCorrectProgram(t *testing.T) {
 				},
 			},
 		},
-		{
-			name:   "operator precedence multiplication before addition",
-			source: "1 + 2 * 3",
-			expectedProgram: ast.Program{
-				Statements: []ast.Statement{
-					ast.ExpressionStatement{
-						Expression: ast.BinaryExpression{
+	},
+	{
+		name:   "left associativity addition",
+		source: "1 + 2 + 3",
+		expectedProgram: ast.Program{
+			Statements: []ast.Statement{
+				ast.ExpressionStatement{
+					Expression: ast.BinaryExpression{
+						Left: ast.BinaryExpression{
 							Left: ast.NumericLiteralExpression{
 								Value: 1,
 							},
 							Operator: '+',
-							Right: ast.BinaryExpression{
-								Left: ast.NumericLiteralExpression{
-									Value: 2,
-								},
-								Operator: '*',
-								Right: ast.NumericLiteralExpression{
-									Value: 3,
-								},
+							Right: ast.NumericLiteralExpression{
+								Value: 2,
 							},
 						},
+						Operator: '+',
+						Right: ast.NumericLiteralExpression{
+							Value: 3,
+						},
 					},
 				},
 			},
 		},
-		{
-			name:   "operator precedence division before subtraction",
-			source: "10 - 6 / 2",
-			expectedProgram: ast.Program{
-				Statements: []ast.Statement{
-					ast.ExpressionStatement{
-						Expression: ast.BinaryExpression{
+	},
+	{
+		name:   "left associativity multiplication",
+		source: "2 * 3 * 4",
+		expectedProgram: ast.Program{
+			Statements: []ast.Statement{
+				ast.ExpressionStatement{
+					Expression: ast.BinaryExpression{
+						Left: ast.BinaryExpression{
 							Left: ast.NumericLiteralExpression{
-								Value: 10,
+								Value: 2,
 							},
-							Operator: '-',
-							Right: ast.BinaryExpression{
-								Left: ast.NumericLiteralExpression{
-									Value: 6,
-								},
-								Operator: '/',
-								Right: ast.NumericLiteralExpression{
-									Value: 2,
-								},
+							Operator: '*',
+							Right: ast.NumericLiteralExpression{
+								Value: 3,
 							},
 						},
+						Operator: '*',
+						Right: ast.NumericLiteralExpression{
+							Value: 4,
+						},
 					},
 				},
 			},
 		},
-		{
-			name:   "left associativity addition",
-			source: "1 + 2 + 3",
-			expectedProgram: ast.Program{
-				Statements: []ast.Statement{
-					ast.ExpressionStatement{
-						Expression: ast.BinaryExpression{
-							Left: ast.BinaryExpression{
-								Left: ast.NumericLiteralExpression{
-									Value: 1,
-								},
-								Operator: '+',
-								Right: ast.NumericLiteralExpression{
-									Value: 2,
-								},
-							},
-							Operator: '+',
-							Right: ast.NumericLiteralExpression{
-								Value: 3,
-							},
+	},
+	{
+		name:   "parenthesized expression",
+		source: "(1 + 2)",
+		expectedProgram: ast.Program{
+			Statements: []ast.Statement{
+				ast.ExpressionStatement{
+					Expression: ast.BinaryExpression{
+						Left: ast.NumericLiteralExpression{
+							Value: 1,
+						},
+						Operator: '+',
+						Right: ast.NumericLiteralExpression{
+							Value: 2,
 						},
 					},
 				},
 			},
 		},
-		{
-			name:   "left associativity multiplication",
-			source: "2 * 3 * 4",
-			expectedProgram: ast.Program{
-				Statements: []ast.Statement{
-					ast.ExpressionStatement{
-						Expression: ast.BinaryExpression{
-							Left: ast.BinaryExpression{
-								Left: ast.NumericLiteralExpression{
-									Value: 2,
-								},
-								Operator: '*',
-								Right: ast.NumericLiteralExpression{
-									Value: 3,
-								},
+	},
+	{
+		name:   "parentheses override precedence",
+		source: "(1 + 2) * 3",
+		expectedProgram: ast.Program{
+			Statements: []ast.Statement{
+				ast.ExpressionStatement{
+					Expression: ast.BinaryExpression{
+						Left: ast.BinaryExpression{
+							Left: ast.NumericLiteralExpression{
+								Value: 1,
 							},
-							Operator: '*',
+							Operator: '+',
 							Right: ast.NumericLiteralExpression{
-								Value: 4,
+								Value: 2,
 							},
 						},
+						Operator: '*',
+						Right: ast.NumericLiteralExpression{
+							Value: 3,
+						},
 					},
 				},
 			},
 		},
-		{
-			name:   "parenthesized expression",
-			source: "(1 + 2)",
-			expectedProgram: ast.Program{
-				Statements: []ast.Statement{
-					ast.ExpressionStatement{
-						Expression: ast.BinaryExpression{
+	},
+	{
+		name:   "nested parentheses",
+		source: "((1 + 2) * 3)",
+		expectedProgram: ast.Program{
+			Statements: []ast.Statement{
+				ast.ExpressionStatement{
+					Expression: ast.BinaryExpression{
+						Left: ast.BinaryExpression{
 							Left: ast.NumericLiteralExpression{
 								Value: 1,
 							},
@@ -220,121 +335,130 @@ func Test_GivenSource_WhenParse_ThenShouldReturnCorrectProgram(t *testing.T) {
 								Value: 2,
 							},
 						},
+						Operator: '*',
+						Right: ast.NumericLiteralExpression{
+							Value: 3,
+						},
 					},
 				},
 			},
 		},
-		{
-			name:   "parentheses override precedence",
-			source: "(1 + 2) * 3",
-			expectedProgram: ast.Program{
-				Statements: []ast.Statement{
-					ast.ExpressionStatement{
-						Expression: ast.BinaryExpression{
-							Left: ast.BinaryExpression{
+	},
+	{
+		name:   "complex expression with all operators",
+		source: "1 + 2 * 3 - 4 / 2",
+		expectedProgram: ast.Program{
+			Statements: []ast.Statement{
+				ast.ExpressionStatement{
+					Expression: ast.BinaryExpression{
+						Left: ast.BinaryExpression{
+							Left: ast.NumericLiteralExpression{
+								Value: 1,
+							},
+							Operator: '+',
+							Right: ast.BinaryExpression{
 								Left: ast.NumericLiteralExpression{
-									Value: 1,
+									Value: 2,
 								},
-								Operator: '+',
+								Operator: '*',
 								Right: ast.NumericLiteralExpression{
-									Value: 2,
+									Value: 3,
 								},
 							},
-							Operator: '*',
+						},
+						Operator: '-',
+						Right: ast.BinaryExpression{
+							Left: ast.NumericLiteralExpression{
+								Value: 4,
+							},
+							Operator: '/',
 							Right: ast.NumericLiteralExpression{
-								Value: 3,
+								Value: 2,
 							},
 						},
 					},
 				},
 			},
 		},
-		{
-			name:   "nested parentheses",
-			source: "((1 + 2) * 3)",
-			expectedProgram: ast.Program{
-				Statements: []ast.Statement{
-					ast.ExpressionStatement{
-						Expression: ast.BinaryExpression{
-							Left: ast.BinaryExpression{
-								Left: ast.NumericLiteralExpression{
-									Value: 1,
-								},
-								Operator: '+',
-								Right: ast.NumericLiteralExpression{
-									Value: 2,
-								},
+	},
+	{
+		name:   "complex with multiple parentheses",
+		source: "(10 + 20) * (30 - 5)",
+		expectedProgram: ast.Program{
+			Statements: []ast.Statement{
+				ast.ExpressionStatement{
+					Expression: ast.BinaryExpression{
+						Left: ast.BinaryExpression{
+							Left: ast.NumericLiteralExpression{
+								Value: 10,
 							},
-							Operator: '*',
+							Operator: '+',
 							Right: ast.NumericLiteralExpression{
-								Value: 3,
+								Value: 20,
+							},
+						},
+						Operator: '*',
+						Right: ast.BinaryExpression{
+							Left: ast.NumericLiteralExpression{
+								Value: 30,
+							},
+							Operator: '-',
+							Right: ast.NumericLiteralExpression{
+								Value: 5,
 							},
 						},
 					},
 				},
 			},
 		},
-		{
-			name:   "complex expression with all operators",
-			source: "1 + 2 * 3 - 4 / 2",
-			expectedProgram: ast.Program{
-				Statements: []ast.Statement{
-					ast.ExpressionStatement{
-						Expression: ast.BinaryExpression{
+	},
+	{
+		name:   "deeply nested expression",
+		source: "((10 + 20) * (30 - 5)) / ((8 + 2) * (15 - 3))",
+		expectedProgram: ast.Program{
+			Statements: []ast.Statement{
+				ast.ExpressionStatement{
+					Expression: ast.BinaryExpression{
+						Left: ast.BinaryExpression{
 							Left: ast.BinaryExpression{
 								Left: ast.NumericLiteralExpression{
-									Value: 1,
+									Value: 10,
 								},
 								Operator: '+',
-								Right: ast.BinaryExpression{
-									Left: ast.NumericLiteralExpression{
-										Value: 2,
-									},
-									Operator: '*',
-									Right: ast.NumericLiteralExpression{
-										Value: 3,
-									},
+								Right: ast.NumericLiteralExpression{
+									Value: 20,
 								},
 							},
-							Operator: '-',
+							Operator: '*',
 							Right: ast.BinaryExpression{
 								Left: ast.NumericLiteralExpression{
-									Value: 4,
+									Value: 30,
 								},
-								Operator: '/',
+								Operator: '-',
 								Right: ast.NumericLiteralExpression{
-									Value: 2,
+									Value: 5,
 								},
 							},
 						},
-					},
-				},
-			},
-		},
-		{
-			name:   "complex with multiple parentheses",
-			source: "(10 + 20) * (30 - 5)",
-			expectedProgram: ast.Program{
-				Statements: []ast.Statement{
-					ast.ExpressionStatement{
-						Expression: ast.BinaryExpression{
+						Operator: '/',
+						Right: ast.BinaryExpression{
 							Left: ast.BinaryExpression{
 								Left: ast.NumericLiteralExpression{
-									Value: 10,
+									Value: 8,
 								},
 								Operator: '+',
 								Right: ast.NumericLiteralExpression{
-									Value: 20,
+									Value: 2,
 								},
 							},
 							Operator: '*',
 							Right: ast.BinaryExpression{
 								Left: ast.NumericLiteralExpression{
-									Value: 30,
+									Value: 15,
 								},
 								Operator: '-',
 								Right: ast.NumericLiteralExpression{
-									Value: 5,
+									Value: 3,
 								},
 							},
 						},
@@ -342,257 +466,533 @@ func Test_GivenSource_WhenParse_ThenShouldReturnCorrectProgram(t *testing.T) {
 				},
 			},
 		},
-		{
-			name:   "deeply nested expression",
-			source: "((10 + 20) * (30 - 5)) / ((8 + 2) * (15 - 3))",
-			expectedProgram: ast.Program{
-				Statements: []ast.Statement{
-					ast.ExpressionStatement{
-						Expression: ast.BinaryExpression{
-							Left: ast.BinaryExpression{
-								Left: ast.BinaryExpression{
-									Left: ast.NumericLiteralExpression{
-										Value: 10,
-									},
-									Operator: '+',
-									Right: ast.NumericLiteralExpression{
-										Value: 20,
-									},
-								},
-								Operator: '*',
-								Right: ast.BinaryExpression{
-									Left: ast.NumericLiteralExpression{
-										Value: 30,
-									},
-									Operator: '-',
-									Right: ast.NumericLiteralExpression{
-										Value: 5,
-									},
-								},
+	},
+	{
+		name:   "expression with spaces",
+		source: "  1   +   2  ",
+		expectedProgram: ast.Program{
+			Statements: []ast.Statement{
+				ast.ExpressionStatement{
+					Expression: ast.BinaryExpression{
+						Left: ast.NumericLiteralExpression{
+							Value: 1,
+						},
+						Operator: '+',
+						Right: ast.NumericLiteralExpression{
+							Value: 2,
+						},
+					},
+				},
+			},
+		},
+	},
+	{
+		name:   "expression without spaces",
+		source: "1+2*3",
+		expectedProgram: ast.Program{
+			Statements: []ast.Statement{
+				ast.ExpressionStatement{
+					Expression: ast.BinaryExpression{
+						Left: ast.NumericLiteralExpression{
+							Value: 1,
+						},
+						Operator: '+',
+						Right: ast.BinaryExpression{
+							Left: ast.NumericLiteralExpression{
+								Value: 2,
 							},
-							Operator: '/',
-							Right: ast.BinaryExpression{
-								Left: ast.BinaryExpression{
-									Left: ast.NumericLiteralExpression{
-										Value: 8,
-									},
-									Operator: '+',
-									Right: ast.NumericLiteralExpression{
-										Value: 2,
-									},
-								},
-								Operator: '*',
-								Right: ast.BinaryExpression{
-									Left: ast.NumericLiteralExpression{
-										Value: 15,
-									},
-									Operator: '-',
-									Right: ast.NumericLiteralExpression{
-										Value: 3,
-									},
-								},
+							Operator: '*',
+							Right: ast.NumericLiteralExpression{
+								Value: 3,
+							},
+						},
+					},
+				},
+			},
+		},
+	},
+	{
+		name:   "single identifier",
+		source: "x",
+		expectedProgram: ast.Program{
+			Statements: []ast.Statement{
+				ast.ExpressionStatement{
+					Expression: ast.IdentifierExpression{
+						Symbol: "x",
+					},
+				},
+			},
+		},
+	},
+	{
+		name:   "identifier with underscore",
+		source: "my_var",
+		expectedProgram: ast.Program{
+			Statements: []ast.Statement{
+				ast.ExpressionStatement{
+					Expression: ast.IdentifierExpression{
+						Symbol: "my_var",
+					},
+				},
+			},
+		},
+	},
+	{
+		name:   "identifier with numbers",
+		source: "var123",
+		expectedProgram: ast.Program{
+			Statements: []ast.Statement{
+				ast.ExpressionStatement{
+					Expression: ast.IdentifierExpression{
+						Symbol: "var123",
+					},
+				},
+			},
+		},
+	},
+	{
+		name:   "identifier in addition",
+		source: "x + 5",
+		expectedProgram: ast.Program{
+			Statements: []ast.Statement{
+				ast.ExpressionStatement{
+					Expression: ast.BinaryExpression{
+						Left: ast.IdentifierExpression{
+							Symbol: "x",
+						},
+						Operator: '+',
+						Right: ast.NumericLiteralExpression{
+							Value: 5,
+						},
+					},
+				},
+			},
+		},
+	},
+	{
+		name:   "identifier in subtraction",
+		source: "10 - y",
+		expectedProgram: ast.Program{
+			Statements: []ast.Statement{
+				ast.ExpressionStatement{
+					Expression: ast.BinaryExpression{
+						Left: ast.NumericLiteralExpression{
+							Value: 10,
+						},
+						Operator: '-',
+						Right: ast.IdentifierExpression{
+							Symbol: "y",
+						},
+					},
+				},
+			},
+		},
+	},
+	{
+		name:   "two identifiers in expression",
+		source: "a + b",
+		expectedProgram: ast.Program{
+			Statements: []ast.Statement{
+				ast.ExpressionStatement{
+					Expression: ast.BinaryExpression{
+						Left: ast.IdentifierExpression{
+							Symbol: "a",
+						},
+						Operator: '+',
+						Right: ast.IdentifierExpression{
+							Symbol: "b",
+						},
+					},
+				},
+			},
+		},
+	},
+	{
+		name:   "identifier in multiplication",
+		source: "x * 3",
+		expectedProgram: ast.Program{
+			Statements: []ast.Statement{
+				ast.ExpressionStatement{
+					Expression: ast.BinaryExpression{
+						Left: ast.IdentifierExpression{
+							Symbol: "x",
+						},
+						Operator: '*',
+						Right: ast.NumericLiteralExpression{
+							Value: 3,
+						},
+					},
+				},
+			},
+		},
+	},
+	{
+		name:   "identifier in division",
+		source: "total / count",
+		expectedProgram: ast.Program{
+			Statements: []ast.Statement{
+				ast.ExpressionStatement{
+					Expression: ast.BinaryExpression{
+						Left: ast.IdentifierExpression{
+							Symbol: "total",
+						},
+						Operator: '/',
+						Right: ast.IdentifierExpression{
+							Symbol: "count",
+						},
+					},
+				},
+			},
+		},
+	},
+	{
+		name:   "complex expression with identifiers",
+		source: "a + b * c",
+		expectedProgram: ast.Program{
+			Statements: []ast.Statement{
+				ast.ExpressionStatement{
+					Expression: ast.BinaryExpression{
+						Left: ast.IdentifierExpression{
+							Symbol: "a",
+						},
+						Operator: '+',
+						Right: ast.BinaryExpression{
+							Left: ast.IdentifierExpression{
+								Symbol: "b",
+							},
+							Operator: '*',
+							Right: ast.IdentifierExpression{
+								Symbol: "c",
+							},
+						},
+					},
+				},
+			},
+		},
+	},
+	{
+		name:   "identifiers with parentheses",
+		source: "(x + y) * z",
+		expectedProgram: ast.Program{
+			Statements: []ast.Statement{
+				ast.ExpressionStatement{
+					Expression: ast.BinaryExpression{
+						Left: ast.BinaryExpression{
+							Left: ast.IdentifierExpression{
+								Symbol: "x",
+							},
+							Operator: '+',
+							Right: ast.IdentifierExpression{
+								Symbol: "y",
+							},
+						},
+						Operator: '*',
+						Right: ast.IdentifierExpression{
+							Symbol: "z",
+						},
+					},
+				},
+			},
+		},
+	},
+	{
+		name:   "mixed identifiers and numbers",
+		source: "2 * x + 3 * y",
+		expectedProgram: ast.Program{
+			Statements: []ast.Statement{
+				ast.ExpressionStatement{
+					Expression: ast.BinaryExpression{
+						Left: ast.BinaryExpression{
+							Left: ast.NumericLiteralExpression{
+								Value: 2,
+							},
+							Operator: '*',
+							Right: ast.IdentifierExpression{
+								Symbol: "x",
 							},
 						},
-					},
-				},
-			},
-		},
-		{
-			name:   "expression with spaces",
-			source: "  1   +   2  ",
-			expectedProgram: ast.Program{
-				Statements: []ast.Statement{
-					ast.ExpressionStatement{
-						Expression: ast.BinaryExpression{
+						Operator: '+',
+						Right: ast.BinaryExpression{
 							Left: ast.NumericLiteralExpression{
-								Value: 1,
+								Value: 3,
 							},
-							Operator: '+',
-							Right: ast.NumericLiteralExpression{
-								Value: 2,
+							Operator: '*',
+							Right: ast.IdentifierExpression{
+								Symbol: "y",
 							},
 						},
 					},
 				},
 			},
 		},
-		{
-			name:   "expression without spaces",
-			source: "1+2*3",
-			expectedProgram: ast.Program{
-				Statements: []ast.Statement{
-					ast.ExpressionStatement{
-						Expression: ast.BinaryExpression{
-							Left: ast.NumericLiteralExpression{
-								Value: 1,
+	},
+	{
+		name:   "identifier in nested parentheses",
+		source: "((a + b) * (c - d))",
+		expectedProgram: ast.Program{
+			Statements: []ast.Statement{
+				ast.ExpressionStatement{
+					Expression: ast.BinaryExpression{
+						Left: ast.BinaryExpression{
+							Left: ast.IdentifierExpression{
+								Symbol: "a",
 							},
 							Operator: '+',
-							Right: ast.BinaryExpression{
-								Left: ast.NumericLiteralExpression{
-									Value: 2,
-								},
-								Operator: '*',
-								Right: ast.NumericLiteralExpression{
-									Value: 3,
-								},
+							Right: ast.IdentifierExpression{
+								Symbol: "b",
+							},
+						},
+						Operator: '*',
+						Right: ast.BinaryExpression{
+							Left: ast.IdentifierExpression{
+								Symbol: "c",
+							},
+							Operator: '-',
+							Right: ast.IdentifierExpression{
+								Symbol: "d",
 							},
 						},
 					},
 				},
 			},
 		},
-		{
-			name:   "single identifier",
-			source: "x",
-			expectedProgram: ast.Program{
-				Statements: []ast.Statement{
-					ast.ExpressionStatement{
-						Expression: ast.IdentifierExpression{
-							Symbol: "x",
-						},
+	},
+	{
+		name:   "string literal",
+		source: `"hello"`,
+		expectedProgram: ast.Program{
+			Statements: []ast.Statement{
+				ast.ExpressionStatement{
+					Expression: ast.StringLiteralExpression{
+						Value: "hello",
 					},
 				},
 			},
 		},
-		{
-			name:   "identifier with underscore",
-			source: "my_var",
-			expectedProgram: ast.Program{
-				Statements: []ast.Statement{
-					ast.ExpressionStatement{
-						Expression: ast.IdentifierExpression{
-							Symbol: "my_var",
-						},
+	},
+	{
+		name:   "string literal with escapes",
+		source: `"a\"b\\c\n"`,
+		expectedProgram: ast.Program{
+			Statements: []ast.Statement{
+				ast.ExpressionStatement{
+					Expression: ast.StringLiteralExpression{
+						Value: "a\"b\\c\n",
 					},
 				},
 			},
 		},
-		{
-			name:   "identifier with numbers",
-			source: "var123",
-			expectedProgram: ast.Program{
-				Statements: []ast.Statement{
-					ast.ExpressionStatement{
-						Expression: ast.IdentifierExpression{
-							Symbol: "var123",
+	},
+	{
+		name:   "string concatenation",
+		source: `"a" + name`,
+		expectedProgram: ast.Program{
+			Statements: []ast.Statement{
+				ast.ExpressionStatement{
+					Expression: ast.BinaryExpression{
+						Left: ast.StringLiteralExpression{
+							Value: "a",
+						},
+						Operator: '+',
+						Right: ast.IdentifierExpression{
+							Symbol: "name",
 						},
 					},
 				},
 			},
 		},
-		{
-			name:   "identifier in addition",
-			source: "x + 5",
-			expectedProgram: ast.Program{
-				Statements: []ast.Statement{
-					ast.ExpressionStatement{
-						Expression: ast.BinaryExpression{
+	},
+	{
+		name:   "let declaration",
+		source: "let x = 1;",
+		expectedProgram: ast.Program{
+			Statements: []ast.Statement{
+				ast.VariableDeclaration{
+					Identifier: "x",
+					Value: ast.NumericLiteralExpression{
+						Value: 1,
+					},
+				},
+			},
+		},
+	},
+	{
+		name:   "const declaration with expression",
+		source: "const total = a * (b + 2)",
+		expectedProgram: ast.Program{
+			Statements: []ast.Statement{
+				ast.VariableDeclaration{
+					Constant:   true,
+					Identifier: "total",
+					Value: ast.BinaryExpression{
+						Left: ast.IdentifierExpression{
+							Symbol: "a",
+						},
+						Operator: '*',
+						Right: ast.BinaryExpression{
 							Left: ast.IdentifierExpression{
-								Symbol: "x",
+								Symbol: "b",
 							},
 							Operator: '+',
 							Right: ast.NumericLiteralExpression{
-								Value: 5,
+								Value: 2,
 							},
 						},
 					},
 				},
 			},
 		},
-		{
-			name:   "identifier in subtraction",
-			source: "10 - y",
-			expectedProgram: ast.Program{
-				Statements: []ast.Statement{
-					ast.ExpressionStatement{
-						Expression: ast.BinaryExpression{
-							Left: ast.NumericLiteralExpression{
-								Value: 10,
-							},
-							Operator: '-',
-							Right: ast.IdentifierExpression{
-								Symbol: "y",
-							},
+	},
+	{
+		name:   "multiple statements",
+		source: "let x = 1; x + 2;",
+		expectedProgram: ast.Program{
+			Statements: []ast.Statement{
+				ast.VariableDeclaration{
+					Identifier: "x",
+					Value: ast.NumericLiteralExpression{
+						Value: 1,
+					},
+				},
+				ast.ExpressionStatement{
+					Expression: ast.BinaryExpression{
+						Left: ast.IdentifierExpression{
+							Symbol: "x",
+						},
+						Operator: '+',
+						Right: ast.NumericLiteralExpression{
+							Value: 2,
 						},
 					},
 				},
 			},
 		},
-		{
-			name:   "two identifiers in expression",
-			source: "a + b",
-			expectedProgram: ast.Program{
-				Statements: []ast.Statement{
-					ast.ExpressionStatement{
-						Expression: ast.BinaryExpression{
+	},
+	{
+		name:   "assignment",
+		source: "x = x + 1",
+		expectedProgram: ast.Program{
+			Statements: []ast.Statement{
+				ast.ExpressionStatement{
+					Expression: ast.AssignmentExpression{
+						Target: ast.IdentifierExpression{
+							Symbol: "x",
+						},
+						Value: ast.BinaryExpression{
 							Left: ast.IdentifierExpression{
-								Symbol: "a",
+								Symbol: "x",
 							},
 							Operator: '+',
-							Right: ast.IdentifierExpression{
-								Symbol: "b",
+							Right: ast.NumericLiteralExpression{
+								Value: 1,
 							},
 						},
 					},
 				},
 			},
 		},
-		{
-			name:   "identifier in multiplication",
-			source: "x * 3",
-			expectedProgram: ast.Program{
-				Statements: []ast.Statement{
-					ast.ExpressionStatement{
-						Expression: ast.BinaryExpression{
-							Left: ast.IdentifierExpression{
-								Symbol: "x",
+	},
+	{
+		name:   "chained assignment is right associative",
+		source: "a = b = 2 * (c = 1)",
+		expectedProgram: ast.Program{
+			Statements: []ast.Statement{
+				ast.ExpressionStatement{
+					Expression: ast.AssignmentExpression{
+						Target: ast.IdentifierExpression{
+							Symbol: "a",
+						},
+						Value: ast.AssignmentExpression{
+							Target: ast.IdentifierExpression{
+								Symbol: "b",
 							},
-							Operator: '*',
-							Right: ast.NumericLiteralExpression{
-								Value: 3,
+							Value: ast.BinaryExpression{
+								Left: ast.NumericLiteralExpression{
+									Value: 2,
+								},
+								Operator: '*',
+								Right: ast.AssignmentExpression{
+									Target: ast.IdentifierExpression{
+										Symbol: "c",
+									},
+									Value: ast.NumericLiteralExpression{
+										Value: 1,
+									},
+								},
 							},
 						},
 					},
 				},
 			},
 		},
-		{
-			name:   "identifier in division",
-			source: "total / count",
-			expectedProgram: ast.Program{
-				Statements: []ast.Statement{
-					ast.ExpressionStatement{
-						Expression: ast.BinaryExpression{
-							Left: ast.IdentifierExpression{
-								Symbol: "total",
+	},
+	{
+		name:   "nested blocks",
+		source: "{ let x = 1; { x } {} }",
+		expectedProgram: ast.Program{
+			Statements: []ast.Statement{
+				ast.BlockStatement{
+					Statements: []ast.Statement{
+						ast.VariableDeclaration{
+							Identifier: "x",
+							Value: ast.NumericLiteralExpression{
+								Value: 1,
 							},
-							Operator: '/',
-							Right: ast.IdentifierExpression{
-								Symbol: "count",
+						},
+						ast.BlockStatement{
+							Statements: []ast.Statement{
+								ast.ExpressionStatement{
+									Expression: ast.IdentifierExpression{
+										Symbol: "x",
+									},
+								},
 							},
 						},
+						ast.BlockStatement{},
 					},
 				},
 			},
 		},
-		{
-			name:   "complex expression with identifiers",
-			source: "a + b * c",
-			expectedProgram: ast.Program{
-				Statements: []ast.Statement{
-					ast.ExpressionStatement{
-						Expression: ast.BinaryExpression{
-							Left: ast.IdentifierExpression{
-								Symbol: "a",
-							},
-							Operator: '+',
-							Right: ast.BinaryExpression{
-								Left: ast.IdentifierExpression{
-									Symbol: "b",
-								},
-								Operator: '*',
-								Right: ast.IdentifierExpression{
-									Symbol: "c",
+	},
+	{
+		name:   "typed declaration",
+		source: "const x: number = 1",
+		expectedProgram: ast.Program{
+			Statements: []ast.Statement{
+				ast.VariableDeclaration{
+					Constant:   true,
+					Identifier: "x",
+					Type:       "number",
+					Value: ast.NumericLiteralExpression{
+						Value: 1,
+					},
+				},
+			},
+		},
+	},
+	{
+		name:   "function declaration with annotations",
+		source: "fn add(a: number, b): number { return a + b; }",
+		expectedProgram: ast.Program{
+			Statements: []ast.Statement{
+				ast.FunctionDeclaration{
+					Name: "add",
+					Parameters: []ast.Parameter{
+						{Name: "a", Type: "number"},
+						{Name: "b"},
+					},
+					ReturnType: "number",
+					Body: ast.BlockStatement{
+						Statements: []ast.Statement{
+							ast.ReturnStatement{
+								Value: ast.BinaryExpression{
+									Left: ast.IdentifierExpression{
+										Symbol: "a",
+									},
+									Operator: '+',
+									Right: ast.IdentifierExpression{
+										Symbol: "b",
+									},
 								},
 							},
 						},
@@ -600,96 +1000,217 @@ func Test_GivenSource_WhenParse_ThenShouldReturnCorrectProgram(t *testing.T) {
 				},
 			},
 		},
-		{
-			name:   "identifiers with parentheses",
-			source: "(x + y) * z",
-			expectedProgram: ast.Program{
-				Statements: []ast.Statement{
-					ast.ExpressionStatement{
-						Expression: ast.BinaryExpression{
-							Left: ast.BinaryExpression{
-								Left: ast.IdentifierExpression{
-									Symbol: "x",
+	},
+	{
+		name:   "function without parameters returning nothing",
+		source: "fn done() { return }",
+		expectedProgram: ast.Program{
+			Statements: []ast.Statement{
+				ast.FunctionDeclaration{
+					Name: "done",
+					Body: ast.BlockStatement{
+						Statements: []ast.Statement{
+							ast.ReturnStatement{},
+						},
+					},
+				},
+			},
+		},
+	},
+	{
+		name:   "calls",
+		source: "f(1, g(x) * 2)() + h()",
+		expectedProgram: ast.Program{
+			Statements: []ast.Statement{
+				ast.ExpressionStatement{
+					Expression: ast.BinaryExpression{
+						Left: ast.CallExpression{
+							Callee: ast.CallExpression{
+								Callee: ast.IdentifierExpression{
+									Symbol: "f",
 								},
-								Operator: '+',
-								Right: ast.IdentifierExpression{
-									Symbol: "y",
+								Arguments: []ast.Expression{
+									ast.NumericLiteralExpression{
+										Value: 1,
+									},
+									ast.BinaryExpression{
+										Left: ast.CallExpression{
+											Callee: ast.IdentifierExpression{
+												Symbol: "g",
+											},
+											Arguments: []ast.Expression{
+												ast.IdentifierExpression{
+													Symbol: "x",
+												},
+											},
+										},
+										Operator: '*',
+										Right: ast.NumericLiteralExpression{
+											Value: 2,
+										},
+									},
 								},
 							},
-							Operator: '*',
-							Right: ast.IdentifierExpression{
-								Symbol: "z",
+						},
+						Operator: '+',
+						Right: ast.CallExpression{
+							Callee: ast.IdentifierExpression{
+								Symbol: "h",
 							},
 						},
 					},
 				},
 			},
 		},
-		{
-			name:   "mixed identifiers and numbers",
-			source: "2 * x + 3 * y",
-			expectedProgram: ast.Program{
-				Statements: []ast.Statement{
-					ast.ExpressionStatement{
-						Expression: ast.BinaryExpression{
-							Left: ast.BinaryExpression{
-								Left: ast.NumericLiteralExpression{
-									Value: 2,
-								},
-								Operator: '*',
-								Right: ast.IdentifierExpression{
-									Symbol: "x",
+	},
+	{
+		name:   "member access and method calls",
+		source: "user.profile.Greet(\"x\").length",
+		expectedProgram: ast.Program{
+			Statements: []ast.Statement{
+				ast.ExpressionStatement{
+					Expression: ast.MemberExpression{
+						Object: ast.CallExpression{
+							Callee: ast.MemberExpression{
+								Object: ast.MemberExpression{
+									Object: ast.IdentifierExpression{
+										Symbol: "user",
+									},
+									Property: "profile",
 								},
+								Property: "Greet",
 							},
-							Operator: '+',
-							Right: ast.BinaryExpression{
-								Left: ast.NumericLiteralExpression{
-									Value: 3,
-								},
-								Operator: '*',
-								Right: ast.IdentifierExpression{
-									Symbol: "y",
+							Arguments: []ast.Expression{
+								ast.StringLiteralExpression{
+									Value: "x",
 								},
 							},
 						},
+						Property: "length",
 					},
 				},
 			},
 		},
-		{
-			name:   "identifier in nested parentheses",
-			source: "((a + b) * (c - d))",
-			expectedProgram: ast.Program{
-				Statements: []ast.Statement{
-					ast.ExpressionStatement{
-						Expression: ast.BinaryExpression{
-							Left: ast.BinaryExpression{
-								Left: ast.IdentifierExpression{
-									Symbol: "a",
+	},
+	{
+		name:   "member assignment",
+		source: "user.Name = x = \"y\"",
+		expectedProgram: ast.Program{
+			Statements: []ast.Statement{
+				ast.ExpressionStatement{
+					Expression: ast.AssignmentExpression{
+						Target: ast.MemberExpression{
+							Object: ast.IdentifierExpression{
+								Symbol: "user",
+							},
+							Property: "Name",
+						},
+						Value: ast.AssignmentExpression{
+							Target: ast.IdentifierExpression{
+								Symbol: "x",
+							},
+							Value: ast.StringLiteralExpression{
+								Value: "y",
+							},
+						},
+					},
+				},
+			},
+		},
+	},
+	{
+		name:   "try and catch",
+		source: "try { risky() } catch (e) { e.message }",
+		expectedProgram: ast.Program{
+			Statements: []ast.Statement{
+				ast.TryStatement{
+					Body: ast.BlockStatement{
+						Statements: []ast.Statement{
+							ast.ExpressionStatement{
+								Expression: ast.CallExpression{
+									Callee: ast.IdentifierExpression{
+										Symbol: "risky",
+									},
 								},
-								Operator: '+',
-								Right: ast.IdentifierExpression{
-									Symbol: "b",
+							},
+						},
+					},
+					Parameter: ast.Parameter{Name: "e"},
+					Handler: ast.BlockStatement{
+						Statements: []ast.Statement{
+							ast.ExpressionStatement{
+								Expression: ast.MemberExpression{
+									Object: ast.IdentifierExpression{
+										Symbol: "e",
+									},
+									Property: "message",
 								},
 							},
-							Operator: '*',
-							Right: ast.BinaryExpression{
-								Left: ast.IdentifierExpression{
-									Symbol: "c",
+						},
+					},
+				},
+			},
+		},
+	},
+	{
+		name:   "object literal",
+		source: "let o = {a: 1, \"b c\": {}, d: x + 1};",
+		expectedProgram: ast.Program{
+			Statements: []ast.Statement{
+				ast.VariableDeclaration{
+					Identifier: "o",
+					Value: ast.ObjectExpression{
+						Properties: []ast.Property{
+							{Key: "a", Value: ast.NumericLiteralExpression{Value: 1}},
+							{Key: "b c", Value: ast.ObjectExpression{}},
+							{
+								Key: "d",
+								Value: ast.BinaryExpression{
+									Left:     ast.IdentifierExpression{Symbol: "x"},
+									Operator: '+',
+									Right:    ast.NumericLiteralExpression{Value: 1},
 								},
-								Operator: '-',
-								Right: ast.IdentifierExpression{
-									Symbol: "d",
+							},
+						},
+					},
+				},
+			},
+		},
+	},
+	{
+		name:   "object literal as argument and member object",
+		source: "f({indent: 2}); ({a: 1}.a);",
+		expectedProgram: ast.Program{
+			Statements: []ast.Statement{
+				ast.ExpressionStatement{
+					Expression: ast.CallExpression{
+						Callee: ast.IdentifierExpression{Symbol: "f"},
+						Arguments: []ast.Expression{
+							ast.ObjectExpression{
+								Properties: []ast.Property{
+									{Key: "indent", Value: ast.NumericLiteralExpression{Value: 2}},
 								},
 							},
 						},
 					},
 				},
+				ast.ExpressionStatement{
+					Expression: ast.MemberExpression{
+						Object: ast.ObjectExpression{
+							Properties: []ast.Property{
+								{Key: "a", Value: ast.NumericLiteralExpression{Value: 1}},
+							},
+						},
+						Property: "a",
+					},
+				},
 			},
 		},
-	}
+	},
+}
 
-	for _, test := range testcases {
+func Test_GivenSource_WhenParse_ThenShouldReturnCorrectProgram(t *testing.T) {
+	for _, test := range parseTestCases {
 		t.Run(test.name, func(t *testing.T) {
 			t.Parallel()
 			parser := NewParser(test.source)
@@ -698,7 +1219,61 @@ func Test_GivenSource_WhenParse_ThenShouldReturnCorrectProgram(t *testing.T) {
 				t.Error(err)
 			}
 
-			assert.Equal(t, test.expectedProgram, program)
+			assert.Truef(t, ast.Equal(test.expectedProgram, program, ast.IgnoreSpans()), "expected:\n%#v\nactual:\n%#v", test.expectedProgram, program)
+		})
+	}
+}
+
+func Test_GivenSource_WhenParse_ThenShouldRecordSourceSpans(t *testing.T) {
+	source := "let x = (1 + 2) * y;\n\"s\";"
+	program, err := NewParser(source).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := ast.Program{
+		Statements: []ast.Statement{
+			ast.VariableDeclaration{
+				Identifier: "x",
+				Value: ast.BinaryExpression{
+					Left: ast.BinaryExpression{
+						Left:     ast.NumericLiteralExpression{Value: 1, Span: ast.Span{Start: 9, End: 10}},
+						Operator: '+',
+						Right:    ast.NumericLiteralExpression{Value: 2, Span: ast.Span{Start: 13, End: 14}},
+						Span:     ast.Span{Start: 9, End: 14},
+					},
+					Operator: '*',
+					Right:    ast.IdentifierExpression{Symbol: "y", Span: ast.Span{Start: 18, End: 19}},
+					Span:     ast.Span{Start: 8, End: 19},
+				},
+				Span: ast.Span{Start: 0, End: 20},
+			},
+			ast.ExpressionStatement{
+				Expression: ast.StringLiteralExpression{Value: "s", Span: ast.Span{Start: 21, End: 24}},
+				Span:       ast.Span{Start: 21, End: 25},
+			},
+		},
+		Span: ast.Span{Start: 0, End: 25},
+	}
+	assert.Equal(t, expected, program)
+}
+
+func Test_GivenParsedSource_WhenPrintAndParseAgain_ThenShouldReturnSameProgram(t *testing.T) {
+	for _, test := range parseTestCases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			program, err := NewParser(test.source).Parse()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			printed := printer.Print(program)
+			reparsed, err := NewParser(printed).Parse()
+			if err != nil {
+				t.Fatalf("could not parse printed source %q: %s", printed, err)
+			}
+
+			assert.Truef(t, ast.Equal(program, reparsed, ast.IgnoreSpans()), "printed source: %q", printed)
 		})
 	}
 }
@@ -717,9 +1292,9 @@ func Test_GivenSourceWithUnexpectedTokens_WhenParse_ThenShouldReturnCorrectError
 			expectedError: fmt.Errorf("Unexpected token '+' at position 0."),
 		},
 		{
-			name:          "operator at start - minus",
+			name:          "minus without operand",
 			source:        "-",
-			expectedError: fmt.Errorf("Unexpected token '-' at position 0."),
+			expectedError: fmt.Errorf("Unexpected token '' at position 1."),
 		},
 		{
 			name:          "operator at start - star",
@@ -811,6 +1386,61 @@ func Test_GivenSourceWithUnexpectedTokens_WhenParse_ThenShouldReturnCorrectError
 			source:        "(1 + 2",
 			expectedError: fmt.Errorf("Unexpected token '' at position 6."),
 		},
+		{
+			name:          "assignment to a literal",
+			source:        "1 = 2",
+			expectedError: fmt.Errorf("Unexpected token '=' at position 2."),
+		},
+		{
+			name:          "assignment to an expression",
+			source:        "a + b = 2",
+			expectedError: fmt.Errorf("Unexpected token '=' at position 6."),
+		},
+		{
+			name:          "unclosed block",
+			source:        "{ let x = 1;",
+			expectedError: fmt.Errorf("Unexpected token '' at position 12."),
+		},
+		{
+			name:          "unmatched right brace",
+			source:        "x; }",
+			expectedError: fmt.Errorf("Unexpected token '}' at position 3."),
+		},
+		{
+			name:          "return outside of a function",
+			source:        "return 1",
+			expectedError: fmt.Errorf("Unexpected token 'return' at position 0."),
+		},
+		{
+			name:          "function without a name",
+			source:        "fn (a) {}",
+			expectedError: fmt.Errorf("Unexpected token '(' at position 3."),
+		},
+		{
+			name:          "parameters without a comma",
+			source:        "fn f(a b) {}",
+			expectedError: fmt.Errorf("Unexpected token 'b' at position 7."),
+		},
+		{
+			name:          "missing parameter type",
+			source:        "fn f(a:) {}",
+			expectedError: fmt.Errorf("Unexpected token ')' at position 7."),
+		},
+		{
+			name:          "missing function body",
+			source:        "fn f(): number;",
+			expectedError: fmt.Errorf("Unexpected token ';' at position 14."),
+		},
+		{
+			name:          "trailing comma in arguments",
+			source:        "f(1,)",
+			expectedError: fmt.Errorf("Unexpected token ')' at position 4."),
+		},
+		{
+			name:          "missing declaration type",
+			source:        "let x: = 1",
+			expectedError: fmt.Errorf("Unexpected token '=' at position 7."),
+		},
 		{
 			name:          "unmatched right paren",
 			source:        "1 + 2)",
@@ -936,6 +1566,86 @@ func Test_GivenSourceWithUnexpectedTokens_WhenParse_ThenShouldReturnCorrectError
 			source:        "1 x 2",
 			expectedError: fmt.Errorf("Unexpected token 'x' at position 2."),
 		},
+		{
+			name:          "declaration without identifier",
+			source:        "let = 1",
+			expectedError: fmt.Errorf("Unexpected token '=' at position 4."),
+		},
+		{
+			name:          "declaration without equals",
+			source:        "const x 1",
+			expectedError: fmt.Errorf("Unexpected token '1' at position 8."),
+		},
+		{
+			name:          "declaration without value",
+			source:        "let x =;",
+			expectedError: fmt.Errorf("Unexpected token ';' at position 7."),
+		},
+		{
+			name:          "declarations without separator",
+			source:        "let x = 1 let y = 2",
+			expectedError: fmt.Errorf("Unexpected token 'let' at position 10."),
+		},
+		{
+			name:          "member without property",
+			source:        "user.",
+			expectedError: fmt.Errorf("Unexpected token '' at position 5."),
+		},
+		{
+			name:          "numeric member",
+			source:        "user.1",
+			expectedError: fmt.Errorf("Unexpected token '1' at position 5."),
+		},
+		{
+			name:          "member of a number",
+			source:        "1.a",
+			expectedError: fmt.Errorf("Unexpected token '.' at position 1."),
+		},
+		{
+			name:          "assignment to a call",
+			source:        "f() = 1",
+			expectedError: fmt.Errorf("Unexpected token '=' at position 4."),
+		},
+		{
+			name:          "try without catch",
+			source:        "try { x }",
+			expectedError: fmt.Errorf("Unexpected token '' at position 9."),
+		},
+		{
+			name:          "catch without parameter",
+			source:        "try { x } catch { y }",
+			expectedError: fmt.Errorf("Unexpected token '{' at position 16."),
+		},
+		{
+			name:          "catch without try",
+			source:        "catch (e) {}",
+			expectedError: fmt.Errorf("Unexpected token 'catch' at position 0."),
+		},
+		{
+			name:          "object property without colon",
+			source:        "let o = {a 1};",
+			expectedError: fmt.Errorf("Unexpected token '1' at position 11."),
+		},
+		{
+			name:          "object properties without comma",
+			source:        "let o = {a: 1 b: 2};",
+			expectedError: fmt.Errorf("Unexpected token 'b' at position 14."),
+		},
+		{
+			name:          "object with numeric key",
+			source:        "let o = {1: 2};",
+			expectedError: fmt.Errorf("Unexpected token '1' at position 9."),
+		},
+		{
+			name:          "unterminated object",
+			source:        "let o = {a: 1",
+			expectedError: fmt.Errorf("Unexpected token '' at position 13."),
+		},
+		{
+			name:          "unterminated string",
+			source:        `"abc`,
+			expectedError: fmt.Errorf("Unexpected token '\"abc' at position 0."),
+		},
 	}
 
 	for _, test := range testcases {
@@ -0,0 +1,250 @@
+package printer
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/joaovictorjs/adam-script/ast"
+	"github.com/joaovictorjs/adam-script/lexer"
+)
+
+const (
+	precedenceLowest = iota
+	precedenceAssignment
+	precedenceAdditive
+	precedenceMultiplicative
+	precedenceUnary
+	precedencePrimary
+)
+
+func Fprint(w io.Writer, node ast.Node) error {
+	_, err := io.WriteString(w, Print(node))
+	return err
+}
+
+func Print(node ast.Node) string {
+	var builder strings.Builder
+	printNode(&builder, node)
+	return builder.String()
+}
+
+func printNode(builder *strings.Builder, node ast.Node) {
+	switch n := node.(type) {
+	case ast.Program:
+		for _, statement := range n.Statements {
+			printNode(builder, statement)
+			builder.WriteString("\n")
+		}
+	case ast.Statement:
+		builder.WriteString(printStatement(n, ""))
+	case ast.Expression:
+		builder.WriteString(printExpression(n))
+	default:
+		panic(fmt.Sprintf("printer: unexpected node type %T", n))
+	}
+}
+
+func printStatement(statement ast.Statement, indent string) string {
+	switch s := statement.(type) {
+	case ast.BlockStatement:
+		if len(s.Statements) == 0 {
+			return "{}"
+		}
+
+		var builder strings.Builder
+		builder.WriteString("{\n")
+		for _, inner := range s.Statements {
+			builder.WriteString(indent + "\t")
+			builder.WriteString(printStatement(inner, indent+"\t"))
+			builder.WriteString("\n")
+		}
+		builder.WriteString(indent + "}")
+		return builder.String()
+	case ast.ExpressionStatement:
+		if startsWithObject(s.Expression) {
+			return "(" + printExpression(s.Expression) + ");"
+		}
+		return printExpression(s.Expression) + ";"
+	case ast.VariableDeclaration:
+		keyword := "let"
+		if s.Constant {
+			keyword = "const"
+		}
+		return keyword + " " + s.Identifier + annotation(s.Type) + " = " + printExpression(s.Value) + ";"
+	case ast.FunctionDeclaration:
+		parameters := make([]string, 0, len(s.Parameters))
+		for _, parameter := range s.Parameters {
+			parameters = append(parameters, parameter.Name+annotation(parameter.Type))
+		}
+		signature := "fn " + s.Name + "(" + strings.Join(parameters, ", ") + ")" + annotation(s.ReturnType)
+		return signature + " " + printStatement(s.Body, indent)
+	case ast.ReturnStatement:
+		if s.Value == nil {
+			return "return;"
+		}
+		return "return " + printExpression(s.Value) + ";"
+	case ast.TryStatement:
+		handler := "catch (" + s.Parameter.Name + ") " + printStatement(s.Handler, indent)
+		return "try " + printStatement(s.Body, indent) + " " + handler
+	default:
+		panic(fmt.Sprintf("printer: unexpected statement type %T", s))
+	}
+}
+
+func printExpression(expression ast.Expression) string {
+	switch e := expression.(type) {
+	case ast.NumericLiteralExpression:
+		return number(e.Value)
+	case ast.StringLiteralExpression:
+		return Quote(e.Value)
+	case ast.IdentifierExpression:
+		return e.Symbol
+	case ast.AssignmentExpression:
+		return printExpression(e.Target) + " = " + printExpression(e.Value)
+	case ast.MemberExpression:
+		object := printExpression(e.Object)
+		if precedenceOf(e.Object) < precedencePrimary {
+			object = "(" + object + ")"
+		}
+		return object + "." + e.Property
+	case ast.ObjectExpression:
+		properties := make([]string, 0, len(e.Properties))
+		for _, property := range e.Properties {
+			properties = append(properties, Key(property.Key)+": "+printExpression(property.Value))
+		}
+		return "{" + strings.Join(properties, ", ") + "}"
+	case ast.CallExpression:
+		callee := printExpression(e.Callee)
+		if precedenceOf(e.Callee) < precedencePrimary {
+			callee = "(" + callee + ")"
+		}
+
+		arguments := make([]string, 0, len(e.Arguments))
+		for _, argument := range e.Arguments {
+			arguments = append(arguments, printExpression(argument))
+		}
+		return callee + "(" + strings.Join(arguments, ", ") + ")"
+	case ast.UnaryExpression:
+		operand := printExpression(e.Operand)
+		if precedenceOf(e.Operand) < precedenceUnary {
+			operand = "(" + operand + ")"
+		}
+		return string(e.Operator) + operand
+	case ast.BinaryExpression:
+		precedence := precedenceOf(e)
+		left := printExpression(e.Left)
+		if precedenceOf(e.Left) < precedence {
+			left = "(" + left + ")"
+		}
+
+		right := printExpression(e.Right)
+		if precedenceOf(e.Right) <= precedence {
+			right = "(" + right + ")"
+		}
+		return left + " " + string(e.Operator) + " " + right
+	default:
+		panic(fmt.Sprintf("printer: unexpected expression type %T", e))
+	}
+}
+
+// number prints v as a literal, or as the division that evaluates to it when
+// v is not finite.
+func number(v float64) string {
+	switch {
+	case math.IsNaN(v):
+		return "(0 / 0)"
+	case math.IsInf(v, 1):
+		return "(1 / 0)"
+	case math.IsInf(v, -1):
+		return "-(1 / 0)"
+	default:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	}
+}
+
+func annotation(typeName string) string {
+	if typeName == "" {
+		return ""
+	}
+	return ": " + typeName
+}
+
+// startsWithObject reports whether the printed expression would begin with
+// a brace, which the parser reads as a block at the start of a statement.
+func startsWithObject(expression ast.Expression) bool {
+	switch e := expression.(type) {
+	case ast.ObjectExpression:
+		return true
+	case ast.AssignmentExpression:
+		return startsWithObject(e.Target)
+	case ast.MemberExpression:
+		return startsWithObject(e.Object)
+	case ast.CallExpression:
+		return startsWithObject(e.Callee)
+	case ast.BinaryExpression:
+		return precedenceOf(e.Left) >= precedenceOf(e) && startsWithObject(e.Left)
+	default:
+		return false
+	}
+}
+
+// Key prints an object key bare when it lexes as a single identifier and
+// quoted otherwise.
+func Key(key string) string {
+	tokens := lexer.NewLexer(key).GenerateTokens()
+	if len(tokens) == 2 && tokens[0].Kind == lexer.Identifier && tokens[0].Lexeme == key {
+		return key
+	}
+	return Quote(key)
+}
+
+func precedenceOf(expression ast.Expression) int {
+	switch e := expression.(type) {
+	case ast.AssignmentExpression:
+		return precedenceAssignment
+	case ast.BinaryExpression:
+		switch e.Operator {
+		case '+', '-':
+			return precedenceAdditive
+		case '*', '/':
+			return precedenceMultiplicative
+		default:
+			return precedenceLowest
+		}
+	case ast.UnaryExpression:
+		return precedenceUnary
+	case ast.NumericLiteralExpression:
+		// Negative numbers print with a leading minus.
+		if math.Signbit(e.Value) && !math.IsNaN(e.Value) {
+			return precedenceUnary
+		}
+		return precedencePrimary
+	default:
+		return precedencePrimary
+	}
+}
+
+func Quote(value string) string {
+	var builder strings.Builder
+	builder.WriteByte('"')
+	for i := 0; i < len(value); i++ {
+		switch char := value[i]; char {
+		case '"', '\\':
+			builder.WriteByte('\\')
+			builder.WriteByte(char)
+		case '\n':
+			builder.WriteString(`\n`)
+		case '\t':
+			builder.WriteString(`\t`)
+		case '\r':
+			builder.WriteString(`\r`)
+		default:
+			builder.WriteByte(char)
+		}
+	}
+	builder.WriteByte('"')
+	return builder.String()
+}
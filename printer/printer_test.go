@@ -0,0 +1,290 @@
+package printer
+
+import (
+	"math"
+	"testing"
+
+	"github.com/joaovictorjs/adam-script/ast"
+	"github.com/joaovictorjs/adam-script/interpreter"
+	"github.com/joaovictorjs/adam-script/parser"
+	"github.com/joaovictorjs/adam-script/value"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GivenNode_WhenPrint_ThenShouldReturnCanonicalSource(t *testing.T) {
+	type TestCase struct {
+		name           string
+		node           ast.Node
+		expectedSource string
+	}
+
+	testcases := []TestCase{
+		{
+			name:           "number",
+			node:           ast.NumericLiteralExpression{Value: 42},
+			expectedSource: "42",
+		},
+		{
+			name:           "fractional number",
+			node:           ast.NumericLiteralExpression{Value: 3.5},
+			expectedSource: "3.5",
+		},
+		{
+			name:           "negative number",
+			node:           ast.NumericLiteralExpression{Value: -1},
+			expectedSource: "-1",
+		},
+		{
+			name:           "negative fractional number",
+			node:           ast.NumericLiteralExpression{Value: -0.25},
+			expectedSource: "-0.25",
+		},
+		{
+			name: "numbers inside a binary expression",
+			node: ast.BinaryExpression{
+				Left:     ast.NumericLiteralExpression{Value: 0.5},
+				Operator: '*',
+				Right:    ast.NumericLiteralExpression{Value: -2},
+			},
+			expectedSource: "0.5 * -2",
+		},
+		{
+			name: "negation of a sum",
+			node: ast.UnaryExpression{
+				Operator: '-',
+				Operand: ast.BinaryExpression{
+					Left:     ast.IdentifierExpression{Symbol: "a"},
+					Operator: '+',
+					Right:    ast.NumericLiteralExpression{Value: 1e21},
+				},
+			},
+			expectedSource: "-(a + 1e+21)",
+		},
+		{
+			name: "member of a negative number",
+			node: ast.MemberExpression{
+				Object:   ast.NumericLiteralExpression{Value: -1},
+				Property: "x",
+			},
+			expectedSource: "(-1).x",
+		},
+		{
+			name:           "string with escapes",
+			node:           ast.StringLiteralExpression{Value: "a\"b\\c\n"},
+			expectedSource: `"a\"b\\c\n"`,
+		},
+		{
+			name: "parenthesized lower precedence on the left",
+			node: ast.BinaryExpression{
+				Left: ast.BinaryExpression{
+					Left:     ast.NumericLiteralExpression{Value: 1},
+					Operator: '+',
+					Right:    ast.NumericLiteralExpression{Value: 2},
+				},
+				Operator: '*',
+				Right:    ast.NumericLiteralExpression{Value: 3},
+			},
+			expectedSource: "(1 + 2) * 3",
+		},
+		{
+			name: "no parentheses for higher precedence",
+			node: ast.BinaryExpression{
+				Left:     ast.NumericLiteralExpression{Value: 1},
+				Operator: '+',
+				Right: ast.BinaryExpression{
+					Left:     ast.NumericLiteralExpression{Value: 2},
+					Operator: '*',
+					Right:    ast.NumericLiteralExpression{Value: 3},
+				},
+			},
+			expectedSource: "1 + 2 * 3",
+		},
+		{
+			name: "no parentheses for left associative chain",
+			node: ast.BinaryExpression{
+				Left: ast.BinaryExpression{
+					Left:     ast.IdentifierExpression{Symbol: "a"},
+					Operator: '-',
+					Right:    ast.IdentifierExpression{Symbol: "b"},
+				},
+				Operator: '-',
+				Right:    ast.IdentifierExpression{Symbol: "c"},
+			},
+			expectedSource: "a - b - c",
+		},
+		{
+			name: "parenthesized same precedence on the right",
+			node: ast.BinaryExpression{
+				Left:     ast.IdentifierExpression{Symbol: "a"},
+				Operator: '-',
+				Right: ast.BinaryExpression{
+					Left:     ast.IdentifierExpression{Symbol: "b"},
+					Operator: '+',
+					Right:    ast.IdentifierExpression{Symbol: "c"},
+				},
+			},
+			expectedSource: "a - (b + c)",
+		},
+		{
+			name: "program with declarations",
+			node: ast.Program{
+				Statements: []ast.Statement{
+					ast.VariableDeclaration{
+						Constant:   true,
+						Identifier: "x",
+						Value:      ast.NumericLiteralExpression{Value: 1},
+					},
+					ast.VariableDeclaration{
+						Identifier: "y",
+						Value:      ast.StringLiteralExpression{Value: "y"},
+					},
+					ast.ExpressionStatement{
+						Expression: ast.IdentifierExpression{Symbol: "x"},
+					},
+				},
+			},
+			expectedSource: "const x = 1;\nlet y = \"y\";\nx;\n",
+		},
+		{
+			name: "parenthesized assignment inside binary expression",
+			node: ast.BinaryExpression{
+				Left:     ast.NumericLiteralExpression{Value: 2},
+				Operator: '*',
+				Right: ast.AssignmentExpression{
+					Target: ast.IdentifierExpression{Symbol: "x"},
+					Value:  ast.NumericLiteralExpression{Value: 3},
+				},
+			},
+			expectedSource: "2 * (x = 3)",
+		},
+		{
+			name: "nested blocks are indented",
+			node: ast.Program{
+				Statements: []ast.Statement{
+					ast.BlockStatement{
+						Statements: []ast.Statement{
+							ast.BlockStatement{
+								Statements: []ast.Statement{
+									ast.ExpressionStatement{
+										Expression: ast.IdentifierExpression{Symbol: "x"},
+									},
+								},
+							},
+							ast.BlockStatement{},
+						},
+					},
+				},
+			},
+			expectedSource: "{\n\t{\n\t\tx;\n\t}\n\t{}\n}\n",
+		},
+		{
+			name: "function declaration with annotations",
+			node: ast.Program{
+				Statements: []ast.Statement{
+					ast.FunctionDeclaration{
+						Name: "add",
+						Parameters: []ast.Parameter{
+							{Name: "a", Type: "number"},
+							{Name: "b"},
+						},
+						ReturnType: "number",
+						Body: ast.BlockStatement{
+							Statements: []ast.Statement{
+								ast.ReturnStatement{
+									Value: ast.CallExpression{
+										Callee: ast.IdentifierExpression{Symbol: "sum"},
+										Arguments: []ast.Expression{
+											ast.IdentifierExpression{Symbol: "a"},
+											ast.IdentifierExpression{Symbol: "b"},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedSource: "fn add(a: number, b): number {\n\treturn sum(a, b);\n}\n",
+		},
+		{
+			name: "object with quoted keys",
+			node: ast.ObjectExpression{
+				Properties: []ast.Property{
+					{Key: "a", Value: ast.NumericLiteralExpression{Value: 1}},
+					{Key: "b c", Value: ast.ObjectExpression{}},
+					{Key: "let", Value: ast.StringLiteralExpression{Value: "x"}},
+				},
+			},
+			expectedSource: `{a: 1, "b c": {}, "let": "x"}`,
+		},
+		{
+			name: "parenthesized statement starting with object",
+			node: ast.Program{
+				Statements: []ast.Statement{
+					ast.ExpressionStatement{
+						Expression: ast.MemberExpression{
+							Object:   ast.ObjectExpression{},
+							Property: "a",
+						},
+					},
+				},
+			},
+			expectedSource: "({}.a);\n",
+		},
+	}
+
+	for _, test := range testcases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, test.expectedSource, Print(test.node))
+		})
+	}
+}
+
+func Test_GivenNumber_WhenPrintParseAndEvaluate_ThenShouldReturnSameNumber(t *testing.T) {
+	type TestCase struct {
+		name   string
+		number float64
+	}
+
+	testcases := []TestCase{
+		{name: "integer", number: 42},
+		{name: "large integer", number: 1e300},
+		{name: "fraction", number: 7.0 / 2},
+		{name: "decimal fraction", number: 0.1},
+		{name: "long fraction", number: 1.0 / 3},
+		{name: "tiny fraction", number: 1e-300},
+		{name: "smallest subnormal", number: math.SmallestNonzeroFloat64},
+		{name: "negative integer", number: -1},
+		{name: "negative fraction", number: -2.75},
+		{name: "negative zero", number: math.Copysign(0, -1)},
+		{name: "infinity", number: math.Inf(1)},
+		{name: "negative infinity", number: math.Inf(-1)},
+		{name: "not a number", number: math.NaN()},
+	}
+
+	for _, test := range testcases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			printed := Print(ast.NumericLiteralExpression{Value: test.number})
+			program, err := parser.NewParser(printed).Parse()
+			if err != nil {
+				t.Fatalf("could not parse printed number %q: %s", printed, err)
+			}
+
+			result, err := interpreter.NewInterpreter().Evaluate(program)
+			if err != nil {
+				t.Fatal(err)
+			}
+			number, ok := result.(value.Number)
+			if !ok {
+				t.Fatalf("printed number %q evaluated to %s", printed, result)
+			}
+			if math.IsNaN(test.number) {
+				assert.Truef(t, math.IsNaN(float64(number)), "printed number: %q", printed)
+				return
+			}
+			assert.Equalf(t, math.Float64bits(test.number), math.Float64bits(float64(number)), "printed number: %q", printed)
+		})
+	}
+}
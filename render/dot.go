@@ -0,0 +1,120 @@
+package render
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/joaovictorjs/adam-script/ast"
+	"github.com/joaovictorjs/adam-script/printer"
+)
+
+type dotWriter struct {
+	builder strings.Builder
+	next    int
+}
+
+func Dot(node ast.Node) string {
+	w := &dotWriter{}
+	w.builder.WriteString("digraph AST {\n")
+	w.builder.WriteString("  node [shape=box, fontname=\"monospace\"];\n")
+	w.writeNode(node)
+	w.builder.WriteString("}\n")
+	return w.builder.String()
+}
+
+func (w *dotWriter) writeNode(node ast.Node) string {
+	id := "n" + strconv.Itoa(w.next)
+	w.next++
+
+	switch n := node.(type) {
+	case ast.Program:
+		w.writeLabel(id, "Program")
+		for i, statement := range n.Statements {
+			w.writeEdge(id, w.writeNode(statement), strconv.Itoa(i))
+		}
+	case ast.ExpressionStatement:
+		w.writeLabel(id, "ExpressionStatement")
+		w.writeEdge(id, w.writeNode(n.Expression), "")
+	case ast.VariableDeclaration:
+		keyword := "let"
+		if n.Constant {
+			keyword = "const"
+		}
+		w.writeLabel(id, "VariableDeclaration\n"+keyword+" "+n.Identifier+typeSuffix(n.Type))
+		w.writeEdge(id, w.writeNode(n.Value), "value")
+	case ast.BlockStatement:
+		w.writeLabel(id, "BlockStatement")
+		for i, statement := range n.Statements {
+			w.writeEdge(id, w.writeNode(statement), strconv.Itoa(i))
+		}
+	case ast.AssignmentExpression:
+		w.writeLabel(id, "AssignmentExpression")
+		w.writeEdge(id, w.writeNode(n.Target), "target")
+		w.writeEdge(id, w.writeNode(n.Value), "value")
+	case ast.FunctionDeclaration:
+		parameters := make([]string, 0, len(n.Parameters))
+		for _, parameter := range n.Parameters {
+			parameters = append(parameters, parameter.Name+typeSuffix(parameter.Type))
+		}
+		w.writeLabel(id, "FunctionDeclaration\n"+n.Name+"("+strings.Join(parameters, ", ")+")"+typeSuffix(n.ReturnType))
+		w.writeEdge(id, w.writeNode(n.Body), "body")
+	case ast.ReturnStatement:
+		w.writeLabel(id, "ReturnStatement")
+		if n.Value != nil {
+			w.writeEdge(id, w.writeNode(n.Value), "value")
+		}
+	case ast.TryStatement:
+		w.writeLabel(id, "TryStatement\ncatch ("+n.Parameter.Name+")")
+		w.writeEdge(id, w.writeNode(n.Body), "body")
+		w.writeEdge(id, w.writeNode(n.Handler), "handler")
+	case ast.CallExpression:
+		w.writeLabel(id, "CallExpression")
+		w.writeEdge(id, w.writeNode(n.Callee), "callee")
+		for i, argument := range n.Arguments {
+			w.writeEdge(id, w.writeNode(argument), strconv.Itoa(i))
+		}
+	case ast.MemberExpression:
+		w.writeLabel(id, "MemberExpression\n."+n.Property)
+		w.writeEdge(id, w.writeNode(n.Object), "object")
+	case ast.ObjectExpression:
+		w.writeLabel(id, "ObjectExpression")
+		for _, property := range n.Properties {
+			w.writeEdge(id, w.writeNode(property.Value), printer.Key(property.Key))
+		}
+	case ast.BinaryExpression:
+		w.writeLabel(id, "BinaryExpression\n"+string(n.Operator))
+		w.writeEdge(id, w.writeNode(n.Left), "left")
+		w.writeEdge(id, w.writeNode(n.Right), "right")
+	case ast.UnaryExpression:
+		w.writeLabel(id, "UnaryExpression\n"+string(n.Operator))
+		w.writeEdge(id, w.writeNode(n.Operand), "operand")
+	case ast.NumericLiteralExpression:
+		w.writeLabel(id, "NumericLiteralExpression\n"+strconv.FormatFloat(n.Value, 'f', -1, 64))
+	case ast.StringLiteralExpression:
+		w.writeLabel(id, "StringLiteralExpression\n"+printer.Quote(n.Value))
+	case ast.IdentifierExpression:
+		w.writeLabel(id, "IdentifierExpression\n"+n.Symbol)
+	default:
+		panic(fmt.Sprintf("render: unexpected node type %T", n))
+	}
+
+	return id
+}
+
+func (w *dotWriter) writeLabel(id string, label string) {
+	fmt.Fprintf(&w.builder, "  %s [label=%s];\n", id, dotQuote(label))
+}
+
+func (w *dotWriter) writeEdge(from string, to string, label string) {
+	if label == "" {
+		fmt.Fprintf(&w.builder, "  %s -> %s;\n", from, to)
+		return
+	}
+	fmt.Fprintf(&w.builder, "  %s -> %s [label=%s];\n", from, to, dotQuote(label))
+}
+
+func dotQuote(text string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	return `"` + replacer.Replace(text) + `"`
+}
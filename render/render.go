@@ -0,0 +1,44 @@
+package render
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/joaovictorjs/adam-script/ast"
+)
+
+type Format string
+
+const (
+	FormatJSON  Format = "json"
+	FormatSExpr Format = "sexpr"
+	FormatDot   Format = "dot"
+)
+
+var Formats = []Format{FormatJSON, FormatSExpr, FormatDot}
+
+func ParseFormat(name string) (Format, error) {
+	for _, format := range Formats {
+		if string(format) == name {
+			return format, nil
+		}
+	}
+	return "", fmt.Errorf("Unknown AST format '%s', expected one of json, sexpr or dot.", name)
+}
+
+func Render(node ast.Node, format Format) (string, error) {
+	switch format {
+	case FormatJSON:
+		data, err := json.MarshalIndent(node, "", "  ")
+		if err != nil {
+			return "", err
+		}
+		return string(data) + "\n", nil
+	case FormatSExpr:
+		return SExpr(node), nil
+	case FormatDot:
+		return Dot(node), nil
+	default:
+		return "", fmt.Errorf("Unknown AST format '%s', expected one of json, sexpr or dot.", format)
+	}
+}
@@ -0,0 +1,61 @@
+package render
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/joaovictorjs/adam-script/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+func Test_GivenSourceFiles_WhenRender_ThenShouldMatchGoldenFiles(t *testing.T) {
+	sources, err := filepath.Glob(filepath.Join("testdata", "*.adam"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, source := range sources {
+		data, err := os.ReadFile(source)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		program, err := parser.NewParser(string(data)).Parse()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, format := range Formats {
+			name := strings.TrimSuffix(filepath.Base(source), ".adam") + "." + string(format)
+			t.Run(name, func(t *testing.T) {
+				rendered, err := Render(program, format)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				golden := filepath.Join("testdata", name+".golden")
+				if *update {
+					if err := os.WriteFile(golden, []byte(rendered), 0o644); err != nil {
+						t.Fatal(err)
+					}
+				}
+
+				expected, err := os.ReadFile(golden)
+				if err != nil {
+					t.Fatal(err)
+				}
+				assert.Equal(t, string(expected), rendered)
+			})
+		}
+	}
+}
+
+func Test_GivenUnknownFormat_WhenParseFormat_ThenShouldReturnCorrectError(t *testing.T) {
+	_, err := ParseFormat("xml")
+	assert.EqualError(t, err, "Unknown AST format 'xml', expected one of json, sexpr or dot.")
+}
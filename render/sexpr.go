@@ -0,0 +1,93 @@
+package render
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/joaovictorjs/adam-script/ast"
+	"github.com/joaovictorjs/adam-script/printer"
+)
+
+func SExpr(node ast.Node) string {
+	if program, ok := node.(ast.Program); ok {
+		var builder strings.Builder
+		for _, statement := range program.Statements {
+			builder.WriteString(sexpr(statement))
+			builder.WriteString("\n")
+		}
+		return builder.String()
+	}
+	return sexpr(node) + "\n"
+}
+
+func sexpr(node ast.Node) string {
+	switch n := node.(type) {
+	case ast.ExpressionStatement:
+		return sexpr(n.Expression)
+	case ast.VariableDeclaration:
+		keyword := "let"
+		if n.Constant {
+			keyword = "const"
+		}
+		return "(" + keyword + " " + n.Identifier + typeSuffix(n.Type) + " " + sexpr(n.Value) + ")"
+	case ast.FunctionDeclaration:
+		parameters := make([]string, 0, len(n.Parameters))
+		for _, parameter := range n.Parameters {
+			parameters = append(parameters, parameter.Name+typeSuffix(parameter.Type))
+		}
+		parts := []string{"fn", n.Name + typeSuffix(n.ReturnType), "(" + strings.Join(parameters, " ") + ")"}
+		for _, statement := range n.Body.Statements {
+			parts = append(parts, sexpr(statement))
+		}
+		return "(" + strings.Join(parts, " ") + ")"
+	case ast.ReturnStatement:
+		if n.Value == nil {
+			return "(return)"
+		}
+		return "(return " + sexpr(n.Value) + ")"
+	case ast.TryStatement:
+		return "(try " + sexpr(n.Body) + " (catch " + n.Parameter.Name + " " + sexpr(n.Handler) + "))"
+	case ast.CallExpression:
+		parts := []string{"call", sexpr(n.Callee)}
+		for _, argument := range n.Arguments {
+			parts = append(parts, sexpr(argument))
+		}
+		return "(" + strings.Join(parts, " ") + ")"
+	case ast.BlockStatement:
+		parts := []string{"block"}
+		for _, statement := range n.Statements {
+			parts = append(parts, sexpr(statement))
+		}
+		return "(" + strings.Join(parts, " ") + ")"
+	case ast.AssignmentExpression:
+		return "(= " + sexpr(n.Target) + " " + sexpr(n.Value) + ")"
+	case ast.MemberExpression:
+		return "(. " + sexpr(n.Object) + " " + n.Property + ")"
+	case ast.ObjectExpression:
+		parts := []string{"object"}
+		for _, property := range n.Properties {
+			parts = append(parts, "("+printer.Quote(property.Key)+" "+sexpr(property.Value)+")")
+		}
+		return "(" + strings.Join(parts, " ") + ")"
+	case ast.BinaryExpression:
+		return "(" + string(n.Operator) + " " + sexpr(n.Left) + " " + sexpr(n.Right) + ")"
+	case ast.UnaryExpression:
+		return "(" + string(n.Operator) + " " + sexpr(n.Operand) + ")"
+	case ast.NumericLiteralExpression:
+		return strconv.FormatFloat(n.Value, 'f', -1, 64)
+	case ast.StringLiteralExpression:
+		return printer.Quote(n.Value)
+	case ast.IdentifierExpression:
+		return n.Symbol
+	default:
+		panic(fmt.Sprintf("render: unexpected node type %T", n))
+	}
+}
+
+func typeSuffix(typeName string) string {
+	if typeName == "" {
+		return ""
+	}
+	return ":" + typeName
+}
@@ -0,0 +1,193 @@
+package repl
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/joaovictorjs/adam-script/render"
+)
+
+type command struct {
+	name        string
+	usage       string
+	description string
+	host        bool
+}
+
+var commands = []command{
+	{name: ".help", description: "Show this help message"},
+	{name: ".ast", usage: "[json|sexpr|dot]", description: "Turn ON/OFF showing AST after parsing, or pick its format"},
+	{name: ".tokens", description: "Turn ON/OFF showing tokens after lexing"},
+	{name: ".time", description: "Turn ON/OFF timing of each evaluation"},
+	{name: ".type", usage: "<expr>", description: "Show the type of the expression's value"},
+	{name: ".load", usage: "<file>", description: "Run a file into the current session", host: true},
+	{name: ".save", usage: "<file>", description: "Save the session's accepted inputs to a file", host: true},
+	{name: ".reset", description: "Clear all session state"},
+	{name: ".clear", description: "Clear the screen", host: true},
+	{name: ".exit", description: "Exit the REPL"},
+}
+
+func (r *REPL) handleCommand(input string) {
+	name, argument, _ := strings.Cut(input, " ")
+	argument = strings.TrimSpace(argument)
+
+	if r.options.Served && r.isHostCommand(name) {
+		r.stderr.PrintRole(RoleError, "Command '"+name+"' is not available in served sessions.")
+		return
+	}
+
+	switch name {
+	case ".help":
+		r.printHelp()
+	case ".ast":
+		r.configureAst(argument)
+	case ".tokens":
+		r.showTokens = r.toggle("Show tokens", r.showTokens)
+	case ".time":
+		r.showTime = r.toggle("Show time", r.showTime)
+	case ".type":
+		r.printType(argument)
+	case ".load":
+		r.load(argument)
+	case ".save":
+		r.save(argument)
+	case ".reset":
+		r.reset()
+	case ".exit":
+		r.exited = true
+	case ".clear":
+		r.clearScreen()
+	default:
+		r.stderr.Println(r.stderr.Paint(RoleError, "Unknown command: ") + r.stderr.Paint(RoleText, name))
+		r.printHelpHint()
+	}
+}
+
+func (r *REPL) toggle(label string, current bool) bool {
+	enabled := !current
+	role := RoleError
+	state := "OFF"
+	if enabled {
+		role = RoleSuccess
+		state = "ON"
+	}
+
+	r.stdout.Println(label + " was turned " + r.stdout.Paint(role, state) + ".")
+	return enabled
+}
+
+func (r *REPL) configureAst(argument string) {
+	if argument == "" {
+		r.showAst = r.toggle("Show ast", r.showAst)
+		return
+	}
+
+	format, err := render.ParseFormat(argument)
+	if err != nil {
+		r.stderr.PrintRole(RoleError, err.Error())
+		return
+	}
+
+	r.astFormat = format
+	r.showAst = true
+	r.stdout.Println("Show ast was turned " + r.stdout.Paint(RoleSuccess, "ON") + " using " + string(format) + ".")
+}
+
+// printType runs source like any other input, so whatever it declares or
+// assigns stays in the session and is recorded for .save.
+func (r *REPL) printType(source string) {
+	if source == "" {
+		r.printUsage(".type")
+		return
+	}
+
+	result, err := r.evaluate(source)
+	if err != nil {
+		r.stderr.PrintRole(RoleError, err.Error())
+		return
+	}
+	r.stdout.PrintRole(RoleKeyword, result.Type())
+}
+
+func (r *REPL) load(path string) {
+	if path == "" {
+		r.printUsage(".load")
+		return
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		r.stderr.PrintRole(RoleError, fmt.Sprintf("Could not load '%s': %s", path, err))
+		return
+	}
+
+	result, err := r.evaluate(string(data))
+	if err != nil {
+		r.stderr.PrintRole(RoleError, err.Error())
+		return
+	}
+
+	r.printValue(result)
+	r.stdout.PrintRole(RoleSuccess, "Loaded '"+path+"'.")
+}
+
+func (r *REPL) save(path string) {
+	if path == "" {
+		r.printUsage(".save")
+		return
+	}
+
+	var content strings.Builder
+	for _, input := range r.accepted {
+		content.WriteString(input)
+		content.WriteString("\n")
+	}
+
+	if err := os.WriteFile(path, []byte(content.String()), 0o644); err != nil {
+		r.stderr.PrintRole(RoleError, fmt.Sprintf("Could not save '%s': %s", path, err))
+		return
+	}
+	r.stdout.PrintRole(RoleSuccess, fmt.Sprintf("Saved %d inputs to '%s'.", len(r.accepted), path))
+}
+
+func (r *REPL) reset() {
+	r.interpreter = newInterpreter()
+	r.accepted = nil
+	r.showAst = false
+	r.astFormat = render.FormatJSON
+	r.showTokens = false
+	r.showTime = false
+	r.stdout.PrintRole(RoleSuccess, "Session was reset.")
+}
+
+func (r *REPL) isHostCommand(name string) bool {
+	for _, cmd := range commands {
+		if cmd.name == name {
+			return cmd.host
+		}
+	}
+	return false
+}
+
+func (r *REPL) printUsage(name string) {
+	for _, cmd := range commands {
+		if cmd.name == name {
+			r.stderr.PrintRole(RoleError, "Usage: "+cmd.name+" "+cmd.usage)
+			return
+		}
+	}
+}
+
+func (r *REPL) printHelp() {
+	out := r.stdout
+	out.PrintRole(RoleEmphasis, "\nAvailable Commands:")
+	for _, cmd := range commands {
+		if r.options.Served && cmd.host {
+			continue
+		}
+		signature := strings.TrimSpace(cmd.name + " " + cmd.usage)
+		out.Println(out.Paint(RoleCommand, fmt.Sprintf("  %-22s ", signature)) + out.Paint(RoleText, "- "+cmd.description))
+	}
+	out.Println("")
+}
@@ -0,0 +1,163 @@
+package repl
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestREPL writes to builders instead of the terminal, feed runs inputs
+// the way Run does for lines read from it.
+func newTestREPL(stdout *strings.Builder, stderr *strings.Builder) *REPL {
+	return &REPL{
+		stdout:      NewOutput(stdout, DefaultTheme(), false),
+		stderr:      NewOutput(stderr, DefaultTheme(), false),
+		interpreter: newInterpreter(),
+	}
+}
+
+func feed(r *REPL, inputs ...string) {
+	for _, input := range inputs {
+		if strings.HasPrefix(input, ".") {
+			r.handleCommand(input)
+			continue
+		}
+
+		result, err := r.evaluate(input)
+		if err != nil {
+			r.stderr.PrintRole(RoleError, err.Error())
+			continue
+		}
+		r.printValue(result)
+	}
+}
+
+func Test_GivenSession_WhenSaved_ThenShouldRecordWhatRan(t *testing.T) {
+	type TestCase struct {
+		name          string
+		file          string
+		inputs        []string
+		expectedSaved string
+	}
+
+	testcases := []TestCase{
+		{
+			name:          "accepted inputs",
+			inputs:        []string{"let x = 2", "x * 21"},
+			expectedSaved: "let x = 2;\nx * 21;\n",
+		},
+		{
+			name:          "type with side effects",
+			inputs:        []string{"let x = 1", ".type x = 5"},
+			expectedSaved: "let x = 1;\nx = 5;\n",
+		},
+		{
+			name:          "input failing after a declaration",
+			inputs:        []string{`let a = 1; a + "s"; let b = 2`},
+			expectedSaved: "let a = 1;\n",
+		},
+		{
+			name:          "load failing halfway",
+			file:          "let a = 1;\nfn f() { return a }\nmissing;\nlet b = 2;\n",
+			inputs:        []string{".load {file}"},
+			expectedSaved: "fn f() { return a }\nlet a = 1;\n",
+		},
+		{
+			name:          "hoisted function",
+			inputs:        []string{"f(); fn f() { return 1 }"},
+			expectedSaved: "fn f() { return 1 }\nf();\n",
+		},
+		{
+			name:          "several statements in one input",
+			inputs:        []string{"let a = 1; let b = a + 1;"},
+			expectedSaved: "let a = 1;\nlet b = a + 1;\n",
+		},
+		{
+			name:          "blocks",
+			inputs:        []string{"{ 1 } try { 2 } catch (e) { e } 3"},
+			expectedSaved: "{ 1 }\ntry { 2 } catch (e) { e }\n3;\n",
+		},
+		{
+			name:          "failed parse",
+			inputs:        []string{"let = 1"},
+			expectedSaved: "",
+		},
+	}
+
+	for _, test := range testcases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			dir := t.TempDir()
+			loaded := filepath.Join(dir, "loaded.adam")
+			if err := os.WriteFile(loaded, []byte(test.file), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			saved := filepath.Join(dir, "saved.adam")
+
+			var stdout, stderr strings.Builder
+			repl := newTestREPL(&stdout, &stderr)
+			for _, input := range test.inputs {
+				feed(repl, strings.ReplaceAll(input, "{file}", loaded))
+			}
+			feed(repl, ".save "+saved)
+
+			data, err := os.ReadFile(saved)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedSaved, string(data))
+
+			var errors strings.Builder
+			feed(newTestREPL(&strings.Builder{}, &errors), ".load "+saved)
+			assert.Empty(t, errors.String())
+		})
+	}
+}
+
+func Test_GivenCommand_WhenHandled_ThenShouldPrintCorrectOutput(t *testing.T) {
+	type TestCase struct {
+		name           string
+		file           string
+		inputs         []string
+		expectedOutput string
+		expectedError  string
+	}
+
+	testcases := []TestCase{
+		{name: "type of number", inputs: []string{".type 1 + 2"}, expectedOutput: "number"},
+		{name: "type of string", inputs: []string{`.type "a"`}, expectedOutput: "string"},
+		{name: "type of map", inputs: []string{".type ({a: 1})"}, expectedOutput: "map"},
+		{name: "type of declared function", inputs: []string{"fn f() { return 1 }", ".type f"}, expectedOutput: "function"},
+		{name: "type without expression", inputs: []string{".type"}, expectedError: "Usage: .type <expr>"},
+		{name: "type of undefined variable", inputs: []string{".type missing"}, expectedError: "Undefined variable 'missing'."},
+		{name: "load", file: "let x = 20;\nx + 1", inputs: []string{".load {file}"}, expectedOutput: "21\nLoaded '{file}'."},
+		{name: "load keeps declarations", file: "let x = 20;", inputs: []string{".load {file}", ".type x"}, expectedOutput: "Loaded '{file}'.\nnumber"},
+		{name: "load without file", inputs: []string{".load"}, expectedError: "Usage: .load <file>"},
+		{name: "load failing halfway", file: "let x = 1;\nmissing", inputs: []string{".load {file}"}, expectedError: "Undefined variable 'missing'."},
+		{name: "reset", inputs: []string{"let x = 1", ".reset"}, expectedOutput: "Session was reset."},
+		{name: "reset clears variables", inputs: []string{"let x = 1", ".reset", ".type x"}, expectedOutput: "Session was reset.", expectedError: "Undefined variable 'x'."},
+		{name: "time toggle", inputs: []string{".time"}, expectedOutput: "Show time was turned ON."},
+		{name: "tokens toggle twice", inputs: []string{".tokens", ".tokens"}, expectedOutput: "Show tokens was turned ON.\nShow tokens was turned OFF."},
+		{name: "save without file", inputs: []string{".save"}, expectedError: "Usage: .save <file>"},
+	}
+
+	for _, test := range testcases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			file := filepath.Join(t.TempDir(), "input.adam")
+			if err := os.WriteFile(file, []byte(test.file), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			var stdout, stderr strings.Builder
+			repl := newTestREPL(&stdout, &stderr)
+			for _, input := range test.inputs {
+				feed(repl, strings.ReplaceAll(input, "{file}", file))
+			}
+
+			assert.Equal(t, strings.ReplaceAll(test.expectedOutput, "{file}", file), strings.TrimSpace(stdout.String()))
+			assert.Equal(t, test.expectedError, strings.TrimSpace(stderr.String()))
+		})
+	}
+}
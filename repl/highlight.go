@@ -0,0 +1,98 @@
+package repl
+
+import (
+	"strings"
+
+	"github.com/joaovictorjs/adam-script/lexer"
+)
+
+func highlight(output *Output, source string, cursor int) string {
+	tokens := lexer.NewLexer(source, lexer.WithTrivia()).GenerateTokens()
+	matching := findMatchingParens(tokens, cursor)
+
+	var builder strings.Builder
+	for i, token := range tokens {
+		for _, trivia := range token.LeadingTrivia {
+			if trivia.Kind == lexer.Comment {
+				builder.WriteString(output.Paint(RoleComment, trivia.Text))
+			} else {
+				builder.WriteString(trivia.Text)
+			}
+		}
+
+		if token.Kind == lexer.EOF {
+			break
+		}
+
+		role := tokenRole(token.Kind)
+		if matching[i] {
+			role = RoleMatchingParen
+		}
+		builder.WriteString(output.Paint(role, token.Lexeme))
+	}
+
+	return builder.String()
+}
+
+func tokenRole(kind lexer.TokenKind) Role {
+	switch kind {
+	case lexer.Let, lexer.Const, lexer.Fn, lexer.Return, lexer.Try, lexer.Catch:
+		return RoleKeyword
+	case lexer.Identifier:
+		return RoleIdentifier
+	case lexer.NumericLiteral:
+		return RoleNumber
+	case lexer.StringLiteral:
+		return RoleString
+	case lexer.Plus, lexer.Minus, lexer.Star, lexer.Slash, lexer.Equals, lexer.Semicolon, lexer.Colon, lexer.Comma, lexer.Dot:
+		return RoleOperator
+	case lexer.LParen, lexer.RParen, lexer.LBrace, lexer.RBrace:
+		return RoleParen
+	default:
+		return RoleUnknown
+	}
+}
+
+func findMatchingParens(tokens []lexer.Token, cursor int) map[int]bool {
+	matching := map[int]bool{}
+	selected := -1
+	for i, token := range tokens {
+		if token.Kind != lexer.LParen && token.Kind != lexer.RParen {
+			continue
+		}
+
+		if token.Position == cursor || token.Position == cursor-1 {
+			selected = i
+			if token.Position == cursor {
+				break
+			}
+		}
+	}
+
+	if selected < 0 {
+		return matching
+	}
+
+	step := 1
+	if tokens[selected].Kind == lexer.RParen {
+		step = -1
+	}
+
+	depth := 0
+	for i := selected; i >= 0 && i < len(tokens); i += step {
+		switch tokens[i].Kind {
+		case lexer.LParen:
+			depth += step
+		case lexer.RParen:
+			depth -= step
+		}
+
+		if depth == 0 {
+			matching[selected] = true
+			matching[i] = true
+			break
+		}
+	}
+
+	return matching
+}
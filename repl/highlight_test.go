@@ -0,0 +1,115 @@
+package repl
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/joaovictorjs/adam-script/lexer"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GivenSource_WhenHighlight_ThenShouldPaintEachToken(t *testing.T) {
+	theme := Theme{}
+	for _, role := range []Role{RoleKeyword, RoleIdentifier, RoleNumber, RoleString, RoleOperator, RoleParen, RoleMatchingParen, RoleUnknown, RoleComment} {
+		theme[role] = "<" + string(role) + ">"
+	}
+
+	type TestCase struct {
+		name     string
+		source   string
+		cursor   int
+		colored  bool
+		expected string
+	}
+
+	testcases := []TestCase{
+		{
+			name:     "declaration",
+			source:   `let x = 1;`,
+			cursor:   -1,
+			colored:  true,
+			expected: `<keyword>let</> <identifier>x</> <operator>=</> <number>1</><operator>;</>`,
+		},
+		{
+			name:     "string and comment",
+			source:   "\"a\" // note\nb",
+			cursor:   -1,
+			colored:  true,
+			expected: "<string>\"a\"</> <comment>// note</>\n<identifier>b</>",
+		},
+		{
+			name:     "unknown character",
+			source:   `1 @ 2`,
+			cursor:   -1,
+			colored:  true,
+			expected: `<number>1</> <unknown>@</> <number>2</>`,
+		},
+		{
+			name:     "parens away from cursor",
+			source:   `f(1)`,
+			cursor:   0,
+			colored:  true,
+			expected: `<identifier>f</><paren>(</><number>1</><paren>)</>`,
+		},
+		{
+			name:     "parens at cursor",
+			source:   `f(1)`,
+			cursor:   4,
+			colored:  true,
+			expected: `<identifier>f</><matchingParen>(</><number>1</><matchingParen>)</>`,
+		},
+		{
+			name:     "uncolored output",
+			source:   "let x = (1 + 2) * 3 // done",
+			cursor:   5,
+			colored:  false,
+			expected: "let x = (1 + 2) * 3 // done",
+		},
+	}
+
+	for _, test := range testcases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			output := NewOutput(nil, theme, test.colored)
+			actual := strings.ReplaceAll(highlight(output, test.source, test.cursor), ColorReset, "</>")
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func Test_GivenCursorNearParen_WhenFindMatchingParens_ThenShouldReturnBothParens(t *testing.T) {
+	type TestCase struct {
+		name     string
+		source   string
+		cursor   int
+		expected []int
+	}
+
+	testcases := []TestCase{
+		{name: "cursor on opening paren", source: `f(1)`, cursor: 1, expected: []int{1, 3}},
+		{name: "cursor after closing paren", source: `f(1)`, cursor: 4, expected: []int{1, 3}},
+		{name: "cursor after opening paren", source: `f(1)`, cursor: 2, expected: []int{1, 3}},
+		{name: "cursor on paren wins over paren before it", source: `f((1), 2)`, cursor: 2, expected: []int{2, 4}},
+		{name: "outer parens", source: `f((1), 2)`, cursor: 9, expected: []int{1, 8}},
+		{name: "inner parens", source: `f((1), 2)`, cursor: 5, expected: []int{2, 4}},
+		{name: "unmatched opening paren", source: `f((1)`, cursor: 1, expected: []int{}},
+		{name: "unmatched closing paren", source: `1)`, cursor: 2, expected: []int{}},
+		{name: "no paren at cursor", source: `f(1)`, cursor: 0, expected: []int{}},
+		{name: "braces are not matched", source: `{1}`, cursor: 0, expected: []int{}},
+	}
+
+	for _, test := range testcases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			tokens := lexer.NewLexer(test.source, lexer.WithTrivia()).GenerateTokens()
+
+			positions := []int{}
+			for index := range findMatchingParens(tokens, test.cursor) {
+				positions = append(positions, tokens[index].Position)
+			}
+			slices.Sort(positions)
+			assert.Equal(t, test.expected, positions)
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package repl
+
+import (
+	"bufio"
+	"encoding/json"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/joaovictorjs/adam-script/lexer"
+	"github.com/joaovictorjs/adam-script/parser"
+	"github.com/joaovictorjs/adam-script/render"
+	"github.com/joaovictorjs/adam-script/value"
+)
+
+type jsonResponse struct {
+	OK          bool             `json:"ok"`
+	Value       json.RawMessage  `json:"value,omitempty"`
+	Type        string           `json:"type,omitempty"`
+	Output      string           `json:"output,omitempty"`
+	Tokens      []jsonToken      `json:"tokens,omitempty"`
+	Ast         any              `json:"ast,omitempty"`
+	Elapsed     string           `json:"elapsed,omitempty"`
+	Diagnostics []jsonDiagnostic `json:"diagnostics,omitempty"`
+}
+
+type jsonToken struct {
+	Kind     string `json:"kind"`
+	Lexeme   string `json:"lexeme"`
+	Position int    `json:"position"`
+}
+
+type jsonDiagnostic struct {
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+}
+
+func (r *REPL) runJSON() {
+	scanner := bufio.NewScanner(r.input)
+	encoder := json.NewEncoder(r.stdout.writer)
+	for !r.exited && scanner.Scan() {
+		input := strings.TrimSpace(scanner.Text())
+		if input == "" {
+			continue
+		}
+		encoder.Encode(r.respond(input))
+	}
+
+	if err := scanner.Err(); err != nil {
+		encoder.Encode(failure(err.Error()))
+	}
+}
+
+func (r *REPL) respond(input string) jsonResponse {
+	if strings.HasPrefix(input, ".") {
+		return r.respondToCommand(input)
+	}
+
+	response := jsonResponse{}
+	if r.showTokens {
+		for _, token := range lexer.NewLexer(input).GenerateTokens() {
+			response.Tokens = append(response.Tokens, jsonToken{
+				Kind:     token.Kind.String(),
+				Lexeme:   token.Lexeme,
+				Position: token.Position,
+			})
+		}
+	}
+
+	program, err := parser.NewParser(input).Parse()
+	if err != nil {
+		response.Diagnostics = failure(err.Error()).Diagnostics
+		return response
+	}
+
+	if r.showAst && r.astFormat == render.FormatJSON {
+		response.Ast = program
+	} else if r.showAst {
+		response.Ast, _ = render.Render(program, r.astFormat)
+	}
+
+	start := time.Now()
+	result, err := r.execute(input, program)
+	if r.showTime {
+		response.Elapsed = time.Since(start).String()
+	}
+
+	if err != nil {
+		response.Diagnostics = failure(err.Error()).Diagnostics
+		return response
+	}
+
+	response.OK = true
+	response.Value = jsonValue(result)
+	response.Type = result.Type()
+	return response
+}
+
+func (r *REPL) respondToCommand(input string) jsonResponse {
+	name, _, _ := strings.Cut(input, " ")
+	if name == ".clear" {
+		return failure("Command '.clear' is not available in JSON mode.")
+	}
+
+	stdout, stderr := r.stdout, r.stderr
+	var output, errors strings.Builder
+	r.stdout = NewOutput(&output, stdout.theme, false)
+	r.stderr = NewOutput(&errors, stderr.theme, false)
+	r.handleCommand(input)
+	r.stdout, r.stderr = stdout, stderr
+
+	if errors.Len() > 0 {
+		return failure(strings.Split(strings.TrimSpace(errors.String()), "\n")...)
+	}
+
+	return jsonResponse{
+		OK:     true,
+		Output: strings.TrimSpace(output.String()),
+	}
+}
+
+func failure(messages ...string) jsonResponse {
+	response := jsonResponse{}
+	for _, message := range messages {
+		response.Diagnostics = append(response.Diagnostics, jsonDiagnostic{
+			Severity: "error",
+			Message:  message,
+		})
+	}
+	return response
+}
+
+func jsonValue(v value.Value) json.RawMessage {
+	var raw any
+	switch v := v.(type) {
+	case value.Number:
+		if math.IsInf(float64(v), 0) || math.IsNaN(float64(v)) {
+			raw = v.String()
+		} else {
+			raw = float64(v)
+		}
+	case value.String:
+		raw = string(v)
+	case value.Null:
+		raw = nil
+	default:
+		raw = v.String()
+	}
+
+	data, _ := json.Marshal(raw)
+	return data
+}
@@ -0,0 +1,99 @@
+package repl
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GivenInputLines_WhenRespondInJSONMode_ThenShouldReturnOneObjectPerLine(t *testing.T) {
+	type TestCase struct {
+		name              string
+		inputs            []string
+		expectedResponses []string
+	}
+
+	testcases := []TestCase{
+		{
+			name:              "number value",
+			inputs:            []string{"1 + 2 * 3"},
+			expectedResponses: []string{`{"ok":true,"value":7,"type":"number"}`},
+		},
+		{
+			name:              "string value",
+			inputs:            []string{`"a" + "b"`},
+			expectedResponses: []string{`{"ok":true,"value":"ab","type":"string"}`},
+		},
+		{
+			name:   "declaration and usage",
+			inputs: []string{"let x = 2", "x * 3"},
+			expectedResponses: []string{
+				`{"ok":true,"value":null,"type":"null"}`,
+				`{"ok":true,"value":6,"type":"number"}`,
+			},
+		},
+		{
+			name:              "non finite number",
+			inputs:            []string{"1 / 0"},
+			expectedResponses: []string{`{"ok":true,"value":"+Inf","type":"number"}`},
+		},
+		{
+			name:              "syntax error",
+			inputs:            []string{"1 +"},
+			expectedResponses: []string{`{"ok":false,"diagnostics":[{"severity":"error","message":"Unexpected token '' at position 3."}]}`},
+		},
+		{
+			name:              "runtime error",
+			inputs:            []string{"y"},
+			expectedResponses: []string{`{"ok":false,"diagnostics":[{"severity":"error","message":"Undefined variable 'y'."}]}`},
+		},
+		{
+			name:   "ast toggle",
+			inputs: []string{".ast", "1"},
+			expectedResponses: []string{
+				`{"ok":true,"output":"Show ast was turned ON."}`,
+				`{"ok":true,"value":1,"type":"number","ast":{"Kind":"Program","Span":{"Start":0,"End":1},"Statements":[{"Kind":"ExpressionStatement","Span":{"Start":0,"End":1},"Expression":{"Kind":"NumericLiteralExpression","Span":{"Start":0,"End":1},"Value":1}}]}}`,
+			},
+		},
+		{
+			name:   "ast in s-expression format",
+			inputs: []string{".ast sexpr", "1 + 2 * 3"},
+			expectedResponses: []string{
+				`{"ok":true,"output":"Show ast was turned ON using sexpr."}`,
+				`{"ok":true,"value":7,"type":"number","ast":"(+ 1 (* 2 3))\n"}`,
+			},
+		},
+		{
+			name:              "unknown ast format",
+			inputs:            []string{".ast xml"},
+			expectedResponses: []string{`{"ok":false,"diagnostics":[{"severity":"error","message":"Unknown AST format 'xml', expected one of json, sexpr or dot."}]}`},
+		},
+		{
+			name:              "unknown command",
+			inputs:            []string{".foo"},
+			expectedResponses: []string{`{"ok":false,"diagnostics":[{"severity":"error","message":"Unknown command: .foo"}]}`},
+		},
+	}
+
+	for _, test := range testcases {
+		t.Run(test.name, func(t *testing.T) {
+			repl := NewREPL(strings.NewReader(""), io.Discard, Options{JSON: true})
+			for i, input := range test.inputs {
+				data, err := json.Marshal(repl.respond(input))
+				if err != nil {
+					t.Fatal(err)
+				}
+				assert.JSONEq(t, test.expectedResponses[i], string(data))
+			}
+		})
+	}
+}
+
+func Test_GivenInputFailingHalfway_WhenRespondInJSONMode_ThenShouldRecordWhatRan(t *testing.T) {
+	repl := NewREPL(strings.NewReader(""), io.Discard, Options{JSON: true})
+	repl.respond(`let a = 1; a + "s"; let b = 2`)
+	assert.Equal(t, []string{"let a = 1;"}, repl.accepted)
+}
@@ -0,0 +1,232 @@
+package repl
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"unicode"
+)
+
+const (
+	keyCtrlA     = 1
+	keyCtrlC     = 3
+	keyCtrlD     = 4
+	keyCtrlE     = 5
+	keyBackspace = 8
+	keyEnter     = '\r'
+	keyNewline   = '\n'
+	keyEscape    = 27
+	keyDelete    = 127
+)
+
+type lineReader interface {
+	readLine() (string, error)
+}
+
+type scannerLineReader struct {
+	scanner *bufio.Scanner
+	output  *Output
+	prompt  string
+}
+
+func (r *scannerLineReader) readLine() (string, error) {
+	r.output.Print(r.prompt)
+	if !r.scanner.Scan() {
+		if err := r.scanner.Err(); err != nil {
+			return "", err
+		}
+		return "", io.EOF
+	}
+	return r.scanner.Text(), nil
+}
+
+type lineEditor struct {
+	input   *os.File
+	reader  *bufio.Reader
+	output  *Output
+	prompt  string
+	history []string
+	buffer  []rune
+	cursor  int
+}
+
+func newLineEditor(input *os.File, output *Output, prompt string) *lineEditor {
+	return &lineEditor{
+		input:  input,
+		reader: bufio.NewReader(input),
+		output: output,
+		prompt: prompt,
+	}
+}
+
+func (e *lineEditor) readLine() (string, error) {
+	restore, err := makeRaw(int(e.input.Fd()))
+	if err != nil {
+		return "", err
+	}
+	defer restore()
+
+	e.buffer = e.buffer[:0]
+	e.cursor = 0
+	historyIndex := len(e.history)
+	e.redraw()
+
+	for {
+		char, _, err := e.reader.ReadRune()
+		if err != nil {
+			return "", err
+		}
+
+		switch char {
+		case keyEnter, keyNewline:
+			line := string(e.buffer)
+			e.output.Print("\r\n")
+			if line != "" {
+				e.history = append(e.history, line)
+			}
+			return line, nil
+		case keyCtrlC:
+			e.output.Print("^C\r\n")
+			return "", nil
+		case keyCtrlD:
+			if len(e.buffer) == 0 {
+				e.output.Print("\r\n")
+				return "", io.EOF
+			}
+			e.deleteAt(e.cursor)
+		case keyBackspace, keyDelete:
+			if e.cursor > 0 {
+				e.cursor--
+				e.deleteAt(e.cursor)
+			}
+		case keyCtrlA:
+			e.cursor = 0
+		case keyCtrlE:
+			e.cursor = len(e.buffer)
+		case keyEscape:
+			historyIndex = e.handleEscapeSequence(historyIndex)
+		default:
+			if unicode.IsPrint(char) {
+				e.insert(char)
+			}
+		}
+
+		e.redraw()
+	}
+}
+
+func (e *lineEditor) handleEscapeSequence(historyIndex int) int {
+	next, _, err := e.reader.ReadRune()
+	if err != nil || next != '[' {
+		return historyIndex
+	}
+
+	code, _, err := e.reader.ReadRune()
+	if err != nil {
+		return historyIndex
+	}
+
+	switch code {
+	case 'A':
+		if historyIndex > 0 {
+			historyIndex--
+			e.replaceBuffer(e.history[historyIndex])
+		}
+	case 'B':
+		if historyIndex < len(e.history)-1 {
+			historyIndex++
+			e.replaceBuffer(e.history[historyIndex])
+		} else {
+			historyIndex = len(e.history)
+			e.replaceBuffer("")
+		}
+	case 'C':
+		if e.cursor < len(e.buffer) {
+			e.cursor++
+		}
+	case 'D':
+		if e.cursor > 0 {
+			e.cursor--
+		}
+	case 'H':
+		e.cursor = 0
+	case 'F':
+		e.cursor = len(e.buffer)
+	case '3':
+		if tilde, _, err := e.reader.ReadRune(); err == nil && tilde == '~' {
+			e.deleteAt(e.cursor)
+		}
+	}
+
+	return historyIndex
+}
+
+func (e *lineEditor) insert(char rune) {
+	e.buffer = append(e.buffer, 0)
+	copy(e.buffer[e.cursor+1:], e.buffer[e.cursor:])
+	e.buffer[e.cursor] = char
+	e.cursor++
+}
+
+func (e *lineEditor) deleteAt(index int) {
+	if index < 0 || index >= len(e.buffer) {
+		return
+	}
+	e.buffer = append(e.buffer[:index], e.buffer[index+1:]...)
+}
+
+func (e *lineEditor) replaceBuffer(line string) {
+	e.buffer = []rune(line)
+	e.cursor = len(e.buffer)
+}
+
+func (e *lineEditor) redraw() {
+	source := string(e.buffer)
+	cursor := len(string(e.buffer[:e.cursor]))
+	e.output.Print("\r\033[K" + e.prompt + highlight(e.output, source, cursor))
+
+	if back := displayWidth(e.buffer[e.cursor:]); back > 0 {
+		e.output.Print(fmt.Sprintf("\033[%dD", back))
+	}
+}
+
+// wideRunes are the East Asian wide and fullwidth ranges, which terminals
+// draw across two columns.
+var wideRunes = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{Lo: 0x1100, Hi: 0x115f, Stride: 1},
+		{Lo: 0x2e80, Hi: 0x303e, Stride: 1},
+		{Lo: 0x3041, Hi: 0x33ff, Stride: 1},
+		{Lo: 0x3400, Hi: 0x4dbf, Stride: 1},
+		{Lo: 0x4e00, Hi: 0x9fff, Stride: 1},
+		{Lo: 0xa000, Hi: 0xa4cf, Stride: 1},
+		{Lo: 0xac00, Hi: 0xd7a3, Stride: 1},
+		{Lo: 0xf900, Hi: 0xfaff, Stride: 1},
+		{Lo: 0xfe30, Hi: 0xfe4f, Stride: 1},
+		{Lo: 0xff00, Hi: 0xff60, Stride: 1},
+		{Lo: 0xffe0, Hi: 0xffe6, Stride: 1},
+	},
+	R32: []unicode.Range32{
+		{Lo: 0x1f300, Hi: 0x1f64f, Stride: 1},
+		{Lo: 0x1f900, Hi: 0x1f9ff, Stride: 1},
+		{Lo: 0x20000, Hi: 0x2fffd, Stride: 1},
+		{Lo: 0x30000, Hi: 0x3fffd, Stride: 1},
+	},
+}
+
+// displayWidth is the number of terminal columns runes take, combining marks
+// and zero width characters join the previous column.
+func displayWidth(runes []rune) int {
+	width := 0
+	for _, r := range runes {
+		switch {
+		case unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf):
+		case unicode.Is(wideRunes, r):
+			width += 2
+		default:
+			width++
+		}
+	}
+	return width
+}
@@ -0,0 +1,67 @@
+package repl
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GivenRunes_WhenDisplayWidth_ThenShouldCountTerminalColumns(t *testing.T) {
+	type TestCase struct {
+		name     string
+		text     string
+		expected int
+	}
+
+	testcases := []TestCase{
+		{name: "ascii", text: "let x = 1", expected: 9},
+		{name: "accented letters", text: "héllo", expected: 5},
+		{name: "combining mark", text: "he\u0301llo", expected: 5},
+		{name: "zero width joiner", text: "a\u200db", expected: 2},
+		{name: "chinese", text: "你好", expected: 4},
+		{name: "hangul", text: "한국", expected: 4},
+		{name: "fullwidth letters", text: "ＡＢ", expected: 4},
+		{name: "emoji", text: "🙂", expected: 2},
+		{name: "empty", text: "", expected: 0},
+	}
+
+	for _, test := range testcases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, test.expected, displayWidth([]rune(test.text)))
+		})
+	}
+}
+
+func Test_GivenCursorInsideLine_WhenRedraw_ThenShouldMoveBackByDisplayWidth(t *testing.T) {
+	type TestCase struct {
+		name         string
+		line         string
+		cursor       int
+		expectedBack int
+	}
+
+	testcases := []TestCase{
+		{name: "ascii", line: `"ab"`, cursor: 1, expectedBack: 3},
+		{name: "chinese", line: `"你好"`, cursor: 1, expectedBack: 5},
+		{name: "combining mark", line: "\"e\u0301x\"", cursor: 1, expectedBack: 3},
+		{name: "cursor at end", line: `"你好"`, cursor: 4, expectedBack: 0},
+	}
+
+	for _, test := range testcases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			var output strings.Builder
+			editor := &lineEditor{output: NewOutput(&output, DefaultTheme(), false), prompt: "> ", buffer: []rune(test.line), cursor: test.cursor}
+			editor.redraw()
+
+			expected := "\r\033[K> " + test.line
+			if test.expectedBack > 0 {
+				expected += fmt.Sprintf("\033[%dD", test.expectedBack)
+			}
+			assert.Equal(t, expected, output.String())
+		})
+	}
+}
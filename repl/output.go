@@ -0,0 +1,58 @@
+package repl
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+type Output struct {
+	writer  io.Writer
+	theme   Theme
+	colored bool
+}
+
+func NewOutput(writer io.Writer, theme Theme, colored bool) *Output {
+	return &Output{
+		writer:  writer,
+		theme:   theme,
+		colored: colored,
+	}
+}
+
+func (o *Output) Paint(role Role, text string) string {
+	if !o.colored || text == "" {
+		return text
+	}
+
+	sequence := o.theme[role]
+	if sequence == "" {
+		return text
+	}
+	return sequence + text + ColorReset
+}
+
+func (o *Output) Print(text string) {
+	fmt.Fprint(o.writer, text)
+}
+
+func (o *Output) Println(text string) {
+	fmt.Fprintln(o.writer, text)
+}
+
+func (o *Output) PrintRole(role Role, text string) {
+	o.Println(o.Paint(role, text))
+}
+
+func shouldColor(writer io.Writer) bool {
+	if force, ok := os.LookupEnv("FORCE_COLOR"); ok {
+		return force != "0" && force != "false"
+	}
+
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+
+	file, ok := writer.(*os.File)
+	return ok && isTerminal(file) && os.Getenv("TERM") != "dumb"
+}
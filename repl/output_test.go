@@ -0,0 +1,70 @@
+package repl
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GivenEnvironmentAndWriter_WhenShouldColor_ThenShouldDetectColorSupport(t *testing.T) {
+	// The null device is a character device, which is what isTerminal looks
+	// for, so it stands in for a terminal.
+	terminal := func(t *testing.T) io.Writer {
+		file, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { file.Close() })
+		return file
+	}
+	regularFile := func(t *testing.T) io.Writer {
+		file, err := os.Create(filepath.Join(t.TempDir(), "output.txt"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { file.Close() })
+		return file
+	}
+	buffer := func(t *testing.T) io.Writer {
+		return &strings.Builder{}
+	}
+
+	type TestCase struct {
+		name     string
+		env      map[string]string
+		writer   func(t *testing.T) io.Writer
+		expected bool
+	}
+
+	testcases := []TestCase{
+		{name: "terminal", env: map[string]string{"TERM": "xterm"}, writer: terminal, expected: true},
+		{name: "terminal without TERM", env: map[string]string{}, writer: terminal, expected: true},
+		{name: "dumb terminal", env: map[string]string{"TERM": "dumb"}, writer: terminal, expected: false},
+		{name: "regular file", env: map[string]string{"TERM": "xterm"}, writer: regularFile, expected: false},
+		{name: "non-file writer", env: map[string]string{"TERM": "xterm"}, writer: buffer, expected: false},
+		{name: "NO_COLOR on terminal", env: map[string]string{"NO_COLOR": "1", "TERM": "xterm"}, writer: terminal, expected: false},
+		{name: "empty NO_COLOR is ignored", env: map[string]string{"NO_COLOR": "", "TERM": "xterm"}, writer: terminal, expected: true},
+		{name: "FORCE_COLOR on non-file writer", env: map[string]string{"FORCE_COLOR": "1"}, writer: buffer, expected: true},
+		{name: "FORCE_COLOR wins over NO_COLOR", env: map[string]string{"FORCE_COLOR": "1", "NO_COLOR": "1"}, writer: buffer, expected: true},
+		{name: "FORCE_COLOR wins over dumb terminal", env: map[string]string{"FORCE_COLOR": "true", "TERM": "dumb"}, writer: terminal, expected: true},
+		{name: "FORCE_COLOR zero on terminal", env: map[string]string{"FORCE_COLOR": "0", "TERM": "xterm"}, writer: terminal, expected: false},
+		{name: "FORCE_COLOR false on terminal", env: map[string]string{"FORCE_COLOR": "false", "TERM": "xterm"}, writer: terminal, expected: false},
+	}
+
+	for _, test := range testcases {
+		t.Run(test.name, func(t *testing.T) {
+			for _, name := range []string{"FORCE_COLOR", "NO_COLOR", "TERM"} {
+				value, ok := test.env[name]
+				t.Setenv(name, value)
+				if !ok {
+					os.Unsetenv(name)
+				}
+			}
+			assert.Equal(t, test.expected, shouldColor(test.writer(t)))
+		})
+	}
+}
@@ -2,49 +2,126 @@ package repl
 
 import (
 	"bufio"
-	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"io/fs"
 	"os"
 	"os/exec"
 	"runtime"
 	"strings"
+	"time"
 
+	"github.com/joaovictorjs/adam-script/ast"
+	"github.com/joaovictorjs/adam-script/interpreter"
+	"github.com/joaovictorjs/adam-script/lexer"
 	"github.com/joaovictorjs/adam-script/parser"
+	"github.com/joaovictorjs/adam-script/render"
+	"github.com/joaovictorjs/adam-script/stdlib"
+	"github.com/joaovictorjs/adam-script/value"
 )
 
+type Options struct {
+	JSON        bool
+	ErrorOutput io.Writer
+	// Served turns off the commands that touch the host, as the session
+	// belongs to a network client rather than the user running the REPL.
+	Served bool
+}
+
 type REPL struct {
-	showAst bool
+	options     Options
+	input       io.Reader
+	stdout      *Output
+	stderr      *Output
+	interpreter *interpreter.Interpreter
+	accepted    []string
+	showAst     bool
+	astFormat   render.Format
+	showTokens  bool
+	showTime    bool
+	exited      bool
 }
 
 const (
-	ColorReset    = "\033[0m"
-	ColorRed      = "\033[31m"
-	ColorGreen    = "\033[32m"
-	ColorYellow   = "\033[33m"
-	ColorBlue     = "\033[34m"
-	ColorPurple   = "\033[35m"
-	ColorCyan     = "\033[36m"
-	ColorWhite    = "\033[37m"
-	ColorDarkGray = "\033[90m"
-	ColorBold     = "\033[1m"
+	ColorReset     = "\033[0m"
+	ColorRed       = "\033[31m"
+	ColorGreen     = "\033[32m"
+	ColorYellow    = "\033[33m"
+	ColorBlue      = "\033[34m"
+	ColorPurple    = "\033[35m"
+	ColorCyan      = "\033[36m"
+	ColorWhite     = "\033[37m"
+	ColorDarkGray  = "\033[90m"
+	ColorBold      = "\033[1m"
+	ColorUnderline = "\033[4m"
 )
 
-func NewREPL() *REPL {
-	return &REPL{}
+func NewREPL(input io.Reader, output io.Writer, options Options) *REPL {
+	errorOutput := options.ErrorOutput
+	if errorOutput == nil {
+		errorOutput = output
+	}
+
+	theme, err := loadUserTheme()
+	colored := !options.JSON
+	repl := &REPL{
+		options:     options,
+		input:       input,
+		stdout:      NewOutput(output, theme, colored && shouldColor(output)),
+		stderr:      NewOutput(errorOutput, theme, colored && shouldColor(errorOutput)),
+		interpreter: newInterpreter(),
+		astFormat:   render.FormatJSON,
+	}
+
+	if err != nil {
+		repl.stderr.PrintRole(RoleError, err.Error())
+	}
+	return repl
+}
+
+// newInterpreter installs the built-in modules as 'adam run' does without
+// any -allow flag, so sessions may not touch files or the environment.
+func newInterpreter() *interpreter.Interpreter {
+	i := interpreter.NewInterpreter()
+	// A fresh interpreter has no constants the modules could collide with.
+	_ = stdlib.Install(i, stdlib.Options{})
+	return i
+}
+
+func loadUserTheme() (Theme, error) {
+	path, err := DefaultThemePath()
+	if err != nil {
+		return DefaultTheme(), nil
+	}
+
+	theme, err := LoadTheme(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return theme, nil
+	}
+	return theme, err
 }
 
 func (r *REPL) Run() {
-	scanner := bufio.NewScanner(os.Stdin)
+	if r.options.JSON {
+		r.runJSON()
+		return
+	}
 
-	printBanner()
+	reader := r.newLineReader()
 
-	for {
-		fmt.Print(ColorCyan + "❯ " + ColorReset)
-		if !scanner.Scan() {
+	r.printBanner()
+
+	for !r.exited {
+		line, err := reader.readLine()
+		if err != nil {
+			if err != io.EOF {
+				r.stderr.Println(r.stderr.Paint(RoleError, "Error reading input:") + " " + err.Error())
+			}
 			break
 		}
 
-		input := strings.TrimSpace(scanner.Text())
+		input := strings.TrimSpace(line)
 		if input == "" {
 			continue
 		}
@@ -54,75 +131,153 @@ func (r *REPL) Run() {
 			continue
 		}
 
-		parser := parser.NewParser(input)
-		program, err := parser.Parse()
+		result, err := r.evaluate(input)
 		if err != nil {
-			fmt.Fprintln(os.Stderr, ColorRed+err.Error()+ColorReset)
+			r.stderr.PrintRole(RoleError, err.Error())
 			continue
 		}
+		r.printValue(result)
+	}
+}
+
+func (r *REPL) evaluate(source string) (value.Value, error) {
+	if r.showTokens {
+		r.printTokens(source)
+	}
+
+	parser := parser.NewParser(source)
+	program, err := parser.Parse()
+	if err != nil {
+		return nil, err
+	}
+
+	if r.showAst {
+		rendered, _ := render.Render(program, r.astFormat)
+		r.stdout.PrintRole(RoleAst, strings.TrimSuffix(rendered, "\n"))
+	}
+
+	start := time.Now()
+	result, err := r.execute(source, program)
+	if r.showTime {
+		r.stdout.PrintRole(RoleHint, "Evaluated in "+time.Since(start).String()+".")
+	}
+	return result, err
+}
+
+// execute runs program one top-level statement at a time and records the
+// ones that completed in accepted, so .save reproduces the session state
+// even when a later statement fails. Function declarations run first, as
+// they are hoisted when the whole program runs.
+func (r *REPL) execute(source string, program ast.Program) (value.Value, error) {
+	statements := make([]ast.Statement, 0, len(program.Statements))
+	for _, statement := range program.Statements {
+		if _, ok := statement.(ast.FunctionDeclaration); ok {
+			statements = append(statements, statement)
+		}
+	}
+	for _, statement := range program.Statements {
+		if _, ok := statement.(ast.FunctionDeclaration); !ok {
+			statements = append(statements, statement)
+		}
+	}
+
+	var result value.Value = value.Null{}
+	for _, statement := range statements {
+		span := ast.SpanOf(statement)
+		v, err := r.interpreter.Evaluate(ast.Program{Statements: []ast.Statement{statement}, Span: span})
+		if err != nil {
+			return nil, err
+		}
+		r.accepted = append(r.accepted, terminated(statement, source[span.Start:span.End]))
+		result = v
+	}
 
-		if r.showAst {
-			data, _ := json.MarshalIndent(program, "", "  ")
-			fmt.Println(ColorDarkGray + string(data) + ColorReset)
+	if last := len(program.Statements) - 1; last >= 0 {
+		if _, ok := program.Statements[last].(ast.FunctionDeclaration); ok {
+			result = value.Null{}
 		}
 	}
+	return result, nil
+}
 
-	if err := scanner.Err(); err != nil {
-		fmt.Fprintln(os.Stderr, ColorRed+"Error reading input:"+ColorReset, err)
+// terminated ends the source of a statement with a semicolon unless it ends
+// with a block, which the parser does not allow to be followed by one.
+func terminated(statement ast.Statement, source string) string {
+	switch statement.(type) {
+	case ast.FunctionDeclaration, ast.BlockStatement, ast.TryStatement:
+		return source
 	}
+	if strings.HasSuffix(source, ";") {
+		return source
+	}
+	return source + ";"
 }
 
-func printBanner() {
-	fmt.Println(ColorPurple + ColorBold)
-	fmt.Println("╔════════════════════════════════════════╗")
-	fmt.Println("║                                        ║")
-	fmt.Println("║       Welcome to the AdamScript        ║")
-	fmt.Println("║                 REPL                   ║")
-	fmt.Println("║                                        ║")
-	fmt.Println("╚════════════════════════════════════════╝")
-	fmt.Println(ColorReset)
-	fmt.Println(ColorYellow + "Type " + ColorCyan + ".help" + ColorYellow + " for available commands" + ColorReset)
-	fmt.Println()
+func (r *REPL) printValue(v value.Value) {
+	role := RoleText
+	switch v.(type) {
+	case value.Null:
+		return
+	case value.Number:
+		role = RoleNumber
+	case value.String:
+		role = RoleString
+	}
+	r.stdout.PrintRole(role, value.Inspect(v))
 }
 
-func (r *REPL) handleCommand(cmd string) {
-	switch cmd {
-	case ".help":
-		printHelp()
-	case ".ast":
-		r.toggleShowAst()
-	case ".exit":
-		os.Exit(0)
-	case ".clear":
-		clearScreen()
-	default:
-		fmt.Printf(ColorRed+"Unknown command: "+ColorWhite+"%s\n"+ColorReset, cmd)
-		fmt.Println(ColorYellow + "Type " + ColorCyan + ".help" + ColorYellow + " for available commands" + ColorReset)
+func (r *REPL) printTokens(source string) {
+	tokens := lexer.NewLexer(source).GenerateTokens()
+	for _, token := range tokens {
+		line := fmt.Sprintf("%-14s %-10q at position %d", token.Kind, token.Lexeme, token.Position)
+		r.stdout.PrintRole(RoleAst, line)
 	}
 }
 
-func (r *REPL) toggleShowAst() {
-	r.showAst = !r.showAst
-	color := ColorRed
-	label := "OFF"
-	if r.showAst {
-		color = ColorGreen
-		label = "ON"
+func (r *REPL) newLineReader() lineReader {
+	prompt := r.stdout.Paint(RolePrompt, "❯ ")
+	input, inputIsFile := r.input.(*os.File)
+	output, outputIsFile := r.stdout.writer.(*os.File)
+	if inputIsFile && outputIsFile && isTerminal(input) && isTerminal(output) {
+		if restore, err := makeRaw(int(input.Fd())); err == nil {
+			restore()
+			return newLineEditor(input, r.stdout, prompt)
+		}
 	}
 
-	fmt.Println("Show ast was turned " + ColorBold + color + label + ColorReset + ".")
+	scanner := bufio.NewScanner(r.input)
+	return &scannerLineReader{scanner: scanner, output: r.stdout, prompt: prompt}
+}
+
+func (r *REPL) printBanner() {
+	banner := strings.Join([]string{
+		"",
+		"╔════════════════════════════════════════╗",
+		"║                                        ║",
+		"║       Welcome to the AdamScript        ║",
+		"║                 REPL                   ║",
+		"║                                        ║",
+		"╚════════════════════════════════════════╝",
+	}, "\n")
+	r.stdout.PrintRole(RoleBanner, banner)
+	r.stdout.Println("")
+	r.printHelpHint()
+	r.stdout.Println("")
 }
 
-func printHelp() {
-	fmt.Println(ColorBlue + ColorBold + "\nAvailable Commands:" + ColorReset)
-	fmt.Println(ColorCyan + "  .help  " + ColorWhite + "- Show this help message" + ColorReset)
-	fmt.Println(ColorCyan + "  .ast   " + ColorWhite + "- Turn ON/OFF showing AST after parsing" + ColorReset)
-	fmt.Println(ColorCyan + "  .exit  " + ColorWhite + "- Exit the REPL" + ColorReset)
-	fmt.Println(ColorCyan + "  .clear " + ColorWhite + "- Clear the screen" + ColorReset)
-	fmt.Println()
+func (r *REPL) printHelpHint() {
+	out := r.stdout
+	out.Println(out.Paint(RoleHint, "Type ") + out.Paint(RoleCommand, ".help") + out.Paint(RoleHint, " for available commands"))
 }
 
-func clearScreen() {
+func (r *REPL) clearScreen() {
+	output, ok := r.stdout.writer.(*os.File)
+	if !ok {
+		r.stdout.Print("\033[H\033[2J")
+		r.printBanner()
+		return
+	}
+
 	var cmd *exec.Cmd
 
 	switch runtime.GOOS {
@@ -132,8 +287,8 @@ func clearScreen() {
 		cmd = exec.Command("clear")
 	}
 
-	cmd.Stdout = os.Stdout
+	cmd.Stdout = output
 	cmd.Run()
 
-	printBanner()
+	r.printBanner()
 }
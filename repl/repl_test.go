@@ -0,0 +1,67 @@
+package repl
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GivenInputReader_WhenRun_ThenShouldWriteResultsToOutput(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	input := strings.NewReader("let x = 2\nx * 21\n.exit\nx\n")
+	var output strings.Builder
+
+	repl := NewREPL(input, &output, Options{})
+	repl.Run()
+
+	lines := strings.Split(output.String(), "\n")
+	assert.Contains(t, lines, "❯ ❯ 42")
+	assert.True(t, strings.HasSuffix(output.String(), "❯ ❯ 42\n❯ "))
+}
+
+func Test_GivenModuleCall_WhenEvaluated_ThenShouldUseBuiltInModules(t *testing.T) {
+	t.Parallel()
+
+	type TestCase struct {
+		name           string
+		inputs         []string
+		expectedStdout string
+		expectedStderr string
+	}
+
+	testcases := []TestCase{
+		{
+			name:           "math function",
+			inputs:         []string{"math.sqrt(16)"},
+			expectedStdout: "4\n",
+		},
+		{
+			name:           "strings function",
+			inputs:         []string{`strings.repeat("ab", 2)`},
+			expectedStdout: "\"abab\"\n",
+		},
+		{
+			name:           "module after reset",
+			inputs:         []string{".reset", "math.sqrt(9)"},
+			expectedStdout: "Session was reset.\n3\n",
+		},
+		{
+			name:           "file access without permission",
+			inputs:         []string{`fs.exists("go.mod")`},
+			expectedStderr: "Permission denied, requires 'read' access to 'go.mod'.\n",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var stdout, stderr strings.Builder
+			feed(newTestREPL(&stdout, &stderr), tc.inputs...)
+
+			assert.Equal(t, tc.expectedStdout, stdout.String())
+			assert.Equal(t, tc.expectedStderr, stderr.String())
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package repl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+)
+
+type Server struct {
+	listener net.Listener
+	options  Options
+	mutex    sync.Mutex
+	sessions map[net.Conn]struct{}
+	closed   bool
+	wg       sync.WaitGroup
+}
+
+// Listen opens 'unix:/path' or 'tcp:host:port' for a Server. Sessions are
+// not authenticated, so a TCP address without a host listens on loopback
+// only and anyone who can reach the address can run scripts on the host.
+func Listen(address string) (net.Listener, error) {
+	network, location, ok := strings.Cut(address, ":")
+	if !ok || location == "" || (network != "unix" && network != "tcp") {
+		return nil, fmt.Errorf("Invalid listen address '%s', expected 'unix:/path' or 'tcp:host:port'.", address)
+	}
+
+	if network == "tcp" {
+		host, port, err := net.SplitHostPort(location)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid listen address '%s', expected 'unix:/path' or 'tcp:host:port'.", address)
+		}
+		if host == "" {
+			host = "127.0.0.1"
+		}
+		location = net.JoinHostPort(host, port)
+	}
+	return net.Listen(network, location)
+}
+
+// NewServer serves sessions without the commands that read, write or run
+// anything on the host, see Options.Served.
+func NewServer(listener net.Listener, options Options) *Server {
+	options.ErrorOutput = nil
+	options.Served = true
+	return &Server{
+		listener: listener,
+		options:  options,
+		sessions: map[net.Conn]struct{}{},
+	}
+}
+
+func (s *Server) Addr() net.Addr {
+	return s.listener.Addr()
+}
+
+func (s *Server) Serve(ctx context.Context) error {
+	stop := context.AfterFunc(ctx, s.shutdown)
+	defer stop()
+
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			s.wg.Wait()
+			if ctx.Err() != nil || errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+
+		if !s.track(conn) {
+			conn.Close()
+			continue
+		}
+
+		s.wg.Add(1)
+		go s.serveSession(conn)
+	}
+}
+
+func (s *Server) serveSession(conn net.Conn) {
+	defer s.wg.Done()
+	defer s.untrack(conn)
+	defer conn.Close()
+
+	repl := NewREPL(conn, conn, s.options)
+	repl.Run()
+}
+
+func (s *Server) shutdown() {
+	s.listener.Close()
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.closed = true
+	for conn := range s.sessions {
+		conn.Close()
+	}
+}
+
+func (s *Server) track(conn net.Conn) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.closed {
+		return false
+	}
+
+	s.sessions[conn] = struct{}{}
+	return true
+}
+
+func (s *Server) untrack(conn net.Conn) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	delete(s.sessions, conn)
+}
@@ -0,0 +1,153 @@
+package repl
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GivenConcurrentConnections_WhenServe_ThenShouldKeepIndependentSessions(t *testing.T) {
+	listener, err := Listen("tcp:127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	server := NewServer(listener, Options{JSON: true})
+	done := make(chan error)
+	go func() {
+		done <- server.Serve(ctx)
+	}()
+
+	first := dialSession(t, server.Addr())
+	second := dialSession(t, server.Addr())
+
+	assert.Equal(t, `{"ok":true,"value":null,"type":"null"}`, first.send(t, "let x = 1"))
+	assert.Equal(t, `{"ok":true,"value":null,"type":"null"}`, second.send(t, "let x = 2"))
+	assert.Equal(t, `{"ok":true,"value":1,"type":"number"}`, first.send(t, "x"))
+	assert.Equal(t, `{"ok":true,"value":2,"type":"number"}`, second.send(t, "x"))
+
+	cancel()
+	assert.NoError(t, <-done)
+
+	_, err = first.reader.ReadString('\n')
+	assert.Error(t, err)
+}
+
+func Test_GivenServedSession_WhenHostCommandSent_ThenShouldRefuseIt(t *testing.T) {
+	listener, err := Listen("tcp:127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	server := NewServer(listener, Options{JSON: true})
+	go server.Serve(ctx)
+
+	saved := filepath.Join(t.TempDir(), "saved.adam")
+	client := dialSession(t, server.Addr())
+
+	type TestCase struct {
+		name     string
+		input    string
+		expected string
+	}
+
+	testcases := []TestCase{
+		{name: "load", input: ".load /etc/passwd", expected: `{"ok":false,"diagnostics":[{"severity":"error","message":"Command '.load' is not available in served sessions."}]}`},
+		{name: "save", input: ".save " + saved, expected: `{"ok":false,"diagnostics":[{"severity":"error","message":"Command '.save' is not available in served sessions."}]}`},
+		{name: "other commands", input: ".time", expected: `{"ok":true,"output":"Show time was turned ON."}`},
+	}
+
+	for _, test := range testcases {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, client.send(t, test.input))
+		})
+	}
+
+	help := client.send(t, ".help")
+	assert.NotContains(t, help, ".load")
+	assert.NotContains(t, help, ".save")
+	assert.NotContains(t, help, ".clear")
+	assert.NoFileExists(t, saved)
+}
+
+func Test_GivenTCPAddress_WhenListen_ThenShouldListenOnCorrectHost(t *testing.T) {
+	type TestCase struct {
+		name         string
+		address      string
+		expectedHost string
+	}
+
+	testcases := []TestCase{
+		{name: "empty host", address: "tcp::0", expectedHost: "127.0.0.1"},
+		{name: "explicit host", address: "tcp:127.0.0.1:0", expectedHost: "127.0.0.1"},
+	}
+
+	for _, test := range testcases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			listener, err := Listen(test.address)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer listener.Close()
+
+			host, _, err := net.SplitHostPort(listener.Addr().String())
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedHost, host)
+		})
+	}
+}
+
+func Test_GivenInvalidAddress_WhenListen_ThenShouldReturnCorrectError(t *testing.T) {
+	type TestCase struct {
+		name    string
+		address string
+	}
+
+	testcases := []TestCase{
+		{name: "unknown network", address: "udp:127.0.0.1:0"},
+		{name: "tcp without port", address: "tcp:localhost"},
+	}
+
+	for _, test := range testcases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := Listen(test.address)
+			assert.Equal(t, fmt.Errorf("Invalid listen address '%s', expected 'unix:/path' or 'tcp:host:port'.", test.address), err)
+		})
+	}
+}
+
+type session struct {
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+func dialSession(t *testing.T, addr net.Addr) session {
+	conn, err := net.Dial(addr.Network(), addr.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return session{conn: conn, reader: bufio.NewReader(conn)}
+}
+
+func (s session) send(t *testing.T, line string) string {
+	if _, err := fmt.Fprintln(s.conn, line); err != nil {
+		t.Fatal(err)
+	}
+
+	response, err := s.reader.ReadString('\n')
+	if err != nil {
+		t.Fatal(err)
+	}
+	return response[:len(response)-1]
+}
@@ -0,0 +1,142 @@
+package repl
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+type Role string
+
+const (
+	RoleBanner        Role = "banner"
+	RolePrompt        Role = "prompt"
+	RoleHint          Role = "hint"
+	RoleCommand       Role = "command"
+	RoleText          Role = "text"
+	RoleError         Role = "error"
+	RoleSuccess       Role = "success"
+	RoleAst           Role = "ast"
+	RoleEmphasis      Role = "emphasis"
+	RoleKeyword       Role = "keyword"
+	RoleIdentifier    Role = "identifier"
+	RoleNumber        Role = "number"
+	RoleString        Role = "string"
+	RoleOperator      Role = "operator"
+	RoleParen         Role = "paren"
+	RoleMatchingParen Role = "matchingParen"
+	RoleUnknown       Role = "unknown"
+	RoleComment       Role = "comment"
+)
+
+type Theme map[Role]string
+
+var colorNames = map[string]string{
+	"reset":     "0",
+	"bold":      "1",
+	"dim":       "2",
+	"italic":    "3",
+	"underline": "4",
+	"black":     "30",
+	"red":       "31",
+	"green":     "32",
+	"yellow":    "33",
+	"blue":      "34",
+	"purple":    "35",
+	"magenta":   "35",
+	"cyan":      "36",
+	"white":     "37",
+	"darkgray":  "90",
+	"gray":      "90",
+}
+
+func DefaultTheme() Theme {
+	return Theme{
+		RoleBanner:        ColorPurple + ColorBold,
+		RolePrompt:        ColorCyan,
+		RoleHint:          ColorYellow,
+		RoleCommand:       ColorCyan,
+		RoleText:          ColorWhite,
+		RoleError:         ColorRed,
+		RoleSuccess:       ColorGreen,
+		RoleAst:           ColorDarkGray,
+		RoleEmphasis:      ColorBlue + ColorBold,
+		RoleKeyword:       ColorPurple + ColorBold,
+		RoleIdentifier:    ColorWhite,
+		RoleNumber:        ColorYellow,
+		RoleString:        ColorGreen,
+		RoleOperator:      ColorCyan,
+		RoleParen:         ColorBlue,
+		RoleMatchingParen: ColorBlue + ColorBold + ColorUnderline,
+		RoleUnknown:       ColorRed,
+		RoleComment:       ColorDarkGray,
+	}
+}
+
+func DefaultThemePath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "adam-script", "theme.json"), nil
+}
+
+// LoadTheme overrides the default theme with the roles in the file at path.
+// The overrides apply only when the whole file is valid, on any error the
+// default theme is returned unchanged.
+func LoadTheme(path string) (Theme, error) {
+	theme := DefaultTheme()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return theme, err
+	}
+
+	overrides := map[Role]string{}
+	if err := json.Unmarshal(data, &overrides); err != nil {
+		return theme, fmt.Errorf("Invalid theme file '%s': %w", path, err)
+	}
+
+	sequences := make(map[Role]string, len(overrides))
+	for role, spec := range overrides {
+		if _, ok := theme[role]; !ok {
+			return theme, fmt.Errorf("Invalid theme file '%s': unknown role '%s'.", path, role)
+		}
+
+		sequence, err := parseColorSpec(spec)
+		if err != nil {
+			return theme, fmt.Errorf("Invalid theme file '%s': %w", path, err)
+		}
+		sequences[role] = sequence
+	}
+
+	for role, sequence := range sequences {
+		theme[role] = sequence
+	}
+	return theme, nil
+}
+
+func parseColorSpec(spec string) (string, error) {
+	codes := []string{}
+	for _, field := range strings.Fields(spec) {
+		name := strings.ToLower(field)
+		if code, ok := colorNames[name]; ok {
+			codes = append(codes, code)
+			continue
+		}
+
+		for _, part := range strings.Split(field, ";") {
+			if _, err := strconv.Atoi(part); err != nil {
+				return "", fmt.Errorf("unknown color '%s'.", field)
+			}
+		}
+		codes = append(codes, field)
+	}
+
+	if len(codes) == 0 {
+		return "", nil
+	}
+	return "\033[" + strings.Join(codes, ";") + "m", nil
+}
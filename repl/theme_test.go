@@ -0,0 +1,127 @@
+package repl
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GivenColorSpec_WhenParse_ThenShouldReturnCorrectSequence(t *testing.T) {
+	type TestCase struct {
+		name          string
+		spec          string
+		expected      string
+		expectedError string
+	}
+
+	testcases := []TestCase{
+		{name: "name", spec: "red", expected: ColorRed},
+		{name: "several names", spec: "blue bold underline", expected: "\033[34;1;4m"},
+		{name: "mixed case", spec: "Bold GRAY", expected: "\033[1;90m"},
+		{name: "numeric code", spec: "38;5;208", expected: "\033[38;5;208m"},
+		{name: "names and codes", spec: "bold 38;5;208", expected: "\033[1;38;5;208m"},
+		{name: "empty", spec: "  ", expected: ""},
+		{name: "unknown name", spec: "bold pink", expectedError: "unknown color 'pink'."},
+		{name: "malformed code", spec: "38;;5", expectedError: "unknown color '38;;5'."},
+	}
+
+	for _, test := range testcases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			actual, err := parseColorSpec(test.spec)
+			if test.expectedError != "" {
+				assert.EqualError(t, err, test.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func Test_GivenThemeFile_WhenLoadTheme_ThenShouldOverrideDefaultTheme(t *testing.T) {
+	type TestCase struct {
+		name          string
+		content       string
+		expected      map[Role]string
+		expectedError string
+	}
+
+	testcases := []TestCase{
+		{
+			name:     "overrides",
+			content:  `{"keyword": "red bold", "number": "38;5;208"}`,
+			expected: map[Role]string{RoleKeyword: "\033[31;1m", RoleNumber: "\033[38;5;208m"},
+		},
+		{
+			name:     "disabled role",
+			content:  `{"ast": ""}`,
+			expected: map[Role]string{RoleAst: ""},
+		},
+		{
+			name:     "empty object",
+			content:  `{}`,
+			expected: map[Role]string{},
+		},
+		{
+			name:          "malformed file",
+			content:       `{"keyword": "red",`,
+			expectedError: "Invalid theme file '%s': unexpected end of JSON input",
+		},
+		{
+			name:          "unknown role",
+			content:       `{"keywords": "red"}`,
+			expectedError: "Invalid theme file '%s': unknown role 'keywords'.",
+		},
+		{
+			name:          "unknown role between valid ones",
+			content:       `{"keyword": "red", "number": "green", "nope": "blue", "paren": "cyan", "string": "bold"}`,
+			expectedError: "Invalid theme file '%s': unknown role 'nope'.",
+		},
+		{
+			name:          "unknown color between valid ones",
+			content:       `{"keyword": "red", "number": "pink", "paren": "cyan", "string": "bold"}`,
+			expectedError: "Invalid theme file '%s': unknown color 'pink'.",
+		},
+		{
+			name:          "unknown color",
+			content:       `{"keyword": "pink"}`,
+			expectedError: "Invalid theme file '%s': unknown color 'pink'.",
+		},
+	}
+
+	for _, test := range testcases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			path := filepath.Join(t.TempDir(), "theme.json")
+			if err := os.WriteFile(path, []byte(test.content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			theme, err := LoadTheme(path)
+			if test.expectedError != "" {
+				assert.EqualError(t, err, fmt.Sprintf(test.expectedError, path))
+				assert.Equal(t, DefaultTheme(), theme)
+				return
+			}
+			assert.NoError(t, err)
+
+			expected := DefaultTheme()
+			for role, sequence := range test.expected {
+				expected[role] = sequence
+			}
+			assert.Equal(t, expected, theme)
+		})
+	}
+}
+
+func Test_GivenMissingThemeFile_WhenLoadTheme_ThenShouldReturnDefaultThemeAndNotExistError(t *testing.T) {
+	t.Parallel()
+	theme, err := LoadTheme(filepath.Join(t.TempDir(), "theme.json"))
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+	assert.Equal(t, DefaultTheme(), theme)
+}
@@ -0,0 +1,384 @@
+package resolver
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/joaovictorjs/adam-script/ast"
+	"github.com/joaovictorjs/adam-script/diagnostic"
+)
+
+type Binding struct {
+	Name        string
+	Depth       int
+	Slot        int
+	Constant    bool
+	Declaration ast.Span
+}
+
+type Resolution struct {
+	Bindings    map[ast.Span]Binding
+	Diagnostics []diagnostic.Diagnostic
+}
+
+type Option func(*resolver)
+
+func WithGlobals(names ...string) Option {
+	return func(r *resolver) {
+		r.globals = append(r.globals, names...)
+	}
+}
+
+type variable struct {
+	binding  Binding
+	function bool
+	used     bool
+}
+
+type scope struct {
+	variables map[string]*variable
+	pending   map[string]bool
+	deferred  []reference
+	function  bool
+	slots     int
+}
+
+// reference is a use of a name from a function body that is declared later
+// in an enclosing scope, which is fine as long as the function only runs
+// after the declaration. It is resolved when that scope ends.
+type reference struct {
+	identifier ast.IdentifierExpression
+	found      func(*variable)
+}
+
+type resolver struct {
+	globals     []string
+	scopes      []*scope
+	bindings    map[ast.Span]Binding
+	diagnostics []diagnostic.Diagnostic
+}
+
+func Resolve(program ast.Program, options ...Option) Resolution {
+	r := &resolver{bindings: map[ast.Span]Binding{}}
+	for _, option := range options {
+		option(r)
+	}
+
+	if invalid, ok := InvalidSpan(program); ok {
+		return Resolution{Bindings: r.bindings, Diagnostics: []diagnostic.Diagnostic{invalid}}
+	}
+
+	r.beginScope(program.Statements)
+	for _, name := range r.globals {
+		if _, ok := r.current().variables[name]; !ok {
+			r.declare(name, false, ast.Span{}).used = true
+		}
+	}
+	r.resolveStatements(program.Statements)
+	r.endScope()
+
+	slices.SortStableFunc(r.diagnostics, func(a, b diagnostic.Diagnostic) int {
+		return cmp.Compare(a.Span.Start, b.Span.Start)
+	})
+	return Resolution{Bindings: r.bindings, Diagnostics: r.diagnostics}
+}
+
+// InvalidSpan reports the first identifier or declaration whose span is empty
+// or shared with another one. Bindings are keyed by span, so programs built
+// or unmarshalled without spans cannot be resolved.
+func InvalidSpan(program ast.Program) (diagnostic.Diagnostic, bool) {
+	seen := map[ast.Span]bool{}
+	var invalid diagnostic.Diagnostic
+	found := false
+	check := func(name string, span ast.Span) {
+		if !found && (span.Start >= span.End || seen[span]) {
+			invalid = diagnostic.Diagnostic{
+				Severity: diagnostic.Error,
+				Code:     "invalid-span",
+				Message:  fmt.Sprintf("Cannot resolve '%s' without a source span of its own.", name),
+				Span:     span,
+			}
+			found = true
+		}
+		seen[span] = true
+	}
+
+	ast.Inspect(program, func(node ast.Node) bool {
+		switch n := node.(type) {
+		case ast.IdentifierExpression:
+			check(n.Symbol, n.Span)
+		case ast.VariableDeclaration:
+			check(n.Identifier, n.Span)
+		case ast.FunctionDeclaration:
+			check(n.Name, n.Span)
+			for _, parameter := range n.Parameters {
+				check(parameter.Name, parameter.Span)
+			}
+		case ast.TryStatement:
+			check(n.Parameter.Name, n.Parameter.Span)
+		}
+		return !found
+	})
+	return invalid, found
+}
+
+func (r *resolver) resolveStatements(statements []ast.Statement) {
+	for _, statement := range statements {
+		if declaration, ok := statement.(ast.FunctionDeclaration); ok {
+			if v, ok := r.resolveDeclaration(declaration.Name, false, declaration.Span); ok {
+				v.function = true
+			}
+		}
+	}
+
+	for _, statement := range statements {
+		r.resolveStatement(statement)
+	}
+}
+
+func (r *resolver) resolveStatement(statement ast.Statement) {
+	switch s := statement.(type) {
+	case ast.ExpressionStatement:
+		r.resolveExpression(s.Expression)
+	case ast.VariableDeclaration:
+		r.resolveExpression(s.Value)
+		r.resolveDeclaration(s.Identifier, s.Constant, s.Span)
+	case ast.FunctionDeclaration:
+		r.beginScope(s.Body.Statements)
+		r.current().function = true
+		for _, parameter := range s.Parameters {
+			if v, ok := r.resolveDeclaration(parameter.Name, false, parameter.Span); ok {
+				v.used = true
+			}
+		}
+		r.resolveStatements(s.Body.Statements)
+		r.endScope()
+	case ast.ReturnStatement:
+		if s.Value != nil {
+			r.resolveExpression(s.Value)
+		}
+	case ast.BlockStatement:
+		r.beginScope(s.Statements)
+		r.resolveStatements(s.Statements)
+		r.endScope()
+	case ast.TryStatement:
+		r.resolveStatement(s.Body)
+		r.beginScope(s.Handler.Statements)
+		if v, ok := r.resolveDeclaration(s.Parameter.Name, false, s.Parameter.Span); ok {
+			v.used = true
+		}
+		r.resolveStatements(s.Handler.Statements)
+		r.endScope()
+	default:
+		panic(fmt.Sprintf("resolver: unexpected statement type %T", s))
+	}
+}
+
+func (r *resolver) resolveExpression(expression ast.Expression) {
+	switch e := expression.(type) {
+	case ast.NumericLiteralExpression, ast.StringLiteralExpression:
+	case ast.IdentifierExpression:
+		r.lookup(e, func(v *variable) {
+			v.used = true
+		})
+	case ast.AssignmentExpression:
+		target, ok := e.Target.(ast.IdentifierExpression)
+		if !ok {
+			r.resolveExpression(e.Target)
+			r.resolveExpression(e.Value)
+			return
+		}
+
+		r.resolveExpression(e.Value)
+		r.lookup(target, func(v *variable) {
+			if v.binding.Constant {
+				r.report(diagnostic.Error, "const-assignment", e.Span, "Cannot assign to constant '%s'.", target.Symbol)
+			}
+		})
+	case ast.MemberExpression:
+		r.resolveExpression(e.Object)
+	case ast.CallExpression:
+		r.resolveExpression(e.Callee)
+		for _, argument := range e.Arguments {
+			r.resolveExpression(argument)
+		}
+	case ast.BinaryExpression:
+		r.resolveExpression(e.Left)
+		r.resolveExpression(e.Right)
+	case ast.UnaryExpression:
+		r.resolveExpression(e.Operand)
+	case ast.ObjectExpression:
+		for _, property := range e.Properties {
+			r.resolveExpression(property.Value)
+		}
+	default:
+		panic(fmt.Sprintf("resolver: unexpected expression type %T", e))
+	}
+}
+
+func (r *resolver) resolveDeclaration(name string, constant bool, span ast.Span) (*variable, bool) {
+	if _, ok := r.current().variables[name]; ok {
+		r.report(diagnostic.Error, "redeclared-variable", span, "Variable '%s' is already declared.", name)
+		return nil, false
+	}
+
+	for _, outer := range r.scopes[:len(r.scopes)-1] {
+		if _, ok := outer.variables[name]; ok {
+			r.report(diagnostic.Warning, "shadowed-variable", span, "Variable '%s' shadows a variable declared in an outer scope.", name)
+			break
+		}
+	}
+
+	v := r.declare(name, constant, span)
+	r.bindings[span] = v.binding
+	return v, true
+}
+
+// lookup binds identifier and calls found with its variable, possibly only
+// when the scope declaring it ends.
+func (r *resolver) lookup(identifier ast.IdentifierExpression, found func(*variable)) {
+	// A name declared later in an enclosing scope still refers to an outer
+	// binding until its declaration runs, it is only an error without one.
+	// Function bodies are the exception, they run after the declarations
+	// of the scopes around them.
+	name := identifier.Symbol
+	pending := false
+	inFunction := false
+	for depth := len(r.scopes) - 1; depth >= 0; depth-- {
+		s := r.scopes[depth]
+		if v, ok := s.variables[name]; ok {
+			r.bindings[identifier.Span] = v.binding
+			found(v)
+			return
+		}
+
+		if s.pending[name] && inFunction {
+			s.deferred = append(s.deferred, reference{identifier: identifier, found: found})
+			return
+		}
+		pending = pending || s.pending[name]
+		inFunction = inFunction || s.function
+	}
+
+	if pending {
+		r.report(diagnostic.Error, "use-before-declaration", identifier.Span, "Variable '%s' is used before its declaration.", name)
+		return
+	}
+
+	message := fmt.Sprintf("Undefined variable '%s'.", name)
+	if suggestion, ok := r.suggest(name); ok {
+		message += fmt.Sprintf(" Did you mean '%s'?", suggestion)
+	}
+	r.report(diagnostic.Error, "undefined-variable", identifier.Span, "%s", message)
+}
+
+func (r *resolver) suggest(name string) (string, bool) {
+	best := ""
+	bestDistance := max(1, len(name)/3) + 1
+	for _, s := range r.scopes {
+		for candidate := range s.variables {
+			distance := levenshtein(name, candidate)
+			if distance < bestDistance || (distance == bestDistance && candidate < best) {
+				best = candidate
+				bestDistance = distance
+			}
+		}
+	}
+	return best, best != ""
+}
+
+func (r *resolver) beginScope(statements []ast.Statement) {
+	s := &scope{
+		variables: map[string]*variable{},
+		pending:   map[string]bool{},
+	}
+	for _, statement := range statements {
+		if declaration, ok := statement.(ast.VariableDeclaration); ok {
+			s.pending[declaration.Identifier] = true
+		}
+	}
+	r.scopes = append(r.scopes, s)
+}
+
+func (r *resolver) endScope() {
+	s := r.current()
+	r.scopes = r.scopes[:len(r.scopes)-1]
+
+	for _, deferred := range s.deferred {
+		if v, ok := s.variables[deferred.identifier.Symbol]; ok {
+			r.bindings[deferred.identifier.Span] = v.binding
+			deferred.found(v)
+		}
+	}
+
+	unused := []*variable{}
+	for _, v := range s.variables {
+		if !v.used && !strings.HasPrefix(v.binding.Name, "_") {
+			unused = append(unused, v)
+		}
+	}
+
+	slices.SortFunc(unused, func(a, b *variable) int {
+		return cmp.Compare(a.binding.Slot, b.binding.Slot)
+	})
+	for _, v := range unused {
+		noun := "Variable"
+		if v.function {
+			noun = "Function"
+		}
+		r.report(diagnostic.Warning, "unused-variable", v.binding.Declaration, "%s '%s' is declared but never used.", noun, v.binding.Name)
+	}
+}
+
+func (r *resolver) declare(name string, constant bool, span ast.Span) *variable {
+	s := r.current()
+	v := &variable{
+		binding: Binding{
+			Name:        name,
+			Depth:       len(r.scopes) - 1,
+			Slot:        s.slots,
+			Constant:    constant,
+			Declaration: span,
+		},
+	}
+	s.slots++
+	s.variables[name] = v
+	delete(s.pending, name)
+	return v
+}
+
+func (r *resolver) current() *scope {
+	return r.scopes[len(r.scopes)-1]
+}
+
+func (r *resolver) report(severity diagnostic.Severity, code string, span ast.Span, format string, args ...any) {
+	r.diagnostics = append(r.diagnostics, diagnostic.Diagnostic{
+		Severity: severity,
+		Code:     code,
+		Message:  fmt.Sprintf(format, args...),
+		Span:     span,
+	})
+}
+
+func levenshtein(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
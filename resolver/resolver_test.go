@@ -0,0 +1,265 @@
+package resolver
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/joaovictorjs/adam-script/ast"
+	"github.com/joaovictorjs/adam-script/diagnostic"
+	"github.com/joaovictorjs/adam-script/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GivenSource_WhenResolve_ThenShouldReportDiagnostics(t *testing.T) {
+	type TestCase struct {
+		name                string
+		source              string
+		expectedDiagnostics []diagnostic.Diagnostic
+	}
+
+	testcases := []TestCase{
+		{
+			name:   "used variables",
+			source: "let x = 1; const y = x; { x = y }",
+		},
+		{
+			name:   "undefined variable",
+			source: "a + 1",
+			expectedDiagnostics: []diagnostic.Diagnostic{
+				{Severity: diagnostic.Error, Code: "undefined-variable", Message: "Undefined variable 'a'.", Span: ast.Span{Start: 0, End: 1}},
+			},
+		},
+		{
+			name:   "undefined variable with suggestion",
+			source: "let total = 1; totl * 2",
+			expectedDiagnostics: []diagnostic.Diagnostic{
+				{Severity: diagnostic.Warning, Code: "unused-variable", Message: "Variable 'total' is declared but never used.", Span: ast.Span{Start: 0, End: 14}},
+				{Severity: diagnostic.Error, Code: "undefined-variable", Message: "Undefined variable 'totl'. Did you mean 'total'?", Span: ast.Span{Start: 15, End: 19}},
+			},
+		},
+		{
+			name:   "variables of closed scopes are not suggested",
+			source: "{ let count = 1; count } coun",
+			expectedDiagnostics: []diagnostic.Diagnostic{
+				{Severity: diagnostic.Error, Code: "undefined-variable", Message: "Undefined variable 'coun'.", Span: ast.Span{Start: 25, End: 29}},
+			},
+		},
+		{
+			name:   "catch parameter is scoped to the handler",
+			source: "try { 1 } catch (e) { e } e",
+			expectedDiagnostics: []diagnostic.Diagnostic{
+				{Severity: diagnostic.Error, Code: "undefined-variable", Message: "Undefined variable 'e'.", Span: ast.Span{Start: 26, End: 27}},
+			},
+		},
+		{
+			name:   "use before declaration",
+			source: "x; let x = 1; x",
+			expectedDiagnostics: []diagnostic.Diagnostic{
+				{Severity: diagnostic.Error, Code: "use-before-declaration", Message: "Variable 'x' is used before its declaration.", Span: ast.Span{Start: 0, End: 1}},
+			},
+		},
+		{
+			name:   "use in own initializer",
+			source: "let x = x + 1; x",
+			expectedDiagnostics: []diagnostic.Diagnostic{
+				{Severity: diagnostic.Error, Code: "use-before-declaration", Message: "Variable 'x' is used before its declaration.", Span: ast.Span{Start: 8, End: 9}},
+			},
+		},
+		{
+			name:   "outer variable is used until an inner declaration shadows it",
+			source: "let x = 1; { x; let x = 2; x }",
+			expectedDiagnostics: []diagnostic.Diagnostic{
+				{Severity: diagnostic.Warning, Code: "shadowed-variable", Message: "Variable 'x' shadows a variable declared in an outer scope.", Span: ast.Span{Start: 16, End: 26}},
+			},
+		},
+		{
+			name:   "assignment to constant",
+			source: "const x = 1; x = 2; x",
+			expectedDiagnostics: []diagnostic.Diagnostic{
+				{Severity: diagnostic.Error, Code: "const-assignment", Message: "Cannot assign to constant 'x'.", Span: ast.Span{Start: 13, End: 18}},
+			},
+		},
+		{
+			name:   "assignment is not a use",
+			source: "let x = 1; x = 2",
+			expectedDiagnostics: []diagnostic.Diagnostic{
+				{Severity: diagnostic.Warning, Code: "unused-variable", Message: "Variable 'x' is declared but never used.", Span: ast.Span{Start: 0, End: 10}},
+			},
+		},
+		{
+			name:   "unused variables prefixed with an underscore",
+			source: "let _ignored = 1;",
+		},
+		{
+			name:   "shadowed variable",
+			source: "let x = 1; { let x = 2; x } x",
+			expectedDiagnostics: []diagnostic.Diagnostic{
+				{Severity: diagnostic.Warning, Code: "shadowed-variable", Message: "Variable 'x' shadows a variable declared in an outer scope.", Span: ast.Span{Start: 13, End: 23}},
+			},
+		},
+		{
+			name:   "functions are hoisted",
+			source: "let x = twice(2); fn twice(n) { return n * 2 } x",
+		},
+		{
+			name:   "function body uses a later declaration",
+			source: "{ fn read() { return later } let later = 5; read() }",
+		},
+		{
+			name:   "function body assigns a later constant",
+			source: "{ fn f() { c = 2 } const c = 1; f(); c }",
+			expectedDiagnostics: []diagnostic.Diagnostic{
+				{Severity: diagnostic.Error, Code: "const-assignment", Message: "Cannot assign to constant 'c'.", Span: ast.Span{Start: 11, End: 16}},
+			},
+		},
+		{
+			name:   "unused function and undefined name inside its body",
+			source: "fn f(a) { return a + b }",
+			expectedDiagnostics: []diagnostic.Diagnostic{
+				{Severity: diagnostic.Warning, Code: "unused-variable", Message: "Function 'f' is declared but never used.", Span: ast.Span{Start: 0, End: 24}},
+				{Severity: diagnostic.Error, Code: "undefined-variable", Message: "Undefined variable 'b'. Did you mean 'a'?", Span: ast.Span{Start: 21, End: 22}},
+			},
+		},
+		{
+			name:   "parameter shadowing a variable",
+			source: "let n = 1; fn f(n) { return n } f(n)",
+			expectedDiagnostics: []diagnostic.Diagnostic{
+				{Severity: diagnostic.Warning, Code: "shadowed-variable", Message: "Variable 'n' shadows a variable declared in an outer scope.", Span: ast.Span{Start: 16, End: 17}},
+			},
+		},
+		{
+			name:   "redeclared variable",
+			source: "let x = 1; let x = 2; x",
+			expectedDiagnostics: []diagnostic.Diagnostic{
+				{Severity: diagnostic.Error, Code: "redeclared-variable", Message: "Variable 'x' is already declared.", Span: ast.Span{Start: 11, End: 21}},
+			},
+		},
+	}
+
+	for _, test := range testcases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			program, err := parser.NewParser(test.source).Parse()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			resolution := Resolve(program)
+			assert.Equal(t, test.expectedDiagnostics, resolution.Diagnostics)
+		})
+	}
+}
+
+func Test_GivenSource_WhenResolve_ThenShouldBindIdentifiersToSlots(t *testing.T) {
+	source := "let a = 1; let b = 2; { let c = a; c = b; c }"
+	program, err := parser.NewParser(source).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resolution := Resolve(program)
+
+	a := Binding{Name: "a", Depth: 0, Slot: 0, Declaration: ast.Span{Start: 0, End: 10}}
+	b := Binding{Name: "b", Depth: 0, Slot: 1, Declaration: ast.Span{Start: 11, End: 21}}
+	c := Binding{Name: "c", Depth: 1, Slot: 0, Declaration: ast.Span{Start: 24, End: 34}}
+	assert.Equal(t, map[ast.Span]Binding{
+		{Start: 0, End: 10}:  a,
+		{Start: 11, End: 21}: b,
+		{Start: 24, End: 34}: c,
+		{Start: 32, End: 33}: a,
+		{Start: 35, End: 36}: c,
+		{Start: 39, End: 40}: b,
+		{Start: 42, End: 43}: c,
+	}, resolution.Bindings)
+	assert.Empty(t, resolution.Diagnostics)
+}
+
+func Test_GivenProgramWithoutUniqueSpans_WhenResolve_ThenShouldRejectIt(t *testing.T) {
+	type TestCase struct {
+		name               string
+		program            ast.Program
+		expectedDiagnostic diagnostic.Diagnostic
+	}
+
+	declaration := ast.VariableDeclaration{
+		Identifier: "a",
+		Value:      ast.NumericLiteralExpression{Value: 1},
+		Span:       ast.Span{Start: 0, End: 10},
+	}
+
+	testcases := []TestCase{
+		{
+			name: "spanless identifiers",
+			program: ast.Program{Statements: []ast.Statement{
+				declaration,
+				ast.ExpressionStatement{Expression: ast.IdentifierExpression{Symbol: "a"}},
+				ast.ExpressionStatement{Expression: ast.IdentifierExpression{Symbol: "b"}},
+			}},
+			expectedDiagnostic: diagnostic.Diagnostic{Severity: diagnostic.Error, Code: "invalid-span", Message: "Cannot resolve 'a' without a source span of its own."},
+		},
+		{
+			name: "shared span",
+			program: ast.Program{Statements: []ast.Statement{
+				declaration,
+				ast.ExpressionStatement{Expression: ast.IdentifierExpression{Symbol: "a", Span: ast.Span{Start: 11, End: 12}}},
+				ast.ExpressionStatement{Expression: ast.IdentifierExpression{Symbol: "b", Span: ast.Span{Start: 11, End: 12}}},
+			}},
+			expectedDiagnostic: diagnostic.Diagnostic{Severity: diagnostic.Error, Code: "invalid-span", Message: "Cannot resolve 'b' without a source span of its own.", Span: ast.Span{Start: 11, End: 12}},
+		},
+		{
+			name: "spanless declaration",
+			program: ast.Program{Statements: []ast.Statement{
+				ast.FunctionDeclaration{Name: "f", Body: ast.BlockStatement{}},
+			}},
+			expectedDiagnostic: diagnostic.Diagnostic{Severity: diagnostic.Error, Code: "invalid-span", Message: "Cannot resolve 'f' without a source span of its own."},
+		},
+	}
+
+	for _, test := range testcases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			resolution := Resolve(test.program)
+			assert.Empty(t, resolution.Bindings)
+			assert.Equal(t, []diagnostic.Diagnostic{test.expectedDiagnostic}, resolution.Diagnostics)
+		})
+	}
+}
+
+func Test_GivenGlobals_WhenResolve_ThenShouldBindThemWithoutReportingUnused(t *testing.T) {
+	program, err := parser.NewParser("print + 1").Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resolution := Resolve(program, WithGlobals("print", "unused"))
+
+	assert.Equal(t, Binding{Name: "print", Depth: 0, Slot: 0}, resolution.Bindings[ast.Span{Start: 0, End: 5}])
+	assert.Empty(t, resolution.Diagnostics)
+}
+
+func Test_GivenConformanceCase_WhenResolve_ThenShouldReportNoErrorsOrUnusedVariables(t *testing.T) {
+	names := []string{
+		"local_hoisting.adam",
+		"outer_before_shadow.adam",
+	}
+
+	for _, name := range names {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			data, err := os.ReadFile(filepath.Join("..", "conformance", "testdata", name))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			program, err := parser.NewParser(string(data)).Parse()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, d := range Resolve(program).Diagnostics {
+				assert.False(t, d.Severity == diagnostic.Error || d.Code == "unused-variable", d.String())
+			}
+		})
+	}
+}
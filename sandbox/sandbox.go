@@ -0,0 +1,131 @@
+package sandbox
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/joaovictorjs/adam-script/value"
+)
+
+type Capability string
+
+const (
+	Read  Capability = "read"
+	Write Capability = "write"
+	Env   Capability = "env"
+)
+
+// Grant allows a capability for every target when All is set, or only for
+// the listed targets otherwise. Path targets also cover everything beneath
+// them. A *Grant is a flag.Value, so "--allow-read" grants everything while
+// "--allow-read=./data,./config" grants the listed directories.
+type Grant struct {
+	All     bool
+	Targets []string
+}
+
+func (g *Grant) String() string {
+	if g == nil {
+		return ""
+	}
+	if g.All {
+		return "true"
+	}
+	return strings.Join(g.Targets, ",")
+}
+
+func (g *Grant) Set(s string) error {
+	if all, err := strconv.ParseBool(s); err == nil {
+		g.All = all
+		g.Targets = nil
+		return nil
+	}
+
+	for target := range strings.SplitSeq(s, ",") {
+		if target = strings.TrimSpace(target); target == "" {
+			return fmt.Errorf("Invalid grant '%s', expected a comma separated list of targets.", s)
+		}
+		g.Targets = append(g.Targets, target)
+	}
+	return nil
+}
+
+func (g *Grant) IsBoolFlag() bool {
+	return true
+}
+
+// Permissions is deny by default, the zero value allows nothing.
+type Permissions struct {
+	Read  Grant
+	Write Grant
+	Env   Grant
+}
+
+func AllowAll() Permissions {
+	return Permissions{Read: Grant{All: true}, Write: Grant{All: true}, Env: Grant{All: true}}
+}
+
+func (p Permissions) Check(capability Capability, target string) error {
+	var allowed bool
+	switch capability {
+	case Read:
+		allowed = p.Read.All || slices.ContainsFunc(p.Read.Targets, func(root string) bool { return within(root, target) })
+	case Write:
+		allowed = p.Write.All || slices.ContainsFunc(p.Write.Targets, func(root string) bool { return within(root, target) })
+	case Env:
+		allowed = p.Env.All || slices.Contains(p.Env.Targets, target)
+	}
+
+	if !allowed {
+		return &PermissionError{Capability: capability, Target: target}
+	}
+	return nil
+}
+
+func within(root string, target string) bool {
+	root = path.Clean(root)
+	target = path.Clean(target)
+	switch {
+	case root == "/":
+		return path.IsAbs(target)
+	case root == ".":
+		return !path.IsAbs(target) && target != ".." && !strings.HasPrefix(target, "../")
+	default:
+		return target == root || strings.HasPrefix(target, root+"/")
+	}
+}
+
+type PermissionError struct {
+	Capability Capability
+	Target     string
+}
+
+func (e *PermissionError) Error() string {
+	return fmt.Sprintf("Permission denied, requires '%s' access to '%s'.", e.Capability, e.Target)
+}
+
+func (e *PermissionError) Type() string {
+	return "error"
+}
+
+func (e *PermissionError) String() string {
+	return "PermissionError: " + e.Error()
+}
+
+func (e *PermissionError) Member(name string) (value.Value, error) {
+	switch name {
+	case "capability":
+		return value.String(e.Capability), nil
+	case "target":
+		return value.String(e.Target), nil
+	default:
+		return (&value.Error{Name: "PermissionError", Message: e.Error()}).Member(name)
+	}
+}
+
+func (e *PermissionError) SetMember(name string, v value.Value) error {
+	return (&value.Error{}).SetMember(name, v)
+}
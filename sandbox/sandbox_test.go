@@ -0,0 +1,106 @@
+package sandbox
+
+import (
+	"flag"
+	"io"
+	"testing"
+
+	"github.com/joaovictorjs/adam-script/value"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GivenPermissions_WhenCheck_ThenShouldAllowOnlyGrantedTargets(t *testing.T) {
+	type TestCase struct {
+		name        string
+		permissions Permissions
+		capability  Capability
+		target      string
+		allowed     bool
+	}
+
+	testcases := []TestCase{
+		{name: "zero value denies reads", capability: Read, target: "data/a.txt"},
+		{name: "zero value denies env", capability: Env, target: "HOME"},
+		{name: "all reads", permissions: Permissions{Read: Grant{All: true}}, capability: Read, target: "/etc/passwd", allowed: true},
+		{name: "read grant does not allow writes", permissions: Permissions{Read: Grant{All: true}}, capability: Write, target: "a.txt"},
+		{name: "file inside directory", permissions: Permissions{Read: Grant{Targets: []string{"./data"}}}, capability: Read, target: "data/a.txt", allowed: true},
+		{name: "directory itself", permissions: Permissions{Read: Grant{Targets: []string{"data/"}}}, capability: Read, target: "./data", allowed: true},
+		{name: "sibling with common prefix", permissions: Permissions{Read: Grant{Targets: []string{"data"}}}, capability: Read, target: "database/a.txt"},
+		{name: "escaping the directory", permissions: Permissions{Read: Grant{Targets: []string{"data"}}}, capability: Read, target: "data/../secret.txt"},
+		{name: "current directory", permissions: Permissions{Write: Grant{Targets: []string{"."}}}, capability: Write, target: "out/a.txt", allowed: true},
+		{name: "parent of current directory", permissions: Permissions{Write: Grant{Targets: []string{"."}}}, capability: Write, target: "../a.txt"},
+		{name: "absolute path outside current directory", permissions: Permissions{Write: Grant{Targets: []string{"."}}}, capability: Write, target: "/tmp/a.txt"},
+		{name: "root", permissions: Permissions{Read: Grant{Targets: []string{"/"}}}, capability: Read, target: "/tmp/a.txt", allowed: true},
+		{name: "env name", permissions: Permissions{Env: Grant{Targets: []string{"HOME"}}}, capability: Env, target: "HOME", allowed: true},
+		{name: "env names are exact", permissions: Permissions{Env: Grant{Targets: []string{"HOME"}}}, capability: Env, target: "HOMEPATH"},
+		{name: "allow all", permissions: AllowAll(), capability: Env, target: "SECRET", allowed: true},
+	}
+
+	for _, test := range testcases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			err := test.permissions.Check(test.capability, test.target)
+			if test.allowed {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Equal(t, &PermissionError{Capability: test.capability, Target: test.target}, err)
+		})
+	}
+}
+
+func Test_GivenFlags_WhenParse_ThenShouldFillGrants(t *testing.T) {
+	type TestCase struct {
+		name          string
+		args          []string
+		expected      Permissions
+		expectedError bool
+	}
+
+	testcases := []TestCase{
+		{name: "no flags", args: nil, expected: Permissions{}},
+		{name: "bare flag", args: []string{"--allow-write"}, expected: Permissions{Write: Grant{All: true}}},
+		{name: "list", args: []string{"--allow-read=./data,config"}, expected: Permissions{Read: Grant{Targets: []string{"./data", "config"}}}},
+		{name: "repeated", args: []string{"--allow-env=HOME", "--allow-env=USER"}, expected: Permissions{Env: Grant{Targets: []string{"HOME", "USER"}}}},
+		{name: "empty target", args: []string{"--allow-read=a,,b"}, expectedError: true},
+	}
+
+	for _, test := range testcases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			var permissions Permissions
+			flags := flag.NewFlagSet("run", flag.ContinueOnError)
+			flags.SetOutput(io.Discard)
+			flags.Var(&permissions.Read, "allow-read", "")
+			flags.Var(&permissions.Write, "allow-write", "")
+			flags.Var(&permissions.Env, "allow-env", "")
+
+			err := flags.Parse(test.args)
+			if test.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, permissions)
+		})
+	}
+}
+
+func Test_GivenPermissionError_WhenAccessMembers_ThenShouldDescribeCapability(t *testing.T) {
+	err := &PermissionError{Capability: Read, Target: "secret.txt"}
+
+	for name, expected := range map[string]value.Value{
+		"name":       value.String("PermissionError"),
+		"message":    value.String("Permission denied, requires 'read' access to 'secret.txt'."),
+		"capability": value.String("read"),
+		"target":     value.String("secret.txt"),
+	} {
+		member, memberErr := err.Member(name)
+		assert.NoError(t, memberErr)
+		assert.Equal(t, expected, member)
+	}
+
+	assert.Equal(t, err, value.FromError(err))
+	assert.Equal(t, "PermissionError: Permission denied, requires 'read' access to 'secret.txt'.", value.Inspect(err))
+	assert.EqualError(t, err.SetMember("target", value.String("x")), "Cannot assign member 'target' of a value of type 'error'.")
+}
@@ -0,0 +1,49 @@
+package stdlib
+
+import (
+	"context"
+
+	"github.com/joaovictorjs/adam-script/sandbox"
+	"github.com/joaovictorjs/adam-script/value"
+	"github.com/joaovictorjs/adam-script/vm"
+)
+
+func envModule(options Options) *Module {
+	lookup := func(name string, arguments []value.Value) (string, bool, error) {
+		if err := expectArguments(name, arguments, 1); err != nil {
+			return "", false, err
+		}
+
+		variable, err := stringArgument(name, arguments, 0)
+		if err != nil {
+			return "", false, err
+		}
+
+		if err := options.Permissions.Check(sandbox.Env, variable); err != nil {
+			return "", false, err
+		}
+
+		v, ok := options.LookupEnv(variable)
+		return v, ok, nil
+	}
+
+	return newModule("env", nil,
+		&vm.Native{
+			Name: "env.get",
+			Function: func(_ context.Context, arguments []value.Value) (value.Value, error) {
+				v, ok, err := lookup("env.get", arguments)
+				if err != nil || !ok {
+					return value.Null{}, err
+				}
+				return value.String(v), nil
+			},
+		},
+		&vm.Native{
+			Name: "env.has",
+			Function: func(_ context.Context, arguments []value.Value) (value.Value, error) {
+				_, ok, err := lookup("env.has", arguments)
+				return value.Bool(ok), err
+			},
+		},
+	)
+}
@@ -0,0 +1,95 @@
+package stdlib
+
+import (
+	"testing"
+
+	"github.com/joaovictorjs/adam-script/sandbox"
+	"github.com/joaovictorjs/adam-script/value"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GivenEnvModule_WhenCalled_ThenShouldRespectPermissions(t *testing.T) {
+	type TestCase struct {
+		name          string
+		permissions   sandbox.Permissions
+		source        string
+		expected      value.Value
+		expectedError string
+	}
+
+	variables := map[string]string{"HOME": "/home/adam", "TOKEN": "secret"}
+	lookup := func(name string) (string, bool) {
+		v, ok := variables[name]
+		return v, ok
+	}
+
+	testcases := []TestCase{
+		{
+			name:        "granted variable",
+			permissions: sandbox.Permissions{Env: sandbox.Grant{Targets: []string{"HOME"}}},
+			source:      `env.get("HOME")`,
+			expected:    value.String("/home/adam"),
+		},
+		{
+			name:        "missing variable",
+			permissions: sandbox.Permissions{Env: sandbox.Grant{All: true}},
+			source:      `env.get("SHELL")`,
+			expected:    value.Null{},
+		},
+		{
+			name:        "has",
+			permissions: sandbox.Permissions{Env: sandbox.Grant{All: true}},
+			source:      `env.has("TOKEN")`,
+			expected:    value.Bool(true),
+		},
+		{
+			name:          "denied by default",
+			source:        `env.get("HOME")`,
+			expectedError: "Permission denied, requires 'env' access to 'HOME'.",
+		},
+		{
+			name:          "denied variable",
+			permissions:   sandbox.Permissions{Env: sandbox.Grant{Targets: []string{"HOME"}}},
+			source:        `env.has("TOKEN")`,
+			expectedError: "Permission denied, requires 'env' access to 'TOKEN'.",
+		},
+		{
+			name:     "caught permission error",
+			source:   `try { env.get("TOKEN") } catch (e) { e.name + " " + e.capability + " " + e.target }`,
+			expected: value.String("PermissionError env TOKEN"),
+		},
+		{
+			name:          "wrong argument count",
+			source:        `env.get()`,
+			expectedError: "Function 'env.get' expects 1 arguments but got 0.",
+		},
+		{
+			name:          "wrong argument type",
+			source:        `env.get(1)`,
+			expectedError: "Function 'env.get' expects argument 1 to be of type 'string' but got 'number'.",
+		},
+		{
+			name:          "unknown member",
+			source:        `env.set`,
+			expectedError: "Module 'env' has no member 'set'.",
+		},
+		{
+			name:          "modules are read-only",
+			source:        `env.get = 1`,
+			expectedError: "Cannot assign member 'get' of module 'env'.",
+		},
+	}
+
+	for _, test := range testcases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			result, err := run(t, Options{Permissions: test.permissions, LookupEnv: lookup}, test.source)
+			if test.expectedError != "" {
+				assert.EqualError(t, err, test.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, result)
+		})
+	}
+}
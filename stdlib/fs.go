@@ -0,0 +1,390 @@
+package stdlib
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/joaovictorjs/adam-script/sandbox"
+	"github.com/joaovictorjs/adam-script/value"
+	"github.com/joaovictorjs/adam-script/vm"
+)
+
+var (
+	errNoFileSystem = errors.New("no file system is available")
+	errReadOnly     = errors.New("the file system is read-only")
+	errTooLarge     = fmt.Errorf("the file is larger than %d bytes", maxStringLength)
+	errOutsideRoot  = errors.New("a symbolic link leads outside of the file system")
+	errTooManyLinks = errors.New("too many levels of symbolic links")
+)
+
+// maxLinks bounds the symbolic links resolve follows for one path, like the
+// loop detection of the operating system.
+const maxLinks = 40
+
+// WritableFS is a file system fs.writeFile, fs.mkdir and fs.remove can
+// change, hosts that hand the fs module a plain fs.FS get read-only access.
+type WritableFS interface {
+	fs.FS
+	WriteFile(name string, data []byte, perm fs.FileMode) error
+	MkdirAll(name string, perm fs.FileMode) error
+	Remove(name string) error
+}
+
+// DirFS opens dir as a WritableFS. Unlike os.DirFS, symbolic links cannot
+// lead scripts outside of it, and the fs module checks grants against the
+// files they point to.
+func DirFS(dir string) (WritableFS, error) {
+	root, err := os.OpenRoot(dir)
+	if err != nil {
+		return nil, err
+	}
+	return rootFS{FS: root.FS(), root: root}, nil
+}
+
+type rootFS struct {
+	fs.FS
+	root *os.Root
+}
+
+func (r rootFS) ReadLink(name string) (string, error) {
+	return r.root.Readlink(name)
+}
+
+func (r rootFS) Lstat(name string) (fs.FileInfo, error) {
+	return r.root.Lstat(name)
+}
+
+func (r rootFS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	return r.root.WriteFile(name, data, perm)
+}
+
+func (r rootFS) MkdirAll(name string, perm fs.FileMode) error {
+	return r.root.MkdirAll(name, perm)
+}
+
+func (r rootFS) Remove(name string) error {
+	return r.root.Remove(name)
+}
+
+// FileError is raised by the fs module when an operation on Path fails, it
+// is a script value so catch clauses can read the path back.
+type FileError struct {
+	Operation string
+	Path      string
+	Err       error
+}
+
+func (e *FileError) Error() string {
+	return fmt.Sprintf("Cannot %s '%s', %s.", e.Operation, e.Path, e.Err)
+}
+
+func (e *FileError) Unwrap() error {
+	return e.Err
+}
+
+func (e *FileError) Type() string {
+	return "error"
+}
+
+func (e *FileError) String() string {
+	return "FileError: " + e.Error()
+}
+
+func (e *FileError) Member(name string) (value.Value, error) {
+	if name == "path" {
+		return value.String(e.Path), nil
+	}
+	return (&value.Error{Name: "FileError", Message: e.Error()}).Member(name)
+}
+
+func (e *FileError) SetMember(name string, v value.Value) error {
+	return (&value.Error{}).SetMember(name, v)
+}
+
+// fsModule resolves every path against options.FS, so scripts name files
+// with slash separated paths relative to its root and never reach the host
+// file system directly.
+func fsModule(options Options) *Module {
+	// file validates the path argument of a call and checks it against the
+	// capability the call needs before it touches the file system. It
+	// returns the cleaned path for errors and the target with symbolic links
+	// resolved, which the grant also has to cover and the call operates on.
+	file := func(name string, arguments []value.Value, count int, capability sandbox.Capability, follow bool) (string, string, error) {
+		if err := expectArguments(name, arguments, count); err != nil {
+			return "", "", err
+		}
+
+		p, err := stringArgument(name, arguments, 0)
+		if err != nil {
+			return "", "", err
+		}
+
+		cleaned := path.Clean(p)
+		if !fs.ValidPath(cleaned) {
+			return "", "", fmt.Errorf("Function '%s' expects a relative path inside the file system but got '%s'.", name, p)
+		}
+		if err := options.Permissions.Check(capability, cleaned); err != nil {
+			return "", "", err
+		}
+		if options.FS == nil {
+			return "", "", &FileError{Operation: "access", Path: cleaned, Err: errNoFileSystem}
+		}
+
+		target, err := resolve(options.FS, cleaned, follow)
+		if err != nil {
+			return "", "", fileError("access", cleaned, err)
+		}
+		if err := options.Permissions.Check(capability, target); err != nil {
+			return "", "", err
+		}
+		return cleaned, target, nil
+	}
+
+	writable := func(operation string, p string) (WritableFS, error) {
+		fsys, ok := options.FS.(WritableFS)
+		if !ok {
+			return nil, &FileError{Operation: operation, Path: p, Err: errReadOnly}
+		}
+		return fsys, nil
+	}
+
+	return newModule("fs", nil,
+		&vm.Native{
+			Name: "fs.readFile",
+			Function: func(_ context.Context, arguments []value.Value) (value.Value, error) {
+				p, target, err := file("fs.readFile", arguments, 1, sandbox.Read, true)
+				if err != nil {
+					return nil, err
+				}
+
+				info, err := fs.Stat(options.FS, target)
+				if err != nil {
+					return nil, fileError("read file", p, err)
+				}
+				if info.Size() > maxStringLength {
+					return nil, &FileError{Operation: "read file", Path: p, Err: errTooLarge}
+				}
+
+				data, err := fs.ReadFile(options.FS, target)
+				if err != nil {
+					return nil, fileError("read file", p, err)
+				}
+				return value.String(data), nil
+			},
+		},
+		&vm.Native{
+			Name: "fs.writeFile",
+			Function: func(_ context.Context, arguments []value.Value) (value.Value, error) {
+				p, target, err := file("fs.writeFile", arguments, 2, sandbox.Write, true)
+				if err != nil {
+					return nil, err
+				}
+				text, err := stringArgument("fs.writeFile", arguments, 1)
+				if err != nil {
+					return nil, err
+				}
+
+				fsys, err := writable("write file", p)
+				if err != nil {
+					return nil, err
+				}
+				if err := fsys.WriteFile(target, []byte(text), 0o644); err != nil {
+					return nil, fileError("write file", p, err)
+				}
+				return value.Null{}, nil
+			},
+		},
+		&vm.Native{
+			Name: "fs.readDir",
+			Function: func(_ context.Context, arguments []value.Value) (value.Value, error) {
+				p, target, err := file("fs.readDir", arguments, 1, sandbox.Read, true)
+				if err != nil {
+					return nil, err
+				}
+
+				entries, err := fs.ReadDir(options.FS, target)
+				if err != nil {
+					return nil, fileError("read directory", p, err)
+				}
+
+				names := make(value.Array, 0, len(entries))
+				for _, entry := range entries {
+					names = append(names, value.String(entry.Name()))
+				}
+				return names, nil
+			},
+		},
+		&vm.Native{
+			Name: "fs.exists",
+			Function: func(_ context.Context, arguments []value.Value) (value.Value, error) {
+				p, target, err := file("fs.exists", arguments, 1, sandbox.Read, true)
+				if err != nil {
+					return nil, err
+				}
+
+				_, err = fs.Stat(options.FS, target)
+				if errors.Is(err, fs.ErrNotExist) {
+					return value.Bool(false), nil
+				}
+				if err != nil {
+					return nil, fileError("stat", p, err)
+				}
+				return value.Bool(true), nil
+			},
+		},
+		&vm.Native{
+			Name: "fs.stat",
+			Function: func(_ context.Context, arguments []value.Value) (value.Value, error) {
+				p, target, err := file("fs.stat", arguments, 1, sandbox.Read, true)
+				if err != nil {
+					return nil, err
+				}
+
+				info, err := fs.Stat(options.FS, target)
+				if err != nil {
+					return nil, fileError("stat", p, err)
+				}
+				return value.Map{
+					"name":     value.String(info.Name()),
+					"size":     value.Number(info.Size()),
+					"isDir":    value.Bool(info.IsDir()),
+					"modified": value.Number(info.ModTime().UnixMilli()),
+				}, nil
+			},
+		},
+		&vm.Native{
+			Name: "fs.mkdir",
+			Function: func(_ context.Context, arguments []value.Value) (value.Value, error) {
+				p, target, err := file("fs.mkdir", arguments, 1, sandbox.Write, true)
+				if err != nil {
+					return nil, err
+				}
+
+				fsys, err := writable("create directory", p)
+				if err != nil {
+					return nil, err
+				}
+				if err := fsys.MkdirAll(target, 0o755); err != nil {
+					return nil, fileError("create directory", p, err)
+				}
+				return value.Null{}, nil
+			},
+		},
+		&vm.Native{
+			Name: "fs.remove",
+			Function: func(_ context.Context, arguments []value.Value) (value.Value, error) {
+				p, target, err := file("fs.remove", arguments, 1, sandbox.Write, false)
+				if err != nil {
+					return nil, err
+				}
+
+				fsys, err := writable("remove", p)
+				if err != nil {
+					return nil, err
+				}
+				if err := fsys.Remove(target); err != nil {
+					return nil, fileError("remove", p, err)
+				}
+				return value.Null{}, nil
+			},
+		},
+	)
+}
+
+// resolve follows the symbolic links along name, so grants are checked
+// against the file an operation reaches rather than the path that names it.
+// Missing components are kept as they are and, unless follow is set, a link
+// in the last component is the target itself.
+func resolve(fsys fs.FS, name string, follow bool) (string, error) {
+	if _, ok := fsys.(fs.ReadLinkFS); !ok {
+		return name, nil
+	}
+
+	resolved := "."
+	remaining := strings.Split(name, "/")
+	links := 0
+	for len(remaining) > 0 {
+		current := path.Join(resolved, remaining[0])
+		remaining = remaining[1:]
+		if len(remaining) == 0 && !follow {
+			return current, nil
+		}
+
+		info, err := fs.Lstat(fsys, current)
+		if errors.Is(err, fs.ErrNotExist) {
+			return path.Join(append([]string{current}, remaining...)...), nil
+		}
+		if err != nil {
+			return "", err
+		}
+		if info.Mode()&fs.ModeSymlink == 0 {
+			resolved = current
+			continue
+		}
+
+		if links++; links > maxLinks {
+			return "", errTooManyLinks
+		}
+		link, err := fs.ReadLink(fsys, current)
+		if err != nil {
+			return "", err
+		}
+		if path.IsAbs(link) {
+			return "", errOutsideRoot
+		}
+		link = path.Join(resolved, link)
+		if !fs.ValidPath(link) {
+			return "", errOutsideRoot
+		}
+		remaining = append(strings.Split(link, "/"), remaining...)
+		resolved = "."
+	}
+	return resolved, nil
+}
+
+// fileError keeps the reason of a *fs.PathError but reports the path the
+// script asked for rather than the name the file system saw.
+func fileError(operation string, p string, err error) *FileError {
+	var pathError *fs.PathError
+	if errors.As(err, &pathError) {
+		err = pathError.Err
+	}
+	return &FileError{Operation: operation, Path: p, Err: err}
+}
+
+func pathModule() *Module {
+	return newModule("path", nil,
+		&vm.Native{
+			Name: "path.join",
+			Function: func(_ context.Context, arguments []value.Value) (value.Value, error) {
+				if err := expectAtLeast("path.join", arguments, 1); err != nil {
+					return nil, err
+				}
+
+				parts := make([]string, 0, len(arguments))
+				for index := range arguments {
+					part, err := stringArgument("path.join", arguments, index)
+					if err != nil {
+						return nil, err
+					}
+					parts = append(parts, part)
+				}
+				return value.String(path.Join(parts...)), nil
+			},
+		},
+		stringFunction("path.base", 1, func(s []string) (value.Value, error) {
+			return value.String(path.Base(s[0])), nil
+		}),
+		stringFunction("path.dir", 1, func(s []string) (value.Value, error) {
+			return value.String(path.Dir(s[0])), nil
+		}),
+		stringFunction("path.ext", 1, func(s []string) (value.Value, error) {
+			return value.String(path.Ext(s[0])), nil
+		}),
+	)
+}
@@ -0,0 +1,273 @@
+package stdlib
+
+import (
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/joaovictorjs/adam-script/sandbox"
+	"github.com/joaovictorjs/adam-script/value"
+	"github.com/stretchr/testify/assert"
+)
+
+type memoryFS struct {
+	fstest.MapFS
+}
+
+func (m memoryFS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	if info, err := fs.Stat(m, path.Dir(name)); err != nil || !info.IsDir() {
+		return &fs.PathError{Op: "write", Path: name, Err: fs.ErrNotExist}
+	}
+	m.MapFS[name] = &fstest.MapFile{Data: data, Mode: perm}
+	return nil
+}
+
+func (m memoryFS) MkdirAll(name string, perm fs.FileMode) error {
+	m.MapFS[name] = &fstest.MapFile{Mode: fs.ModeDir | perm}
+	return nil
+}
+
+func (m memoryFS) Remove(name string) error {
+	if _, ok := m.MapFS[name]; !ok {
+		return &fs.PathError{Op: "remove", Path: name, Err: fs.ErrNotExist}
+	}
+	for file := range m.MapFS {
+		if strings.HasPrefix(file, name+"/") {
+			return &fs.PathError{Op: "remove", Path: name, Err: fs.ErrExist}
+		}
+	}
+	delete(m.MapFS, name)
+	return nil
+}
+
+func newMemoryFS() memoryFS {
+	return memoryFS{fstest.MapFS{
+		"data/users.json": {Data: []byte(`[{"name": "ada"}]`)},
+		"data/notes.txt":  {Data: []byte("héllo")},
+	}}
+}
+
+func Test_GivenFSModule_WhenCalled_ThenShouldReturnCorrectValue(t *testing.T) {
+	type TestCase struct {
+		name     string
+		source   string
+		expected value.Value
+	}
+
+	testcases := []TestCase{
+		{name: "read file", source: `fs.readFile("./data/notes.txt")`, expected: value.String("héllo")},
+		{name: "read directory", source: `fs.readDir("data")`, expected: value.Array{value.String("notes.txt"), value.String("users.json")}},
+		{name: "existing file", source: `fs.exists("data/users.json")`, expected: value.Bool(true)},
+		{name: "missing file", source: `fs.exists("data/missing.json")`, expected: value.Bool(false)},
+		{name: "stat size", source: `fs.stat("data/notes.txt").size`, expected: value.Number(6)},
+		{name: "stat directory", source: `fs.stat("data").isDir`, expected: value.Bool(true)},
+		{name: "write then read", source: `fs.writeFile("data/out.txt", "x"); fs.readFile("data/out.txt")`, expected: value.String("x")},
+		{name: "mkdir then write", source: `fs.mkdir("logs"); fs.writeFile("logs/a.txt", ""); fs.readDir("logs")`, expected: value.Array{value.String("a.txt")}},
+		{name: "remove", source: `fs.remove("data/notes.txt"); fs.exists("data/notes.txt")`, expected: value.Bool(false)},
+		{name: "catch path", source: `try { fs.readFile("data/missing.json") } catch (e) { e.path }`, expected: value.String("data/missing.json")},
+		{name: "catch name", source: `try { fs.readFile("data") } catch (e) { e.name }`, expected: value.String("FileError")},
+	}
+
+	for _, test := range testcases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			result, err := run(t, Options{Permissions: sandbox.AllowAll(), FS: newMemoryFS()}, test.source)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, result)
+		})
+	}
+}
+
+func Test_GivenFSModule_WhenMisused_ThenShouldReturnCorrectError(t *testing.T) {
+	readData := sandbox.Permissions{Read: sandbox.Grant{Targets: []string{"data"}}}
+
+	type TestCase struct {
+		name          string
+		options       Options
+		source        string
+		expectedError string
+	}
+
+	testcases := []TestCase{
+		{
+			name:          "missing file",
+			options:       Options{Permissions: readData, FS: newMemoryFS()},
+			source:        `fs.readFile("data/missing.json")`,
+			expectedError: "Cannot read file 'data/missing.json', file does not exist.",
+		},
+		{
+			name:          "read outside grant",
+			options:       Options{Permissions: readData, FS: newMemoryFS()},
+			source:        `fs.readFile("secrets.txt")`,
+			expectedError: "Permission denied, requires 'read' access to 'secrets.txt'.",
+		},
+		{
+			name:          "write without grant",
+			options:       Options{Permissions: readData, FS: newMemoryFS()},
+			source:        `fs.writeFile("data/out.txt", "x")`,
+			expectedError: "Permission denied, requires 'write' access to 'data/out.txt'.",
+		},
+		{
+			name:          "absolute path",
+			options:       Options{Permissions: sandbox.AllowAll(), FS: newMemoryFS()},
+			source:        `fs.readFile("/etc/passwd")`,
+			expectedError: "Function 'fs.readFile' expects a relative path inside the file system but got '/etc/passwd'.",
+		},
+		{
+			name:          "escaping path",
+			options:       Options{Permissions: sandbox.AllowAll(), FS: newMemoryFS()},
+			source:        `fs.readFile("data/../../x")`,
+			expectedError: "Function 'fs.readFile' expects a relative path inside the file system but got 'data/../../x'.",
+		},
+		{
+			name:          "read-only file system",
+			options:       Options{Permissions: sandbox.AllowAll(), FS: newMemoryFS().MapFS},
+			source:        `fs.writeFile("data/out.txt", "x")`,
+			expectedError: "Cannot write file 'data/out.txt', the file system is read-only.",
+		},
+		{
+			name:          "no file system",
+			options:       Options{Permissions: sandbox.AllowAll()},
+			source:        `fs.exists("data")`,
+			expectedError: "Cannot access 'data', no file system is available.",
+		},
+		{
+			name:          "write into missing directory",
+			options:       Options{Permissions: sandbox.AllowAll(), FS: newMemoryFS()},
+			source:        `fs.writeFile("logs/a.txt", "x")`,
+			expectedError: "Cannot write file 'logs/a.txt', file does not exist.",
+		},
+		{
+			name:          "non-string content",
+			options:       Options{Permissions: sandbox.AllowAll(), FS: newMemoryFS()},
+			source:        `fs.writeFile("data/out.txt", 1)`,
+			expectedError: "Function 'fs.writeFile' expects argument 2 to be of type 'string' but got 'number'.",
+		},
+	}
+
+	for _, test := range testcases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := run(t, test.options, test.source)
+			assert.EqualError(t, err, test.expectedError)
+		})
+	}
+}
+
+func Test_GivenDirFS_WhenCalled_ThenShouldChangeDirectory(t *testing.T) {
+	fsys, err := DirFS(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = run(t, Options{Permissions: sandbox.AllowAll(), FS: fsys}, `fs.mkdir("a/b"); fs.writeFile("a/b/c.txt", "x"); fs.readFile("a/b/c.txt")`)
+	assert.NoError(t, err)
+
+	_, err = run(t, Options{Permissions: sandbox.AllowAll(), FS: fsys}, `fs.remove("a")`)
+	var fileError *FileError
+	assert.ErrorAs(t, err, &fileError)
+	assert.Equal(t, "a", fileError.Path)
+}
+
+func Test_GivenDirFSWithSymbolicLinks_WhenCalled_ThenShouldCheckGrantsOnTargets(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"data", "secret"} {
+		if err := os.Mkdir(filepath.Join(dir, name), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	files := map[string]string{"secret/key": "TOPSECRET", "data/notes.txt": "notes"}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	links := map[string]string{
+		"data/link":     "../secret/key",
+		"data/secrets":  "../secret",
+		"data/alias":    "notes.txt",
+		"data/absolute": filepath.Join(dir, "secret", "key"),
+		"data/outside":  "../../outside",
+		"data/loop":     "loop",
+	}
+	for name, target := range links {
+		if err := os.Symlink(target, filepath.Join(dir, name)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	fsys, err := DirFS(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dataOnly := sandbox.Permissions{
+		Read:  sandbox.Grant{Targets: []string{"data"}},
+		Write: sandbox.Grant{Targets: []string{"data"}},
+	}
+
+	type TestCase struct {
+		name          string
+		source        string
+		expected      value.Value
+		expectedError string
+	}
+
+	testcases := []TestCase{
+		{name: "link inside grant", source: `fs.readFile("data/alias")`, expected: value.String("notes")},
+		{name: "read through file link", source: `fs.readFile("data/link")`, expectedError: "Permission denied, requires 'read' access to 'secret/key'."},
+		{name: "read through directory link", source: `fs.readFile("data/secrets/key")`, expectedError: "Permission denied, requires 'read' access to 'secret/key'."},
+		{name: "stat through link", source: `fs.exists("data/link")`, expectedError: "Permission denied, requires 'read' access to 'secret/key'."},
+		{name: "write through directory link", source: `fs.writeFile("data/secrets/new.txt", "x")`, expectedError: "Permission denied, requires 'write' access to 'secret/new.txt'."},
+		{name: "absolute link", source: `fs.readFile("data/absolute")`, expectedError: "Cannot access 'data/absolute', a symbolic link leads outside of the file system."},
+		{name: "link above root", source: `fs.readFile("data/outside")`, expectedError: "Cannot access 'data/outside', a symbolic link leads outside of the file system."},
+		{name: "link loop", source: `fs.readFile("data/loop")`, expectedError: "Cannot access 'data/loop', too many levels of symbolic links."},
+		{name: "remove link only", source: `fs.remove("data/link"); fs.exists("data/link")`, expected: value.Bool(false)},
+	}
+
+	for _, test := range testcases {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := run(t, Options{Permissions: dataOnly, FS: fsys}, test.source)
+			if test.expectedError != "" {
+				assert.EqualError(t, err, test.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, result)
+		})
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "secret", "key"))
+	assert.NoError(t, err)
+	assert.Equal(t, "TOPSECRET", string(data))
+	assert.NoFileExists(t, filepath.Join(dir, "secret", "new.txt"))
+}
+
+func Test_GivenPathModule_WhenCalled_ThenShouldReturnCorrectValue(t *testing.T) {
+	type TestCase struct {
+		name     string
+		source   string
+		expected value.Value
+	}
+
+	testcases := []TestCase{
+		{name: "join", source: `path.join("data", "../logs", "a.txt")`, expected: value.String("logs/a.txt")},
+		{name: "join one", source: `path.join("./data/")`, expected: value.String("data")},
+		{name: "base", source: `path.base("data/users.json")`, expected: value.String("users.json")},
+		{name: "dir", source: `path.dir("data/users.json")`, expected: value.String("data")},
+		{name: "ext", source: `path.ext("data/users.json")`, expected: value.String(".json")},
+		{name: "ext without extension", source: `path.ext("Makefile")`, expected: value.String("")},
+	}
+
+	for _, test := range testcases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			result, err := run(t, Options{}, test.source)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, result)
+		})
+	}
+}
@@ -0,0 +1,206 @@
+package stdlib
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/joaovictorjs/adam-script/diagnostic"
+	"github.com/joaovictorjs/adam-script/value"
+	"github.com/joaovictorjs/adam-script/vm"
+)
+
+// maxIndent bounds the indentation of json.stringify, as in JavaScript.
+const maxIndent = 10
+
+// unexpectedEnd is the message of the syntax error encoding/json reports
+// when the input stops early, its offset is the end of the input instead of
+// one past the offending byte.
+const unexpectedEnd = "unexpected end of JSON input"
+
+func jsonModule() *Module {
+	return newModule("json", nil,
+		&vm.Native{Name: "json.parse", Function: parseJSON},
+		&vm.Native{Name: "json.stringify", Function: stringify},
+	)
+}
+
+func parseJSON(_ context.Context, arguments []value.Value) (value.Value, error) {
+	if err := expectArguments("json.parse", arguments, 1); err != nil {
+		return nil, err
+	}
+
+	text, err := stringArgument("json.parse", arguments, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	var decoded any
+	if err := json.Unmarshal([]byte(text), &decoded); err != nil {
+		var syntaxError *json.SyntaxError
+		if !errors.As(err, &syntaxError) {
+			return nil, err
+		}
+
+		offset := int(syntaxError.Offset)
+		if syntaxError.Error() != unexpectedEnd {
+			offset--
+		}
+		line, column := diagnostic.Position(text, offset)
+		return nil, fmt.Errorf("Function 'json.parse' found invalid JSON at line %d, column %d: %s.", line, column, syntaxError)
+	}
+	return fromJSON(decoded), nil
+}
+
+func fromJSON(decoded any) value.Value {
+	switch decoded := decoded.(type) {
+	case map[string]any:
+		object := make(value.Map, len(decoded))
+		for key, element := range decoded {
+			object[key] = fromJSON(element)
+		}
+		return object
+	case []any:
+		array := make(value.Array, 0, len(decoded))
+		for _, element := range decoded {
+			array = append(array, fromJSON(element))
+		}
+		return array
+	case float64:
+		return value.Number(decoded)
+	case string:
+		return value.String(decoded)
+	case bool:
+		return value.Bool(decoded)
+	default:
+		return value.Null{}
+	}
+}
+
+func stringify(_ context.Context, arguments []value.Value) (value.Value, error) {
+	if err := expectBetween("json.stringify", arguments, 1, 2); err != nil {
+		return nil, err
+	}
+
+	indent := ""
+	if len(arguments) == 2 {
+		var err error
+		if indent, err = indentOption(arguments[1]); err != nil {
+			return nil, err
+		}
+	}
+
+	encodable, err := toJSON(arguments[0], map[uintptr]bool{})
+	if err != nil {
+		return nil, err
+	}
+
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", indent)
+	if err := encoder.Encode(encodable); err != nil {
+		return nil, err
+	}
+	return value.String(strings.TrimSuffix(buffer.String(), "\n")), nil
+}
+
+func indentOption(options value.Value) (string, error) {
+	object, ok := options.(value.Map)
+	if !ok {
+		return "", fmt.Errorf("Function 'json.stringify' expects argument 2 to be of type 'map' but got '%s'.", options.Type())
+	}
+
+	indent := ""
+	for key, option := range object {
+		if key != "indent" {
+			return "", fmt.Errorf("Function 'json.stringify' has no option '%s'.", key)
+		}
+
+		switch option := option.(type) {
+		case value.Number:
+			n := float64(option)
+			if n != math.Trunc(n) || n < 0 || n > maxIndent {
+				return "", fmt.Errorf("Function 'json.stringify' expects option 'indent' to be an integer from 0 to %d but got %s.", maxIndent, option)
+			}
+			indent = strings.Repeat(" ", int(n))
+		case value.String:
+			if len([]rune(option)) > maxIndent {
+				return "", fmt.Errorf("Function 'json.stringify' expects option 'indent' to be at most %d characters long.", maxIndent)
+			}
+			indent = string(option)
+		default:
+			return "", fmt.Errorf("Function 'json.stringify' expects option 'indent' to be of type 'number' or 'string' but got '%s'.", option.Type())
+		}
+	}
+	return indent, nil
+}
+
+// toJSON converts a script value into one encoding/json can encode. Active
+// tracks the maps and arrays on the current path, so a value shared between
+// siblings is encoded twice while a value containing itself is an error.
+func toJSON(v value.Value, active map[uintptr]bool) (any, error) {
+	switch v := v.(type) {
+	case value.Map:
+		leave, err := enter(v, active)
+		if err != nil {
+			return nil, err
+		}
+		defer leave()
+
+		object := make(map[string]any, len(v))
+		for key, element := range v {
+			encodable, err := toJSON(element, active)
+			if err != nil {
+				return nil, err
+			}
+			object[key] = encodable
+		}
+		return object, nil
+	case value.Array:
+		leave, err := enter(v, active)
+		if err != nil {
+			return nil, err
+		}
+		defer leave()
+
+		array := make([]any, 0, len(v))
+		for _, element := range v {
+			encodable, err := toJSON(element, active)
+			if err != nil {
+				return nil, err
+			}
+			array = append(array, encodable)
+		}
+		return array, nil
+	case value.Number:
+		if math.IsNaN(float64(v)) || math.IsInf(float64(v), 0) {
+			return nil, fmt.Errorf("Function 'json.stringify' cannot serialize the number %s.", v)
+		}
+		return float64(v), nil
+	case value.String:
+		return string(v), nil
+	case value.Bool:
+		return bool(v), nil
+	case value.Null:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("Function 'json.stringify' cannot serialize a value of type '%s'.", v.Type())
+	}
+}
+
+func enter(v value.Value, active map[uintptr]bool) (func(), error) {
+	id, ok := value.Identity(v)
+	if !ok {
+		return func() {}, nil
+	}
+	if active[id] {
+		return nil, fmt.Errorf("Function 'json.stringify' cannot serialize a cyclic structure.")
+	}
+	active[id] = true
+	return func() { delete(active, id) }, nil
+}
@@ -0,0 +1,76 @@
+package stdlib
+
+import (
+	"testing"
+
+	"github.com/joaovictorjs/adam-script/value"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GivenJSONModule_WhenCalled_ThenShouldReturnCorrectValue(t *testing.T) {
+	type TestCase struct {
+		name     string
+		source   string
+		expected value.Value
+	}
+
+	testcases := []TestCase{
+		{
+			name:   "parse object",
+			source: `json.parse("{\"a\": [1, true, null], \"b\": {\"c\": \"d\"}}")`,
+			expected: value.Map{
+				"a": value.Array{value.Number(1), value.Bool(true), value.Null{}},
+				"b": value.Map{"c": value.String("d")},
+			},
+		},
+		{name: "parse number", source: `json.parse(" 1.5e3 ")`, expected: value.Number(1500)},
+		{name: "parse escaped string", source: `json.parse("\"caf\\u00e9\"")`, expected: value.String("café")},
+		{name: "parse member", source: `json.parse("{\"user\": {\"name\": \"ada\"}}").user.name`, expected: value.String("ada")},
+		{name: "stringify object with sorted keys", source: `json.stringify({b: 1, a: "x<y", c: {}})`, expected: value.String(`{"a":"x<y","b":1,"c":{}}`)},
+		{name: "stringify with numeric indent", source: `json.stringify({a: {b: 1}}, {indent: 2})`, expected: value.String("{\n  \"a\": {\n    \"b\": 1\n  }\n}")},
+		{name: "stringify with string indent", source: `json.stringify({a: 1}, {indent: "\t"})`, expected: value.String("{\n\t\"a\": 1\n}")},
+		{name: "stringify array", source: `json.stringify(strings.split("a,b", ","))`, expected: value.String(`["a","b"]`)},
+		{name: "stringify shared value", source: `let s = {a: 1}; json.stringify({x: s, y: s})`, expected: value.String(`{"x":{"a":1},"y":{"a":1}}`)},
+		{name: "round trip", source: `json.stringify(json.parse("[1, \"two\", false, null, {\"three\": 3}]"))`, expected: value.String(`[1,"two",false,null,{"three":3}]`)},
+	}
+
+	for _, test := range testcases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			result, err := run(t, Options{}, test.source)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, result)
+		})
+	}
+}
+
+func Test_GivenJSONModule_WhenMisused_ThenShouldReturnCorrectError(t *testing.T) {
+	type TestCase struct {
+		name          string
+		source        string
+		expectedError string
+	}
+
+	testcases := []TestCase{
+		{name: "invalid character", source: `json.parse("{\"a\": 1,\n  }")`, expectedError: "Function 'json.parse' found invalid JSON at line 2, column 3: invalid character '}' looking for beginning of object key string."},
+		{name: "unexpected end", source: `json.parse("[1,\n2")`, expectedError: "Function 'json.parse' found invalid JSON at line 2, column 2: unexpected end of JSON input."},
+		{name: "trailing value", source: `json.parse("1 2")`, expectedError: "Function 'json.parse' found invalid JSON at line 1, column 3: invalid character '2' after top-level value."},
+		{name: "parse non-string", source: `json.parse(1)`, expectedError: "Function 'json.parse' expects argument 1 to be of type 'string' but got 'number'."},
+		{name: "cyclic map", source: `let o = {}; o.self = {parent: o}; json.stringify(o)`, expectedError: "Function 'json.stringify' cannot serialize a cyclic structure."},
+		{name: "not a number", source: `json.stringify({n: math.NaN})`, expectedError: "Function 'json.stringify' cannot serialize the number NaN."},
+		{name: "function", source: `json.stringify({f: strings.len})`, expectedError: "Function 'json.stringify' cannot serialize a value of type 'function'."},
+		{name: "unknown option", source: `json.stringify(1, {spaces: 2})`, expectedError: "Function 'json.stringify' has no option 'spaces'."},
+		{name: "options of wrong type", source: `json.stringify(1, 2)`, expectedError: "Function 'json.stringify' expects argument 2 to be of type 'map' but got 'number'."},
+		{name: "fractional indent", source: `json.stringify(1, {indent: 3 / 2})`, expectedError: "Function 'json.stringify' expects option 'indent' to be an integer from 0 to 10 but got 1.5."},
+		{name: "long indent", source: `json.stringify(1, {indent: "           "})`, expectedError: "Function 'json.stringify' expects option 'indent' to be at most 10 characters long."},
+		{name: "missing value", source: `json.stringify()`, expectedError: "Function 'json.stringify' expects 1 to 2 arguments but got 0."},
+	}
+
+	for _, test := range testcases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := run(t, Options{}, test.source)
+			assert.EqualError(t, err, test.expectedError)
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package stdlib
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand/v2"
+
+	"github.com/joaovictorjs/adam-script/value"
+	"github.com/joaovictorjs/adam-script/vm"
+)
+
+func mathModule(options Options) *Module {
+	generator := rand.New(options.Random)
+
+	constants := map[string]value.Value{
+		"PI":  value.Number(math.Pi),
+		"E":   value.Number(math.E),
+		"Inf": value.Number(math.Inf(1)),
+		"NaN": value.Number(math.NaN()),
+	}
+
+	natives := []*vm.Native{
+		unary("math.abs", math.Abs),
+		unary("math.floor", math.Floor),
+		unary("math.ceil", math.Ceil),
+		unary("math.round", math.Round),
+		unary("math.trunc", math.Trunc),
+		unary("math.sqrt", math.Sqrt),
+		unary("math.exp", math.Exp),
+		unary("math.log", math.Log),
+		unary("math.log2", math.Log2),
+		unary("math.log10", math.Log10),
+		unary("math.sin", math.Sin),
+		unary("math.cos", math.Cos),
+		unary("math.tan", math.Tan),
+		unary("math.asin", math.Asin),
+		unary("math.acos", math.Acos),
+		unary("math.atan", math.Atan),
+		binary("math.pow", math.Pow),
+		binary("math.atan2", math.Atan2),
+		extremum("math.min", math.Min),
+		extremum("math.max", math.Max),
+		{
+			Name: "math.clamp",
+			Function: func(_ context.Context, arguments []value.Value) (value.Value, error) {
+				numbers, err := numberArguments("math.clamp", arguments, 3)
+				if err != nil {
+					return nil, err
+				}
+
+				x, low, high := numbers[0], numbers[1], numbers[2]
+				if low > high {
+					return nil, fmt.Errorf("Function 'math.clamp' expects the lower bound %s to be at most the upper bound %s.", value.Number(low), value.Number(high))
+				}
+				return value.Number(math.Min(math.Max(x, low), high)), nil
+			},
+		},
+		{
+			Name: "math.random",
+			Function: func(_ context.Context, arguments []value.Value) (value.Value, error) {
+				if err := expectArguments("math.random", arguments, 0); err != nil {
+					return nil, err
+				}
+				return value.Number(generator.Float64()), nil
+			},
+		},
+		{
+			Name: "math.seed",
+			Function: func(_ context.Context, arguments []value.Value) (value.Value, error) {
+				if err := expectArguments("math.seed", arguments, 1); err != nil {
+					return nil, err
+				}
+
+				seed, err := integerArgument("math.seed", arguments, 0)
+				if err != nil {
+					return nil, err
+				}
+				generator = rand.New(rand.NewPCG(uint64(seed), 0))
+				return value.Null{}, nil
+			},
+		},
+	}
+
+	return newModule("math", constants, natives...)
+}
+
+func unary(name string, fn func(float64) float64) *vm.Native {
+	return &vm.Native{
+		Name: name,
+		Function: func(_ context.Context, arguments []value.Value) (value.Value, error) {
+			numbers, err := numberArguments(name, arguments, 1)
+			if err != nil {
+				return nil, err
+			}
+			return value.Number(fn(numbers[0])), nil
+		},
+	}
+}
+
+func binary(name string, fn func(float64, float64) float64) *vm.Native {
+	return &vm.Native{
+		Name: name,
+		Function: func(_ context.Context, arguments []value.Value) (value.Value, error) {
+			numbers, err := numberArguments(name, arguments, 2)
+			if err != nil {
+				return nil, err
+			}
+			return value.Number(fn(numbers[0], numbers[1])), nil
+		},
+	}
+}
+
+func extremum(name string, fn func(float64, float64) float64) *vm.Native {
+	return &vm.Native{
+		Name: name,
+		Function: func(_ context.Context, arguments []value.Value) (value.Value, error) {
+			if err := expectAtLeast(name, arguments, 1); err != nil {
+				return nil, err
+			}
+
+			numbers, err := numberArguments(name, arguments, len(arguments))
+			if err != nil {
+				return nil, err
+			}
+
+			result := numbers[0]
+			for _, n := range numbers[1:] {
+				result = fn(result, n)
+			}
+			return value.Number(result), nil
+		},
+	}
+}
+
+func numberArguments(name string, arguments []value.Value, count int) ([]float64, error) {
+	if err := expectArguments(name, arguments, count); err != nil {
+		return nil, err
+	}
+
+	numbers := make([]float64, 0, count)
+	for index := range arguments {
+		n, err := numberArgument(name, arguments, index)
+		if err != nil {
+			return nil, err
+		}
+		numbers = append(numbers, n)
+	}
+	return numbers, nil
+}
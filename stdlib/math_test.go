@@ -0,0 +1,104 @@
+package stdlib
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/joaovictorjs/adam-script/value"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GivenMathModule_WhenCalled_ThenShouldReturnCorrectNumber(t *testing.T) {
+	type TestCase struct {
+		name     string
+		source   string
+		expected float64
+	}
+
+	testcases := []TestCase{
+		{name: "pi", source: "math.PI", expected: math.Pi},
+		{name: "e", source: "math.E", expected: math.E},
+		{name: "infinity", source: "0 - math.Inf", expected: math.Inf(-1)},
+		{name: "abs", source: "math.abs(0 - 3)", expected: 3},
+		{name: "floor", source: "math.floor(7 / 2)", expected: 3},
+		{name: "ceil", source: "math.ceil(7 / 2)", expected: 4},
+		{name: "round half away from zero", source: "math.round(0 - 5 / 2)", expected: -3},
+		{name: "trunc", source: "math.trunc(0 - 7 / 2)", expected: -3},
+		{name: "sqrt", source: "math.sqrt(16)", expected: 4},
+		{name: "pow", source: "math.pow(2, 10)", expected: 1024},
+		{name: "exp", source: "math.exp(0)", expected: 1},
+		{name: "log", source: "math.log(math.E)", expected: 1},
+		{name: "log2", source: "math.log2(8)", expected: 3},
+		{name: "log10", source: "math.log10(1000)", expected: 3},
+		{name: "sin", source: "math.sin(math.PI / 2)", expected: 1},
+		{name: "cos", source: "math.cos(0)", expected: 1},
+		{name: "tan", source: "math.tan(0)", expected: 0},
+		{name: "asin", source: "math.asin(1)", expected: math.Pi / 2},
+		{name: "acos", source: "math.acos(1)", expected: 0},
+		{name: "atan", source: "math.atan(0)", expected: 0},
+		{name: "atan2", source: "math.atan2(1, 1)", expected: math.Pi / 4},
+		{name: "min of one", source: "math.min(4)", expected: 4},
+		{name: "min", source: "math.min(4, 0 - 1, 3)", expected: -1},
+		{name: "max", source: "math.max(4, 0 - 1, 9, 3)", expected: 9},
+		{name: "clamp below", source: "math.clamp(0 - 5, 0, 10)", expected: 0},
+		{name: "clamp above", source: "math.clamp(15, 0, 10)", expected: 10},
+		{name: "clamp inside", source: "math.clamp(5, 0, 10)", expected: 5},
+	}
+
+	for _, test := range testcases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			result, err := run(t, Options{}, test.source)
+			assert.NoError(t, err)
+			assert.InDelta(t, test.expected, float64(result.(value.Number)), 1e-12)
+		})
+	}
+}
+
+func Test_GivenMathModule_WhenMisused_ThenShouldReturnCorrectError(t *testing.T) {
+	type TestCase struct {
+		name          string
+		source        string
+		expectedError string
+	}
+
+	testcases := []TestCase{
+		{name: "missing argument", source: "math.sqrt()", expectedError: "Function 'math.sqrt' expects 1 arguments but got 0."},
+		{name: "string argument", source: `math.pow(2, "3")`, expectedError: "Function 'math.pow' expects argument 2 to be of type 'number' but got 'string'."},
+		{name: "empty min", source: "math.min()", expectedError: "Function 'math.min' expects at least 1 arguments but got 0."},
+		{name: "inverted clamp", source: "math.clamp(1, 10, 0)", expectedError: "Function 'math.clamp' expects the lower bound 10 to be at most the upper bound 0."},
+		{name: "fractional seed", source: "math.seed(3 / 2)", expectedError: "Function 'math.seed' expects argument 1 to be an integer but got 1.5."},
+		{name: "constants are read-only", source: "math.PI = 3", expectedError: "Cannot assign member 'PI' of module 'math'."},
+	}
+
+	for _, test := range testcases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := run(t, Options{}, test.source)
+			assert.EqualError(t, err, test.expectedError)
+		})
+	}
+}
+
+func Test_GivenMathModule_WhenNaN_ThenShouldNotEqualItself(t *testing.T) {
+	result, err := run(t, Options{}, "math.NaN + 1")
+	assert.NoError(t, err)
+	assert.True(t, math.IsNaN(float64(result.(value.Number))))
+}
+
+func Test_GivenSeed_WhenRandom_ThenShouldBeReproducible(t *testing.T) {
+	source := `math.seed(42); let a = math.random(); math.seed(42); let b = math.random(); a - b`
+	result, err := run(t, Options{}, source)
+	assert.NoError(t, err)
+	assert.Equal(t, value.Number(0), result)
+
+	first, err := run(t, Options{Random: rand.NewPCG(7, 7)}, "math.random()")
+	assert.NoError(t, err)
+	second, err := run(t, Options{Random: rand.NewPCG(7, 7)}, "math.random()")
+	assert.NoError(t, err)
+	assert.Equal(t, first, second)
+
+	n := float64(first.(value.Number))
+	assert.True(t, n >= 0 && n < 1)
+}
@@ -0,0 +1,164 @@
+package stdlib
+
+import (
+	"fmt"
+	"io/fs"
+	"maps"
+	"math"
+	"math/rand/v2"
+	"os"
+	"slices"
+
+	"github.com/joaovictorjs/adam-script/sandbox"
+	"github.com/joaovictorjs/adam-script/value"
+	"github.com/joaovictorjs/adam-script/vm"
+)
+
+type Options struct {
+	Permissions sandbox.Permissions
+	LookupEnv   func(name string) (string, bool)
+
+	// FS backs the fs module, nil denies every file operation. Implement
+	// WritableFS to also allow fs.writeFile, fs.mkdir and fs.remove.
+	FS fs.FS
+
+	// Random feeds math.random until a script calls math.seed, nil uses a
+	// randomly seeded generator.
+	Random rand.Source
+}
+
+func Modules(options Options) map[string]value.Value {
+	if options.LookupEnv == nil {
+		options.LookupEnv = os.LookupEnv
+	}
+	if options.Random == nil {
+		options.Random = rand.NewPCG(rand.Uint64(), rand.Uint64())
+	}
+
+	modules := map[string]value.Value{}
+	for _, module := range []*Module{
+		envModule(options),
+		fsModule(options),
+		jsonModule(),
+		mathModule(options),
+		pathModule(),
+		stringsModule(),
+	} {
+		modules[module.Name] = module
+	}
+	return modules
+}
+
+func Names() []string {
+	return slices.Sorted(maps.Keys(Modules(Options{})))
+}
+
+// Globals is where Install declares the modules, such as a *vm.VM or an
+// *interpreter.Interpreter.
+type Globals interface {
+	SetGlobal(name string, v value.Value) error
+}
+
+func Install(globals Globals, options Options) error {
+	for name, module := range Modules(options) {
+		if err := globals.SetGlobal(name, module); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type Module struct {
+	Name    string
+	members map[string]value.Value
+}
+
+func newModule(name string, constants map[string]value.Value, natives ...*vm.Native) *Module {
+	module := &Module{Name: name, members: maps.Clone(constants)}
+	if module.members == nil {
+		module.members = map[string]value.Value{}
+	}
+	for _, native := range natives {
+		module.members[native.Name[len(name)+1:]] = native
+	}
+	return module
+}
+
+func (m *Module) Type() string {
+	return "module"
+}
+
+func (m *Module) String() string {
+	return "<module " + m.Name + ">"
+}
+
+func (m *Module) Member(name string) (value.Value, error) {
+	member, ok := m.members[name]
+	if !ok {
+		return nil, fmt.Errorf("Module '%s' has no member '%s'.", m.Name, name)
+	}
+	return member, nil
+}
+
+func (m *Module) SetMember(name string, _ value.Value) error {
+	return fmt.Errorf("Cannot assign member '%s' of module '%s'.", name, m.Name)
+}
+
+func expectArguments(name string, arguments []value.Value, count int) error {
+	if len(arguments) != count {
+		return fmt.Errorf("Function '%s' expects %d arguments but got %d.", name, count, len(arguments))
+	}
+	return nil
+}
+
+func expectBetween(name string, arguments []value.Value, least int, most int) error {
+	if len(arguments) < least || len(arguments) > most {
+		return fmt.Errorf("Function '%s' expects %d to %d arguments but got %d.", name, least, most, len(arguments))
+	}
+	return nil
+}
+
+func expectAtLeast(name string, arguments []value.Value, count int) error {
+	if len(arguments) < count {
+		return fmt.Errorf("Function '%s' expects at least %d arguments but got %d.", name, count, len(arguments))
+	}
+	return nil
+}
+
+func numberArgument(name string, arguments []value.Value, index int) (float64, error) {
+	n, ok := arguments[index].(value.Number)
+	if !ok {
+		return 0, fmt.Errorf("Function '%s' expects argument %d to be of type 'number' but got '%s'.", name, index+1, arguments[index].Type())
+	}
+	return float64(n), nil
+}
+
+func integerArgument(name string, arguments []value.Value, index int) (int, error) {
+	n, err := numberArgument(name, arguments, index)
+	if err != nil {
+		return 0, err
+	}
+	if n != math.Trunc(n) || math.Abs(n) > 1<<53 {
+		return 0, fmt.Errorf("Function '%s' expects argument %d to be an integer but got %s.", name, index+1, value.Number(n))
+	}
+	return int(n), nil
+}
+
+func countArgument(name string, arguments []value.Value, index int) (int, error) {
+	n, err := integerArgument(name, arguments, index)
+	if err != nil {
+		return 0, err
+	}
+	if n < 0 {
+		return 0, fmt.Errorf("Function '%s' expects argument %d to be a non-negative integer but got %d.", name, index+1, n)
+	}
+	return n, nil
+}
+
+func stringArgument(name string, arguments []value.Value, index int) (string, error) {
+	s, ok := arguments[index].(value.String)
+	if !ok {
+		return "", fmt.Errorf("Function '%s' expects argument %d to be of type 'string' but got '%s'.", name, index+1, arguments[index].Type())
+	}
+	return string(s), nil
+}
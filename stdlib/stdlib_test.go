@@ -0,0 +1,34 @@
+package stdlib
+
+import (
+	"context"
+	"testing"
+
+	"github.com/joaovictorjs/adam-script/compiler"
+	"github.com/joaovictorjs/adam-script/parser"
+	"github.com/joaovictorjs/adam-script/value"
+	"github.com/joaovictorjs/adam-script/vm"
+)
+
+func run(t *testing.T, options Options, source string) (value.Value, error) {
+	t.Helper()
+	return runOn(t, vm.New(), options, source)
+}
+
+func runOn(t *testing.T, machine *vm.VM, options Options, source string) (value.Value, error) {
+	t.Helper()
+	program, err := parser.NewParser(source).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	function, err := compiler.Compile(program)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Install(machine, options); err != nil {
+		t.Fatal(err)
+	}
+	return machine.Run(context.Background(), function)
+}
@@ -0,0 +1,313 @@
+package stdlib
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/joaovictorjs/adam-script/value"
+	"github.com/joaovictorjs/adam-script/vm"
+)
+
+// maxStringLength bounds the strings the module builds before they are
+// allocated, the VM memory limit only charges them afterwards so natives
+// also ask vm.CheckMemory first.
+const maxStringLength = 1 << 28
+
+// stringsModule counts every index and length in Unicode code points rather
+// than bytes, so scripts see the same characters the lexer read.
+func stringsModule() *Module {
+	return newModule("strings", nil,
+		stringFunction("strings.len", 1, func(s []string) (value.Value, error) {
+			return value.Number(utf8.RuneCountInString(s[0])), nil
+		}),
+		stringFunction("strings.upper", 1, func(s []string) (value.Value, error) {
+			return value.String(strings.ToUpper(s[0])), nil
+		}),
+		stringFunction("strings.lower", 1, func(s []string) (value.Value, error) {
+			return value.String(strings.ToLower(s[0])), nil
+		}),
+		stringFunction("strings.trim", 1, func(s []string) (value.Value, error) {
+			return value.String(strings.TrimSpace(s[0])), nil
+		}),
+		stringFunction("strings.split", 2, func(s []string) (value.Value, error) {
+			return stringArray(strings.Split(s[0], s[1])), nil
+		}),
+		stringFunction("strings.chars", 1, func(s []string) (value.Value, error) {
+			return stringArray(strings.Split(s[0], "")), nil
+		}),
+		&vm.Native{Name: "strings.replace", Function: replace},
+		stringFunction("strings.contains", 2, func(s []string) (value.Value, error) {
+			return value.Bool(strings.Contains(s[0], s[1])), nil
+		}),
+		stringFunction("strings.startsWith", 2, func(s []string) (value.Value, error) {
+			return value.Bool(strings.HasPrefix(s[0], s[1])), nil
+		}),
+		stringFunction("strings.endsWith", 2, func(s []string) (value.Value, error) {
+			return value.Bool(strings.HasSuffix(s[0], s[1])), nil
+		}),
+		stringFunction("strings.indexOf", 2, func(s []string) (value.Value, error) {
+			index := strings.Index(s[0], s[1])
+			if index < 0 {
+				return value.Number(-1), nil
+			}
+			return value.Number(utf8.RuneCountInString(s[0][:index])), nil
+		}),
+		&vm.Native{Name: "strings.join", Function: join},
+		&vm.Native{Name: "strings.repeat", Function: repeat},
+		&vm.Native{Name: "strings.slice", Function: slice},
+		pad("strings.padStart", func(s, padding string) string { return padding + s }),
+		pad("strings.padEnd", func(s, padding string) string { return s + padding }),
+		&vm.Native{Name: "strings.format", Function: format},
+	)
+}
+
+func stringFunction(name string, count int, fn func([]string) (value.Value, error)) *vm.Native {
+	return &vm.Native{
+		Name: name,
+		Function: func(_ context.Context, arguments []value.Value) (value.Value, error) {
+			if err := expectArguments(name, arguments, count); err != nil {
+				return nil, err
+			}
+
+			s := make([]string, 0, count)
+			for index := range arguments {
+				argument, err := stringArgument(name, arguments, index)
+				if err != nil {
+					return nil, err
+				}
+				s = append(s, argument)
+			}
+			return fn(s)
+		},
+	}
+}
+
+func stringArray(parts []string) value.Array {
+	array := make(value.Array, 0, len(parts))
+	for _, part := range parts {
+		array = append(array, value.String(part))
+	}
+	return array
+}
+
+// checkLength runs before the native name builds a string of length bytes,
+// which has to fit both maxStringLength and the memory left to the VM.
+func checkLength(ctx context.Context, name string, length int) error {
+	if length > maxStringLength {
+		return errTooLong(name)
+	}
+	return vm.CheckMemory(ctx, length)
+}
+
+func errTooLong(name string) error {
+	return fmt.Errorf("Function '%s' would create a string longer than %d bytes.", name, maxStringLength)
+}
+
+func replace(ctx context.Context, arguments []value.Value) (value.Value, error) {
+	if err := expectArguments("strings.replace", arguments, 3); err != nil {
+		return nil, err
+	}
+
+	s := make([]string, 0, 3)
+	for index := range arguments {
+		argument, err := stringArgument("strings.replace", arguments, index)
+		if err != nil {
+			return nil, err
+		}
+		s = append(s, argument)
+	}
+
+	// An empty old string matches around every code point.
+	matches := utf8.RuneCountInString(s[0]) + 1
+	if s[1] != "" {
+		matches = strings.Count(s[0], s[1])
+	}
+	if grow := len(s[2]) - len(s[1]); grow > 0 && matches > 0 {
+		if grow > (maxStringLength-len(s[0]))/matches {
+			return nil, errTooLong("strings.replace")
+		}
+		if err := checkLength(ctx, "strings.replace", len(s[0])+matches*grow); err != nil {
+			return nil, err
+		}
+	}
+	return value.String(strings.ReplaceAll(s[0], s[1], s[2])), nil
+}
+
+func join(ctx context.Context, arguments []value.Value) (value.Value, error) {
+	if err := expectArguments("strings.join", arguments, 2); err != nil {
+		return nil, err
+	}
+
+	array, ok := arguments[0].(value.Array)
+	if !ok {
+		return nil, fmt.Errorf("Function 'strings.join' expects argument 1 to be of type 'array' but got '%s'.", arguments[0].Type())
+	}
+	separator, err := stringArgument("strings.join", arguments, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	parts := make([]string, 0, len(array))
+	length := 0
+	for index, element := range array {
+		s, ok := element.(value.String)
+		if !ok {
+			return nil, fmt.Errorf("Function 'strings.join' expects element %d to be of type 'string' but got '%s'.", index+1, element.Type())
+		}
+		if index > 0 {
+			length += len(separator)
+		}
+		if length += len(s); length > maxStringLength {
+			return nil, errTooLong("strings.join")
+		}
+		parts = append(parts, string(s))
+	}
+
+	if err := checkLength(ctx, "strings.join", length); err != nil {
+		return nil, err
+	}
+	return value.String(strings.Join(parts, separator)), nil
+}
+
+func repeat(ctx context.Context, arguments []value.Value) (value.Value, error) {
+	if err := expectArguments("strings.repeat", arguments, 2); err != nil {
+		return nil, err
+	}
+
+	s, err := stringArgument("strings.repeat", arguments, 0)
+	if err != nil {
+		return nil, err
+	}
+	count, err := countArgument("strings.repeat", arguments, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	if count > 0 && len(s) > maxStringLength/count {
+		return nil, errTooLong("strings.repeat")
+	}
+	if err := checkLength(ctx, "strings.repeat", len(s)*count); err != nil {
+		return nil, err
+	}
+	return value.String(strings.Repeat(s, count)), nil
+}
+
+func slice(_ context.Context, arguments []value.Value) (value.Value, error) {
+	if err := expectBetween("strings.slice", arguments, 2, 3); err != nil {
+		return nil, err
+	}
+
+	s, err := stringArgument("strings.slice", arguments, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	runes := []rune(s)
+	start, err := integerArgument("strings.slice", arguments, 1)
+	if err != nil {
+		return nil, err
+	}
+	end := len(runes)
+	if len(arguments) == 3 {
+		if end, err = integerArgument("strings.slice", arguments, 2); err != nil {
+			return nil, err
+		}
+	}
+
+	start, end = position(start, len(runes)), position(end, len(runes))
+	if start >= end {
+		return value.String(""), nil
+	}
+	return value.String(string(runes[start:end])), nil
+}
+
+// position resolves a slice index, negative indices count from the end.
+func position(index int, length int) int {
+	if index < 0 {
+		index += length
+	}
+	return min(max(index, 0), length)
+}
+
+func pad(name string, join func(s, padding string) string) *vm.Native {
+	return &vm.Native{
+		Name: name,
+		Function: func(ctx context.Context, arguments []value.Value) (value.Value, error) {
+			if err := expectBetween(name, arguments, 2, 3); err != nil {
+				return nil, err
+			}
+
+			s, err := stringArgument(name, arguments, 0)
+			if err != nil {
+				return nil, err
+			}
+			length, err := countArgument(name, arguments, 1)
+			if err != nil {
+				return nil, err
+			}
+			filler := " "
+			if len(arguments) == 3 {
+				if filler, err = stringArgument(name, arguments, 2); err != nil {
+					return nil, err
+				}
+			}
+
+			missing := length - utf8.RuneCountInString(s)
+			if missing <= 0 || filler == "" {
+				return value.String(s), nil
+			}
+			if missing > maxStringLength/utf8.UTFMax {
+				return nil, errTooLong(name)
+			}
+
+			runes := []rune(filler)
+			rest := string(runes[:missing%len(runes)])
+			copies := missing / len(runes)
+			if err := checkLength(ctx, name, len(s)+copies*len(filler)+len(rest)); err != nil {
+				return nil, err
+			}
+			return value.String(join(s, strings.Repeat(filler, copies)+rest)), nil
+		},
+	}
+}
+
+func format(_ context.Context, arguments []value.Value) (value.Value, error) {
+	if err := expectAtLeast("strings.format", arguments, 1); err != nil {
+		return nil, err
+	}
+
+	template, err := stringArgument("strings.format", arguments, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	var builder strings.Builder
+	values := arguments[1:]
+	used := 0
+	for i := 0; i < len(template); i++ {
+		char := template[i]
+		switch {
+		case (char == '{' || char == '}') && i+1 < len(template) && template[i+1] == char:
+			builder.WriteByte(char)
+			i++
+		case char == '{' && i+1 < len(template) && template[i+1] == '}':
+			if used < len(values) {
+				builder.WriteString(values[used].String())
+			}
+			used++
+			i++
+		case char == '{' || char == '}':
+			position := utf8.RuneCountInString(template[:i])
+			return nil, fmt.Errorf("Function 'strings.format' found an unmatched '%c' at position %d.", char, position)
+		default:
+			builder.WriteByte(char)
+		}
+	}
+
+	if used != len(values) {
+		return nil, fmt.Errorf("Function 'strings.format' expects %d values for its placeholders but got %d.", used, len(values))
+	}
+	return value.String(builder.String()), nil
+}
@@ -0,0 +1,113 @@
+package stdlib
+
+import (
+	"testing"
+
+	"github.com/joaovictorjs/adam-script/value"
+	"github.com/joaovictorjs/adam-script/vm"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GivenStringsModule_WhenCalled_ThenShouldReturnCorrectValue(t *testing.T) {
+	type TestCase struct {
+		name     string
+		source   string
+		expected value.Value
+	}
+
+	testcases := []TestCase{
+		{name: "len counts code points", source: `strings.len("héllo, 世界")`, expected: value.Number(9)},
+		{name: "len of empty", source: `strings.len("")`, expected: value.Number(0)},
+		{name: "upper", source: `strings.upper("héllo")`, expected: value.String("HÉLLO")},
+		{name: "lower", source: `strings.lower("ÀBC")`, expected: value.String("àbc")},
+		{name: "trim", source: `strings.trim("  \t padded \n")`, expected: value.String("padded")},
+		{name: "split", source: `strings.split("a,b,,c", ",")`, expected: value.Array{value.String("a"), value.String("b"), value.String(""), value.String("c")}},
+		{name: "chars", source: `strings.chars("añ世")`, expected: value.Array{value.String("a"), value.String("ñ"), value.String("世")}},
+		{name: "join", source: `strings.join(strings.split("a b c", " "), "-")`, expected: value.String("a-b-c")},
+		{name: "replace", source: `strings.replace("a.b.c", ".", "::")`, expected: value.String("a::b::c")},
+		{name: "contains", source: `strings.contains("haystack", "st")`, expected: value.Bool(true)},
+		{name: "starts with", source: `strings.startsWith("prefix", "pre")`, expected: value.Bool(true)},
+		{name: "ends with", source: `strings.endsWith("suffix", "pre")`, expected: value.Bool(false)},
+		{name: "index of counts code points", source: `strings.indexOf("日本語テキスト", "テ")`, expected: value.Number(3)},
+		{name: "index of missing", source: `strings.indexOf("abc", "z")`, expected: value.Number(-1)},
+		{name: "repeat", source: `strings.repeat("ab", 3)`, expected: value.String("ababab")},
+		{name: "repeat zero times", source: `strings.repeat("ab", 0)`, expected: value.String("")},
+		{name: "pad start", source: `strings.padStart("7", 3, "0")`, expected: value.String("007")},
+		{name: "pad start with spaces", source: `strings.padStart("é", 3)`, expected: value.String("  é")},
+		{name: "pad end truncates filler", source: `strings.padEnd("ab", 7, "xyz")`, expected: value.String("abxyzxy")},
+		{name: "pad shorter length", source: `strings.padEnd("abc", 2)`, expected: value.String("abc")},
+		{name: "slice", source: `strings.slice("héllo", 1, 3)`, expected: value.String("él")},
+		{name: "slice to end", source: `strings.slice("世界你好", 2)`, expected: value.String("你好")},
+		{name: "slice negative", source: `strings.slice("abcdef", 0 - 3, 0 - 1)`, expected: value.String("de")},
+		{name: "slice out of range", source: `strings.slice("abc", 2, 10)`, expected: value.String("c")},
+		{name: "slice inverted", source: `strings.slice("abc", 2, 1)`, expected: value.String("")},
+		{name: "format", source: `strings.format("{}: {}", "total", 42)`, expected: value.String("total: 42")},
+		{name: "format escaped braces", source: `strings.format("{{{}}}", "x")`, expected: value.String("{x}")},
+		{name: "format without placeholders", source: `strings.format("plain")`, expected: value.String("plain")},
+	}
+
+	for _, test := range testcases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			result, err := run(t, Options{}, test.source)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, result)
+		})
+	}
+}
+
+func Test_GivenStringsModule_WhenMisused_ThenShouldReturnCorrectError(t *testing.T) {
+	type TestCase struct {
+		name          string
+		source        string
+		expectedError string
+	}
+
+	testcases := []TestCase{
+		{name: "non-string argument", source: `strings.upper(1)`, expectedError: "Function 'strings.upper' expects argument 1 to be of type 'string' but got 'number'."},
+		{name: "wrong argument count", source: `strings.split("a")`, expectedError: "Function 'strings.split' expects 2 arguments but got 1."},
+		{name: "join of a string", source: `strings.join("abc", "")`, expectedError: "Function 'strings.join' expects argument 1 to be of type 'array' but got 'string'."},
+		{name: "negative repeat", source: `strings.repeat("a", 0 - 1)`, expectedError: "Function 'strings.repeat' expects argument 2 to be a non-negative integer but got -1."},
+		{name: "huge repeat", source: `strings.repeat("ab", 1000000000)`, expectedError: "Function 'strings.repeat' would create a string longer than 268435456 bytes."},
+		{name: "huge replace", source: `strings.replace(strings.repeat("a", 1000000), "a", strings.repeat("b", 1000))`, expectedError: "Function 'strings.replace' would create a string longer than 268435456 bytes."},
+		{name: "huge replace of empty string", source: `strings.replace(strings.repeat("a", 1000000), "", strings.repeat("b", 1000))`, expectedError: "Function 'strings.replace' would create a string longer than 268435456 bytes."},
+		{name: "huge join", source: `strings.join(strings.split(strings.repeat(",", 999), ","), strings.repeat("x", 1000000))`, expectedError: "Function 'strings.join' would create a string longer than 268435456 bytes."},
+		{name: "fractional slice", source: `strings.slice("abc", 1 / 2)`, expectedError: "Function 'strings.slice' expects argument 2 to be an integer but got 0.5."},
+		{name: "slice without start", source: `strings.slice("abc")`, expectedError: "Function 'strings.slice' expects 2 to 3 arguments but got 1."},
+		{name: "format missing values", source: `strings.format("{} {}", 1)`, expectedError: "Function 'strings.format' expects 2 values for its placeholders but got 1."},
+		{name: "format extra values", source: `strings.format("{}", 1, 2)`, expectedError: "Function 'strings.format' expects 1 values for its placeholders but got 2."},
+		{name: "format unmatched brace", source: `strings.format("é{x}", 1)`, expectedError: "Function 'strings.format' found an unmatched '{' at position 1."},
+	}
+
+	for _, test := range testcases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := run(t, Options{}, test.source)
+			assert.EqualError(t, err, test.expectedError)
+		})
+	}
+}
+
+func Test_GivenMemoryLimit_WhenStringsBuildLargeResults_ThenShouldReturnMemoryLimitError(t *testing.T) {
+	const limit = 1 << 19
+
+	type TestCase struct {
+		name   string
+		source string
+	}
+
+	testcases := []TestCase{
+		{name: "repeat", source: `strings.repeat("ab", 1000000)`},
+		{name: "replace", source: `strings.replace(strings.repeat("a", 1000), "a", strings.repeat("b", 1000))`},
+		{name: "join", source: `strings.join(strings.split(strings.repeat(",", 999), ","), strings.repeat("x", 1000))`},
+		{name: "pad", source: `strings.padStart("", 1000000)`},
+	}
+
+	for _, test := range testcases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := runOn(t, vm.New(vm.WithMaxMemory(limit)), Options{}, test.source)
+			assert.Equal(t, &vm.MemoryLimitError{Limit: limit}, err)
+		})
+	}
+}
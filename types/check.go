@@ -0,0 +1,261 @@
+package types
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/joaovictorjs/adam-script/ast"
+	"github.com/joaovictorjs/adam-script/diagnostic"
+	"github.com/joaovictorjs/adam-script/resolver"
+)
+
+type Result struct {
+	Types       map[ast.Span]Type
+	Diagnostics []diagnostic.Diagnostic
+}
+
+type checker struct {
+	resolution   resolver.Resolution
+	declarations map[ast.Span]Type
+	results      []Type
+	types        map[ast.Span]Type
+	diagnostics  []diagnostic.Diagnostic
+}
+
+func Check(program ast.Program, resolution resolver.Resolution) Result {
+	c := &checker{
+		resolution:   resolution,
+		declarations: map[ast.Span]Type{},
+		types:        map[ast.Span]Type{},
+	}
+
+	// Declarations are keyed by span like bindings, a program without unique
+	// spans is left to the error Resolve reports for it.
+	if _, ok := resolver.InvalidSpan(program); ok {
+		return Result{Types: c.types}
+	}
+	c.checkStatements(program.Statements)
+	return Result{Types: c.types, Diagnostics: c.diagnostics}
+}
+
+func (c *checker) checkStatements(statements []ast.Statement) {
+	for _, statement := range statements {
+		if declaration, ok := statement.(ast.FunctionDeclaration); ok {
+			c.declarations[declaration.Span] = c.signature(declaration)
+		}
+	}
+
+	for _, statement := range statements {
+		c.checkStatement(statement)
+	}
+}
+
+func (c *checker) checkStatement(statement ast.Statement) {
+	switch s := statement.(type) {
+	case ast.ExpressionStatement:
+		c.checkExpression(s.Expression)
+	case ast.VariableDeclaration:
+		valueType := c.checkExpression(s.Value)
+		declared := Type(Any)
+		if s.Constant {
+			declared = valueType
+		}
+
+		if s.Type != "" {
+			declared = c.annotation(s.Type, s.Span)
+			if !Assignable(valueType, declared) {
+				c.report("type-mismatch", ast.SpanOf(s.Value), "Cannot assign '%s' to variable '%s' of type '%s'.", valueType, s.Identifier, declared)
+			}
+		}
+		c.declarations[s.Span] = declared
+	case ast.BlockStatement:
+		c.checkStatements(s.Statements)
+	case ast.TryStatement:
+		c.checkStatements(s.Body.Statements)
+		c.declarations[s.Parameter.Span] = Any
+		c.checkStatements(s.Handler.Statements)
+	case ast.FunctionDeclaration:
+		signature := c.declarations[s.Span].(Function)
+		for index, parameter := range s.Parameters {
+			c.declarations[parameter.Span] = signature.Parameters[index]
+		}
+
+		c.results = append(c.results, signature.Result)
+		c.checkStatements(s.Body.Statements)
+		c.results = c.results[:len(c.results)-1]
+	case ast.ReturnStatement:
+		valueType := Type(Null)
+		span := s.Span
+		if s.Value != nil {
+			valueType = c.checkExpression(s.Value)
+			span = ast.SpanOf(s.Value)
+		}
+
+		if len(c.results) == 0 {
+			return
+		}
+
+		result := c.results[len(c.results)-1]
+		if !Assignable(valueType, result) {
+			c.report("type-mismatch", span, "Cannot return '%s' from a function returning '%s'.", valueType, result)
+		}
+	default:
+		panic(fmt.Sprintf("types: unexpected statement type %T", s))
+	}
+}
+
+func (c *checker) checkExpression(expression ast.Expression) Type {
+	t := c.expressionType(expression)
+	c.types[ast.SpanOf(expression)] = t
+	return t
+}
+
+func (c *checker) expressionType(expression ast.Expression) Type {
+	switch e := expression.(type) {
+	case ast.NumericLiteralExpression:
+		return Number
+	case ast.StringLiteralExpression:
+		return String
+	case ast.IdentifierExpression:
+		return c.variableType(e)
+	case ast.AssignmentExpression:
+		identifier, ok := e.Target.(ast.IdentifierExpression)
+		if !ok {
+			c.checkExpression(e.Target)
+			return c.checkExpression(e.Value)
+		}
+
+		valueType := c.checkExpression(e.Value)
+		target := c.variableType(identifier)
+		c.types[identifier.Span] = target
+		if !Assignable(valueType, target) {
+			c.report("type-mismatch", ast.SpanOf(e.Value), "Cannot assign '%s' to variable '%s' of type '%s'.", valueType, identifier.Symbol, target)
+		}
+		return valueType
+	case ast.MemberExpression:
+		object := c.checkExpression(e.Object)
+		if object != Any {
+			c.report("unknown-member", e.Span, "Type '%s' has no member '%s'.", object, e.Property)
+		}
+		return Any
+	case ast.BinaryExpression:
+		left := c.checkExpression(e.Left)
+		right := c.checkExpression(e.Right)
+		return c.binaryType(e, left, right)
+	case ast.UnaryExpression:
+		operand := c.checkExpression(e.Operand)
+		if operand != Any && !Identical(operand, Number) {
+			c.report("type-mismatch", e.Span, "Operator '%c' cannot be applied to '%s'.", e.Operator, operand)
+			return Any
+		}
+		return operand
+	case ast.CallExpression:
+		return c.callType(e)
+	case ast.ObjectExpression:
+		for _, property := range e.Properties {
+			c.checkExpression(property.Value)
+		}
+		return Any
+	default:
+		panic(fmt.Sprintf("types: unexpected expression type %T", e))
+	}
+}
+
+func (c *checker) binaryType(binary ast.BinaryExpression, left, right Type) Type {
+	operands := []Type{Number}
+	if binary.Operator == '+' {
+		operands = append(operands, String)
+	}
+
+	if left == Any || right == Any {
+		other := left
+		if left == Any {
+			other = right
+		}
+		if other == Any || slices.Contains(operands, other) {
+			return Any
+		}
+	} else if Identical(left, right) && slices.Contains(operands, left) {
+		return left
+	}
+
+	c.report("type-mismatch", binary.Span, "Operator '%c' cannot be applied to '%s' and '%s'.", binary.Operator, left, right)
+	return Any
+}
+
+func (c *checker) callType(call ast.CallExpression) Type {
+	callee := c.checkExpression(call.Callee)
+	arguments := make([]Type, 0, len(call.Arguments))
+	for _, argument := range call.Arguments {
+		arguments = append(arguments, c.checkExpression(argument))
+	}
+
+	if callee == Any {
+		return Any
+	}
+
+	function, ok := callee.(Function)
+	if !ok {
+		c.report("not-callable", ast.SpanOf(call.Callee), "Type '%s' is not callable.", callee)
+		return Any
+	}
+
+	if len(arguments) != len(function.Parameters) {
+		c.report("argument-count", call.Span, "Expected %d arguments but got %d.", len(function.Parameters), len(arguments))
+		return function.Result
+	}
+
+	for index, argument := range arguments {
+		parameter := function.Parameters[index]
+		if !Assignable(argument, parameter) {
+			c.report("type-mismatch", ast.SpanOf(call.Arguments[index]), "Cannot pass '%s' as a parameter of type '%s'.", argument, parameter)
+		}
+	}
+	return function.Result
+}
+
+func (c *checker) variableType(identifier ast.IdentifierExpression) Type {
+	binding, ok := c.resolution.Bindings[identifier.Span]
+	if !ok {
+		return Any
+	}
+
+	if t, ok := c.declarations[binding.Declaration]; ok {
+		return t
+	}
+	return Any
+}
+
+func (c *checker) signature(declaration ast.FunctionDeclaration) Function {
+	signature := Function{Result: Any}
+	for _, parameter := range declaration.Parameters {
+		parameterType := Type(Any)
+		if parameter.Type != "" {
+			parameterType = c.annotation(parameter.Type, parameter.Span)
+		}
+		signature.Parameters = append(signature.Parameters, parameterType)
+	}
+
+	if declaration.ReturnType != "" {
+		signature.Result = c.annotation(declaration.ReturnType, declaration.Span)
+	}
+	return signature
+}
+
+func (c *checker) annotation(name string, span ast.Span) Type {
+	t, ok := Lookup(name)
+	if !ok {
+		c.report("unknown-type", span, "Unknown type '%s'.", name)
+		return Any
+	}
+	return t
+}
+
+func (c *checker) report(code string, span ast.Span, format string, args ...any) {
+	c.diagnostics = append(c.diagnostics, diagnostic.Diagnostic{
+		Severity: diagnostic.Error,
+		Code:     code,
+		Message:  fmt.Sprintf(format, args...),
+		Span:     span,
+	})
+}
@@ -0,0 +1,148 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/joaovictorjs/adam-script/ast"
+	"github.com/joaovictorjs/adam-script/diagnostic"
+	"github.com/joaovictorjs/adam-script/parser"
+	"github.com/joaovictorjs/adam-script/resolver"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GivenSource_WhenCheck_ThenShouldReportTypeErrors(t *testing.T) {
+	type TestCase struct {
+		name                string
+		source              string
+		expectedDiagnostics []diagnostic.Diagnostic
+	}
+
+	testcases := []TestCase{
+		{
+			name:   "untyped code",
+			source: "let x = 1; x = \"a\"; fn f(a, b) { return a - b } f(x, 2)",
+		},
+		{
+			name:   "annotated declarations",
+			source: "let x: number = 1 + 2; const s: string = \"a\" + \"b\"; let y: any = s",
+		},
+		{
+			name:   "declaration mismatch",
+			source: "let x: number = \"one\"",
+			expectedDiagnostics: []diagnostic.Diagnostic{
+				{Severity: diagnostic.Error, Code: "type-mismatch", Message: "Cannot assign 'string' to variable 'x' of type 'number'.", Span: ast.Span{Start: 16, End: 21}},
+			},
+		},
+		{
+			name:   "assignment mismatch",
+			source: "let x: string = \"a\"; x = 2",
+			expectedDiagnostics: []diagnostic.Diagnostic{
+				{Severity: diagnostic.Error, Code: "type-mismatch", Message: "Cannot assign 'number' to variable 'x' of type 'string'.", Span: ast.Span{Start: 25, End: 26}},
+			},
+		},
+		{
+			name:   "operand mismatch",
+			source: "const n = 1; n + \"a\"",
+			expectedDiagnostics: []diagnostic.Diagnostic{
+				{Severity: diagnostic.Error, Code: "type-mismatch", Message: "Operator '+' cannot be applied to 'number' and 'string'.", Span: ast.Span{Start: 13, End: 20}},
+			},
+		},
+		{
+			name:   "negation of a string",
+			source: "let s: string = \"a\"; -s",
+			expectedDiagnostics: []diagnostic.Diagnostic{
+				{Severity: diagnostic.Error, Code: "type-mismatch", Message: "Operator '-' cannot be applied to 'string'.", Span: ast.Span{Start: 21, End: 23}},
+			},
+		},
+		{
+			name:   "strings only support concatenation",
+			source: "let x = 1; \"a\" * x",
+			expectedDiagnostics: []diagnostic.Diagnostic{
+				{Severity: diagnostic.Error, Code: "type-mismatch", Message: "Operator '*' cannot be applied to 'string' and 'any'.", Span: ast.Span{Start: 11, End: 18}},
+			},
+		},
+		{
+			name:   "function signature",
+			source: "fn add(a: number, b: number): number { return a + b } add(1, \"2\"); add(1)",
+			expectedDiagnostics: []diagnostic.Diagnostic{
+				{Severity: diagnostic.Error, Code: "type-mismatch", Message: "Cannot pass 'string' as a parameter of type 'number'.", Span: ast.Span{Start: 61, End: 64}},
+				{Severity: diagnostic.Error, Code: "argument-count", Message: "Expected 2 arguments but got 1.", Span: ast.Span{Start: 67, End: 73}},
+			},
+		},
+		{
+			name:   "return type",
+			source: "fn name(): string { return 1 } fn nothing(): number { return }",
+			expectedDiagnostics: []diagnostic.Diagnostic{
+				{Severity: diagnostic.Error, Code: "type-mismatch", Message: "Cannot return 'number' from a function returning 'string'.", Span: ast.Span{Start: 27, End: 28}},
+				{Severity: diagnostic.Error, Code: "type-mismatch", Message: "Cannot return 'null' from a function returning 'number'.", Span: ast.Span{Start: 54, End: 60}},
+			},
+		},
+		{
+			name:   "inferred result type",
+			source: "fn half(n: number): number { return n / 2 } const s: string = half(4)",
+			expectedDiagnostics: []diagnostic.Diagnostic{
+				{Severity: diagnostic.Error, Code: "type-mismatch", Message: "Cannot assign 'number' to variable 's' of type 'string'.", Span: ast.Span{Start: 62, End: 69}},
+			},
+		},
+		{
+			name:   "calling a number",
+			source: "const x = 1; x()",
+			expectedDiagnostics: []diagnostic.Diagnostic{
+				{Severity: diagnostic.Error, Code: "not-callable", Message: "Type 'number' is not callable.", Span: ast.Span{Start: 13, End: 14}},
+			},
+		},
+		{
+			name:   "member of a string",
+			source: "const s = \"a\"; let host = 1; s.length; host.length",
+			expectedDiagnostics: []diagnostic.Diagnostic{
+				{Severity: diagnostic.Error, Code: "unknown-member", Message: "Type 'string' has no member 'length'.", Span: ast.Span{Start: 29, End: 37}},
+			},
+		},
+		{
+			name:   "unknown type",
+			source: "let x: integer = 1",
+			expectedDiagnostics: []diagnostic.Diagnostic{
+				{Severity: diagnostic.Error, Code: "unknown-type", Message: "Unknown type 'integer'.", Span: ast.Span{Start: 0, End: 18}},
+			},
+		},
+	}
+
+	for _, test := range testcases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			program, err := parser.NewParser(test.source).Parse()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			result := Check(program, resolver.Resolve(program))
+			assert.Equal(t, test.expectedDiagnostics, result.Diagnostics)
+		})
+	}
+}
+
+func Test_GivenSource_WhenCheck_ThenShouldRecordExpressionTypes(t *testing.T) {
+	program, err := parser.NewParser("fn f(a: number) { return a } f(2) + 1").Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := Check(program, resolver.Resolve(program))
+
+	assert.Equal(t, Function{Parameters: []Type{Number}, Result: Any}, result.Types[ast.Span{Start: 29, End: 30}])
+	assert.Equal(t, Any, result.Types[ast.Span{Start: 29, End: 33}])
+	assert.Equal(t, Number, result.Types[ast.Span{Start: 31, End: 32}])
+	assert.Equal(t, "fn(number): any", result.Types[ast.Span{Start: 29, End: 30}].String())
+}
+
+func Test_GivenProgramWithoutUniqueSpans_WhenCheck_ThenShouldLeaveItToTheResolver(t *testing.T) {
+	program := ast.Program{Statements: []ast.Statement{
+		ast.VariableDeclaration{Identifier: "x", Type: "number", Value: ast.NumericLiteralExpression{Value: 1}},
+		ast.FunctionDeclaration{Name: "f", Body: ast.BlockStatement{}},
+	}}
+
+	result := Check(program, resolver.Resolve(program))
+
+	assert.Empty(t, result.Types)
+	assert.Empty(t, result.Diagnostics)
+}
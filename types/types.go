@@ -0,0 +1,50 @@
+package types
+
+import "strings"
+
+type Type interface {
+	String() string
+}
+
+type Basic string
+
+const (
+	Any    Basic = "any"
+	Number Basic = "number"
+	String Basic = "string"
+	Null   Basic = "null"
+)
+
+func (b Basic) String() string {
+	return string(b)
+}
+
+type Function struct {
+	Parameters []Type
+	Result     Type
+}
+
+func (f Function) String() string {
+	parameters := make([]string, 0, len(f.Parameters))
+	for _, parameter := range f.Parameters {
+		parameters = append(parameters, parameter.String())
+	}
+	return "fn(" + strings.Join(parameters, ", ") + "): " + f.Result.String()
+}
+
+func Lookup(name string) (Type, bool) {
+	switch Basic(name) {
+	case Any, Number, String, Null:
+		return Basic(name), true
+	default:
+		return nil, false
+	}
+}
+
+func Assignable(from, to Type) bool {
+	return from == Any || to == Any || Identical(from, to)
+}
+
+func Identical(a, b Type) bool {
+	return a.String() == b.String()
+}
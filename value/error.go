@@ -0,0 +1,46 @@
+package value
+
+import (
+	"errors"
+	"fmt"
+)
+
+type Error struct {
+	Name    string
+	Message string
+}
+
+func (*Error) Type() string { return "error" }
+
+func (e *Error) String() string {
+	return e.Name + ": " + e.Message
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func (e *Error) Member(name string) (Value, error) {
+	switch name {
+	case "name":
+		return String(e.Name), nil
+	case "message":
+		return String(e.Message), nil
+	default:
+		return nil, fmt.Errorf("Value of type 'error' has no member '%s'.", name)
+	}
+}
+
+func (e *Error) SetMember(name string, _ Value) error {
+	return fmt.Errorf("Cannot assign member '%s' of a value of type 'error'.", name)
+}
+
+// FromError returns the value a catch clause binds for err. Errors that are
+// already script values, such as those raised by built-ins, are bound as is.
+func FromError(err error) Value {
+	var v Value
+	if errors.As(err, &v) {
+		return v
+	}
+	return &Error{Name: "Error", Message: err.Error()}
+}
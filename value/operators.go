@@ -0,0 +1,58 @@
+package value
+
+import "fmt"
+
+func Binary(operator uint8, left, right Value) (Value, error) {
+	if l, ok := left.(String); ok && operator == '+' {
+		if r, ok := right.(String); ok {
+			return l + r, nil
+		}
+	}
+
+	l, lok := left.(Number)
+	r, rok := right.(Number)
+	if !lok || !rok {
+		return nil, fmt.Errorf("Invalid operands '%s' and '%s' for operator '%c'.", left.Type(), right.Type(), operator)
+	}
+
+	switch operator {
+	case '+':
+		return l + r, nil
+	case '-':
+		return l - r, nil
+	case '*':
+		return l * r, nil
+	case '/':
+		return l / r, nil
+	default:
+		return nil, fmt.Errorf("Unsupported operator '%c'.", operator)
+	}
+}
+
+func Unary(operator uint8, operand Value) (Value, error) {
+	number, ok := operand.(Number)
+	if !ok {
+		return nil, fmt.Errorf("Invalid operand '%s' for operator '%c'.", operand.Type(), operator)
+	}
+
+	switch operator {
+	case '-':
+		return -number, nil
+	default:
+		return nil, fmt.Errorf("Unsupported operator '%c'.", operator)
+	}
+}
+
+func Member(v Value, name string) (Value, error) {
+	if object, ok := v.(Object); ok {
+		return object.Member(name)
+	}
+	return nil, fmt.Errorf("Value of type '%s' has no member '%s'.", v.Type(), name)
+}
+
+func SetMember(v Value, name string, member Value) error {
+	if object, ok := v.(Object); ok {
+		return object.SetMember(name, member)
+	}
+	return fmt.Errorf("Cannot assign member '%s' of a value of type '%s'.", name, v.Type())
+}
@@ -0,0 +1,128 @@
+package value
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+type Value interface {
+	Type() string
+	String() string
+}
+
+type Number float64
+
+func (Number) Type() string { return "number" }
+
+func (v Number) String() string {
+	return strconv.FormatFloat(float64(v), 'f', -1, 64)
+}
+
+type String string
+
+func (String) Type() string { return "string" }
+
+func (v String) String() string { return string(v) }
+
+type Null struct{}
+
+func (Null) Type() string { return "null" }
+
+func (Null) String() string { return "null" }
+
+func Inspect(v Value) string {
+	return inspect(v, map[uintptr]bool{})
+}
+
+// inspect tracks the containers on the current path in active, so a map
+// that holds itself prints as <cycle> instead of recursing forever.
+func inspect(v Value, active map[uintptr]bool) string {
+	if id, ok := Identity(v); ok {
+		if active[id] {
+			return "<cycle>"
+		}
+		active[id] = true
+		defer delete(active, id)
+	}
+
+	switch v := v.(type) {
+	case String:
+		return strconv.Quote(string(v))
+	case Array:
+		elements := make([]string, 0, len(v))
+		for _, element := range v {
+			elements = append(elements, inspect(element, active))
+		}
+		return "[" + strings.Join(elements, ", ") + "]"
+	case Map:
+		keys := slices.Sorted(maps.Keys(v))
+		entries := make([]string, 0, len(keys))
+		for _, key := range keys {
+			entries = append(entries, strconv.Quote(key)+": "+inspect(v[key], active))
+		}
+		return "{" + strings.Join(entries, ", ") + "}"
+	default:
+		return v.String()
+	}
+}
+
+// Identity returns the address behind a map or a non-empty array, values
+// with the same identity share their elements.
+func Identity(v Value) (uintptr, bool) {
+	switch v := v.(type) {
+	case Map:
+		return reflect.ValueOf(v).Pointer(), true
+	case Array:
+		if len(v) == 0 {
+			return 0, false
+		}
+		return reflect.ValueOf(v).Pointer(), true
+	default:
+		return 0, false
+	}
+}
+
+type Bool bool
+
+func (Bool) Type() string { return "bool" }
+
+func (v Bool) String() string { return strconv.FormatBool(bool(v)) }
+
+type Array []Value
+
+func (Array) Type() string { return "array" }
+
+func (v Array) String() string {
+	return Inspect(v)
+}
+
+type Object interface {
+	Value
+	Member(name string) (Value, error)
+	SetMember(name string, v Value) error
+}
+
+type Map map[string]Value
+
+func (Map) Type() string { return "map" }
+
+func (v Map) Member(name string) (Value, error) {
+	member, ok := v[name]
+	if !ok {
+		return nil, fmt.Errorf("Value of type 'map' has no member '%s'.", name)
+	}
+	return member, nil
+}
+
+func (v Map) SetMember(name string, member Value) error {
+	v[name] = member
+	return nil
+}
+
+func (v Map) String() string {
+	return Inspect(v)
+}
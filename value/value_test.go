@@ -0,0 +1,39 @@
+package value
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GivenValue_WhenInspect_ThenShouldReturnCorrectText(t *testing.T) {
+	cyclicMap := Map{"a": Number(1)}
+	cyclicMap["self"] = cyclicMap
+
+	cyclicArray := Array{Number(1), nil}
+	cyclicArray[1] = cyclicArray
+
+	shared := Map{"n": Number(1)}
+
+	type TestCase struct {
+		name     string
+		value    Value
+		expected string
+	}
+
+	testcases := []TestCase{
+		{name: "string", value: String("a\"b"), expected: `"a\"b"`},
+		{name: "nested containers", value: Map{"list": Array{String("x"), Null{}, Bool(true)}}, expected: `{"list": ["x", null, true]}`},
+		{name: "map holding itself", value: cyclicMap, expected: `{"a": 1, "self": <cycle>}`},
+		{name: "array holding itself", value: cyclicArray, expected: `[1, <cycle>]`},
+		{name: "shared map", value: Array{shared, shared}, expected: `[{"n": 1}, {"n": 1}]`},
+		{name: "empty arrays", value: Array{Array{}, Array{}}, expected: `[[], []]`},
+	}
+
+	for _, test := range testcases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, test.expected, Inspect(test.value))
+		})
+	}
+}
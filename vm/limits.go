@@ -0,0 +1,177 @@
+package vm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/joaovictorjs/adam-script/value"
+)
+
+// DefaultMaxCallDepth is the call depth limit of a VM created without
+// WithMaxCallDepth.
+const DefaultMaxCallDepth = 1024
+
+const interruptCheckSteps = 1024
+
+type Option func(*VM)
+
+func WithMaxSteps(steps int) Option {
+	return func(m *VM) {
+		m.maxSteps = steps
+	}
+}
+
+func WithMaxMemory(bytes int) Option {
+	return func(m *VM) {
+		m.maxMemory = bytes
+	}
+}
+
+func WithMaxCallDepth(depth int) Option {
+	return func(m *VM) {
+		m.maxCallDepth = depth
+	}
+}
+
+type InterruptedError struct {
+	Cause error
+}
+
+func (e *InterruptedError) Error() string {
+	return fmt.Sprintf("Execution interrupted: %s.", e.Cause)
+}
+
+func (e *InterruptedError) Unwrap() error {
+	return e.Cause
+}
+
+type StepLimitError struct {
+	Limit int
+}
+
+func (e *StepLimitError) Error() string {
+	return fmt.Sprintf("Step limit of %d exceeded.", e.Limit)
+}
+
+type MemoryLimitError struct {
+	Limit int
+}
+
+func (e *MemoryLimitError) Error() string {
+	return fmt.Sprintf("Memory limit of %d bytes exceeded.", e.Limit)
+}
+
+type CallDepthError struct {
+	Limit int
+}
+
+func (e *CallDepthError) Error() string {
+	return fmt.Sprintf("Call depth limit of %d exceeded.", e.Limit)
+}
+
+// Limit errors are never caught by try statements, otherwise a script could
+// swallow them and keep running past the host's budget.
+type limitError interface {
+	limit()
+}
+
+func (*InterruptedError) limit() {}
+
+func (*StepLimitError) limit() {}
+
+func (*MemoryLimitError) limit() {}
+
+func (*CallDepthError) limit() {}
+
+func catchable(err error) bool {
+	var limit limitError
+	return !errors.As(err, &limit)
+}
+
+func (m *VM) step() error {
+	m.steps++
+	if m.maxSteps > 0 && m.steps > m.maxSteps {
+		return &StepLimitError{Limit: m.maxSteps}
+	}
+
+	if m.steps%interruptCheckSteps == 0 {
+		return m.interrupted()
+	}
+	return nil
+}
+
+func (m *VM) interrupted() error {
+	if err := m.ctx.Err(); err != nil {
+		return &InterruptedError{Cause: err}
+	}
+	return nil
+}
+
+type machineKey struct{}
+
+// CheckMemory reports a MemoryLimitError when the VM running the native
+// called with ctx cannot allocate bytes more. The VM charges native results
+// only once they are returned, so natives call it before building large ones.
+func CheckMemory(ctx context.Context, bytes int) error {
+	m, ok := ctx.Value(machineKey{}).(*VM)
+	if !ok || m.maxMemory <= 0 {
+		return nil
+	}
+	if bytes > m.maxMemory-m.memory {
+		return &MemoryLimitError{Limit: m.maxMemory}
+	}
+	return nil
+}
+
+func (m *VM) allocate(size int) error {
+	m.memory += size
+	if m.maxMemory > 0 && m.memory > m.maxMemory {
+		return &MemoryLimitError{Limit: m.maxMemory}
+	}
+	return nil
+}
+
+// sizeOf estimates the memory of a value the VM just created, the values it
+// holds were charged when they were created themselves.
+func sizeOf(v value.Value) int {
+	switch v := v.(type) {
+	case value.String:
+		return len(v)
+	case value.Array:
+		return 16 * len(v)
+	case value.Map:
+		size := 0
+		for key := range v {
+			size += 48 + len(key)
+		}
+		return size
+	default:
+		return 0
+	}
+}
+
+// resultSize estimates the memory of a native result. Natives build it
+// outside the VM, so everything it holds is charged too, but a container
+// reached twice counts once.
+func resultSize(v value.Value, seen map[uintptr]bool) int {
+	if id, ok := value.Identity(v); ok {
+		if seen[id] {
+			return 0
+		}
+		seen[id] = true
+	}
+
+	size := sizeOf(v)
+	switch v := v.(type) {
+	case value.Array:
+		for _, element := range v {
+			size += resultSize(element, seen)
+		}
+	case value.Map:
+		for _, element := range v {
+			size += resultSize(element, seen)
+		}
+	}
+	return size
+}
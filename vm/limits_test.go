@@ -0,0 +1,168 @@
+package vm
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/joaovictorjs/adam-script/value"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GivenLimits_WhenExceeded_ThenShouldReturnTypedError(t *testing.T) {
+	type TestCase struct {
+		name          string
+		options       []Option
+		source        string
+		expectedError error
+	}
+
+	testcases := []TestCase{
+		{
+			name:          "step limit",
+			options:       []Option{WithMaxSteps(100), WithMaxCallDepth(0)},
+			source:        "fn f() { return f() } f()",
+			expectedError: &StepLimitError{Limit: 100},
+		},
+		{
+			name:          "call depth",
+			options:       []Option{WithMaxCallDepth(10)},
+			source:        "fn f() { return f() } f()",
+			expectedError: &CallDepthError{Limit: 10},
+		},
+		{
+			name:          "memory limit",
+			options:       []Option{WithMaxMemory(10)},
+			source:        `let s = "abcd"; s = s + s; s = s + s`,
+			expectedError: &MemoryLimitError{Limit: 10},
+		},
+	}
+
+	for _, test := range testcases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := New(test.options...).Run(context.Background(), compile(t, test.source))
+			assert.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_GivenLimits_WhenNotExceeded_ThenShouldResetBetweenRuns(t *testing.T) {
+	machine := New(WithMaxSteps(20), WithMaxMemory(8))
+	for range 3 {
+		result, err := machine.Run(context.Background(), compile(t, `"abcd" + "efgh"`))
+		assert.NoError(t, err)
+		assert.Equal(t, value.String("abcdefgh"), result)
+	}
+}
+
+func Test_GivenCanceledContext_WhenRun_ThenShouldReturnInterruptedError(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	machine := New(WithMaxCallDepth(0))
+	assert.NoError(t, machine.SetGlobal("cancel", &Native{
+		Name: "cancel",
+		Function: func(context.Context, []value.Value) (value.Value, error) {
+			cancel()
+			return value.Null{}, nil
+		},
+	}))
+
+	_, err := machine.Run(ctx, compile(t, "fn f() { return f() } cancel(); f()"))
+
+	var interrupted *InterruptedError
+	assert.True(t, errors.As(err, &interrupted))
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.EqualError(t, err, "Execution interrupted: context canceled.")
+}
+
+func Test_GivenNativeResult_WhenAllocated_ThenShouldCountTowardsMemory(t *testing.T) {
+	machine := New(WithMaxMemory(100))
+	assert.NoError(t, machine.SetGlobal("items", &Native{
+		Name: "items",
+		Function: func(context.Context, []value.Value) (value.Value, error) {
+			return value.Array{value.String("abc"), value.Map{"k": value.Number(1)}}, nil
+		},
+	}))
+
+	_, err := machine.Run(context.Background(), compile(t, "items()"))
+	assert.NoError(t, err)
+
+	_, err = machine.Run(context.Background(), compile(t, "items(); items()"))
+	assert.Equal(t, &MemoryLimitError{Limit: 100}, err)
+}
+
+func Test_GivenLimitError_WhenInsideTry_ThenShouldNotBeCaught(t *testing.T) {
+	type TestCase struct {
+		name          string
+		options       []Option
+		expectedError error
+	}
+
+	testcases := []TestCase{
+		{name: "step limit", options: []Option{WithMaxSteps(200), WithMaxCallDepth(0)}, expectedError: &StepLimitError{Limit: 200}},
+		{name: "call depth", options: []Option{WithMaxCallDepth(16)}, expectedError: &CallDepthError{Limit: 16}},
+	}
+
+	for _, test := range testcases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			source := "fn f() { return f() } try { f() } catch (e) { e }"
+			_, err := New(test.options...).Run(context.Background(), compile(t, source))
+			assert.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_GivenSharedValues_WhenAllocated_ThenShouldCountThemOnce(t *testing.T) {
+	machine := New(WithMaxMemory(160))
+	result, err := machine.Run(context.Background(), compile(t, "let m = {key: 1}; ({a: m, b: m})"))
+	assert.NoError(t, err)
+	assert.Equal(t, value.Map{"a": value.Map{"key": value.Number(1)}, "b": value.Map{"key": value.Number(1)}}, result)
+}
+
+func Test_GivenCyclicNativeResult_WhenAllocated_ThenShouldCountItOnce(t *testing.T) {
+	cyclic := value.Map{}
+	cyclic["self"] = cyclic
+
+	machine := New(WithMaxMemory(100))
+	assert.NoError(t, machine.SetGlobal("cyclic", &Native{
+		Name: "cyclic",
+		Function: func(context.Context, []value.Value) (value.Value, error) {
+			return cyclic, nil
+		},
+	}))
+
+	_, err := machine.Run(context.Background(), compile(t, "cyclic()"))
+	assert.NoError(t, err)
+}
+
+func Test_GivenNative_WhenCheckMemory_ThenShouldRespectRemainingBudget(t *testing.T) {
+	type TestCase struct {
+		name          string
+		options       []Option
+		bytes         int
+		expectedError error
+	}
+
+	testcases := []TestCase{
+		{name: "within budget", options: []Option{WithMaxMemory(100)}, bytes: 90},
+		{name: "over budget", options: []Option{WithMaxMemory(100)}, bytes: 91, expectedError: &MemoryLimitError{Limit: 100}},
+		{name: "unlimited", bytes: 1 << 40},
+	}
+
+	for _, test := range testcases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			machine := New(test.options...)
+			assert.NoError(t, machine.SetGlobal("build", &Native{
+				Name: "build",
+				Function: func(ctx context.Context, _ []value.Value) (value.Value, error) {
+					return value.Null{}, CheckMemory(ctx, test.bytes)
+				},
+			}))
+
+			_, err := machine.Run(context.Background(), compile(t, `"01234" + "56789"; build()`))
+			assert.Equal(t, test.expectedError, err)
+		})
+	}
+}
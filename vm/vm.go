@@ -0,0 +1,454 @@
+package vm
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/joaovictorjs/adam-script/compiler"
+	"github.com/joaovictorjs/adam-script/value"
+)
+
+type Closure struct {
+	Function *compiler.Function
+	upvalues []*upvalue
+}
+
+func (c *Closure) Type() string {
+	return "function"
+}
+
+func (c *Closure) String() string {
+	return c.Function.String()
+}
+
+type Native struct {
+	Name     string
+	Function func(ctx context.Context, arguments []value.Value) (value.Value, error)
+}
+
+func (n *Native) Type() string {
+	return "function"
+}
+
+func (n *Native) String() string {
+	return "<native " + n.Name + ">"
+}
+
+// undefined fills the slot of a local until its declaration runs. Only
+// OpJumpIfDefined looks at it, scripts never see it.
+type undefined struct{}
+
+func (undefined) Type() string {
+	return "undefined"
+}
+
+func (undefined) String() string {
+	return "undefined"
+}
+
+type upvalue struct {
+	slot   int
+	closed value.Value
+	open   bool
+}
+
+type global struct {
+	value    value.Value
+	constant bool
+}
+
+type frame struct {
+	closure *Closure
+	ip      int
+	base    int
+}
+
+type handler struct {
+	frames int
+	depth  int
+	ip     int
+}
+
+type VM struct {
+	stack    []value.Value
+	frames   []frame
+	handlers []handler
+	globals  map[string]*global
+	open     []*upvalue
+	ctx      context.Context
+
+	maxSteps     int
+	maxMemory    int
+	maxCallDepth int
+	steps        int
+	memory       int
+}
+
+func New(options ...Option) *VM {
+	m := &VM{globals: map[string]*global{}, maxCallDepth: DefaultMaxCallDepth}
+	for _, option := range options {
+		option(m)
+	}
+	return m
+}
+
+func (m *VM) Run(ctx context.Context, function *compiler.Function) (value.Value, error) {
+	return m.Call(ctx, &Closure{Function: function})
+}
+
+func (m *VM) Call(ctx context.Context, callee value.Value, arguments ...value.Value) (value.Value, error) {
+	m.ctx = context.WithValue(ctx, machineKey{}, m)
+	m.stack = m.stack[:0]
+	m.frames = m.frames[:0]
+	m.open = m.open[:0]
+	m.handlers = m.handlers[:0]
+	m.steps = 0
+	m.memory = 0
+
+	if err := m.interrupted(); err != nil {
+		return nil, err
+	}
+
+	m.push(callee)
+	for _, argument := range arguments {
+		m.push(argument)
+	}
+
+	if err := m.callValue(callee, len(arguments)); err != nil {
+		return nil, err
+	}
+
+	if len(m.frames) == 0 {
+		return m.pop(), nil
+	}
+	return m.run()
+}
+
+func (m *VM) Global(name string) (value.Value, bool) {
+	g, ok := m.globals[name]
+	if !ok {
+		return nil, false
+	}
+	return g.value, true
+}
+
+func (m *VM) SetGlobal(name string, v value.Value) error {
+	g, ok := m.globals[name]
+	if !ok {
+		m.globals[name] = &global{value: v}
+		return nil
+	}
+
+	if g.constant {
+		return fmt.Errorf("Cannot assign to constant '%s'.", name)
+	}
+	g.value = v
+	return nil
+}
+
+func (m *VM) Globals() []string {
+	return slices.Sorted(maps.Keys(m.globals))
+}
+
+func (m *VM) run() (value.Value, error) {
+	for {
+		result, err := m.execute()
+		if err == nil || !m.recover(err) {
+			return result, err
+		}
+	}
+}
+
+func (m *VM) recover(err error) bool {
+	if len(m.handlers) == 0 || !catchable(err) {
+		return false
+	}
+
+	h := m.handlers[len(m.handlers)-1]
+	m.handlers = m.handlers[:len(m.handlers)-1]
+	m.closeUpvalues(h.depth)
+	m.stack = m.stack[:h.depth]
+	m.frames = m.frames[:h.frames]
+	m.frames[h.frames-1].ip = h.ip
+	m.push(value.FromError(err))
+	return true
+}
+
+func (m *VM) execute() (value.Value, error) {
+	f := &m.frames[len(m.frames)-1]
+	for {
+		if err := m.step(); err != nil {
+			return nil, err
+		}
+
+		chunk := &f.closure.Function.Chunk
+		opcode := compiler.Opcode(chunk.Code[f.ip])
+		f.ip++
+
+		switch opcode {
+		case compiler.OpConstant:
+			m.push(chunk.Constants[m.readUint16(f)])
+		case compiler.OpNull:
+			m.push(value.Null{})
+		case compiler.OpUndefined:
+			m.push(undefined{})
+		case compiler.OpPop:
+			m.pop()
+		case compiler.OpAdd, compiler.OpSubtract, compiler.OpMultiply, compiler.OpDivide:
+			operator, _ := opcode.Operator()
+			right := m.pop()
+			left := m.pop()
+			result, err := value.Binary(operator, left, right)
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := result.(value.String); ok {
+				if err := m.allocate(sizeOf(result)); err != nil {
+					return nil, err
+				}
+			}
+			m.push(result)
+		case compiler.OpNegate:
+			result, err := value.Unary('-', m.pop())
+			if err != nil {
+				return nil, err
+			}
+			m.push(result)
+		case compiler.OpDefineGlobal:
+			name := m.readName(f)
+			constant := m.readByte(f) == 1
+			if _, ok := m.globals[name]; ok {
+				return nil, fmt.Errorf("Variable '%s' is already declared.", name)
+			}
+			m.globals[name] = &global{value: m.pop(), constant: constant}
+		case compiler.OpGetGlobal:
+			name := m.readName(f)
+			g, ok := m.globals[name]
+			if !ok {
+				return nil, fmt.Errorf("Undefined variable '%s'.", name)
+			}
+			m.push(g.value)
+		case compiler.OpSetGlobal:
+			name := m.readName(f)
+			g, ok := m.globals[name]
+			if !ok {
+				return nil, fmt.Errorf("Undefined variable '%s'.", name)
+			}
+			if g.constant {
+				return nil, fmt.Errorf("Cannot assign to constant '%s'.", name)
+			}
+			g.value = m.peek(0)
+		case compiler.OpGetLocal:
+			m.push(m.stack[f.base+m.readByte(f)])
+		case compiler.OpSetLocal:
+			m.stack[f.base+m.readByte(f)] = m.peek(0)
+		case compiler.OpGetUpvalue:
+			m.push(m.getUpvalue(f.closure.upvalues[m.readByte(f)]))
+		case compiler.OpSetUpvalue:
+			m.setUpvalue(f.closure.upvalues[m.readByte(f)], m.peek(0))
+		case compiler.OpCloseUpvalue:
+			m.closeUpvalues(len(m.stack) - 1)
+			m.pop()
+		case compiler.OpClosure:
+			function := chunk.Constants[m.readUint16(f)].(*compiler.Function)
+			closure := &Closure{Function: function, upvalues: make([]*upvalue, function.Upvalues)}
+			for i := range closure.upvalues {
+				isLocal := m.readByte(f) == 1
+				index := m.readByte(f)
+				if isLocal {
+					closure.upvalues[i] = m.captureUpvalue(f.base + index)
+				} else {
+					closure.upvalues[i] = f.closure.upvalues[index]
+				}
+			}
+			m.push(closure)
+		case compiler.OpCall:
+			count := m.readByte(f)
+			if err := m.callValue(m.peek(count), count); err != nil {
+				return nil, err
+			}
+			f = &m.frames[len(m.frames)-1]
+		case compiler.OpReturn:
+			result := m.pop()
+			m.closeUpvalues(f.base)
+			m.stack = m.stack[:f.base]
+			m.frames = m.frames[:len(m.frames)-1]
+			for len(m.handlers) > 0 && m.handlers[len(m.handlers)-1].frames > len(m.frames) {
+				m.handlers = m.handlers[:len(m.handlers)-1]
+			}
+			if len(m.frames) == 0 {
+				return result, nil
+			}
+			m.push(result)
+			f = &m.frames[len(m.frames)-1]
+		case compiler.OpGetMember:
+			name := m.readName(f)
+			member, err := value.Member(m.pop(), name)
+			if err != nil {
+				return nil, err
+			}
+			m.push(member)
+		case compiler.OpSetMember:
+			name := m.readName(f)
+			v := m.pop()
+			if err := value.SetMember(m.pop(), name, v); err != nil {
+				return nil, err
+			}
+			m.push(v)
+		case compiler.OpJump:
+			offset := m.readUint16(f)
+			f.ip += offset
+		case compiler.OpJumpIfDefined:
+			offset := m.readUint16(f)
+			if _, ok := m.peek(0).(undefined); ok {
+				m.pop()
+			} else {
+				f.ip += offset
+			}
+		case compiler.OpTry:
+			offset := m.readUint16(f)
+			m.handlers = append(m.handlers, handler{frames: len(m.frames), depth: len(m.stack), ip: f.ip + offset})
+		case compiler.OpEndTry:
+			m.handlers = m.handlers[:len(m.handlers)-1]
+		case compiler.OpMap:
+			count := m.readUint16(f)
+			properties := m.stack[len(m.stack)-2*count:]
+			result := make(value.Map, count)
+			for i := 0; i < len(properties); i += 2 {
+				key, ok := properties[i].(value.String)
+				if !ok {
+					return nil, fmt.Errorf("Map keys must be of type 'string' but got '%s'.", properties[i].Type())
+				}
+				result[string(key)] = properties[i+1]
+			}
+			m.stack = m.stack[:len(m.stack)-2*count]
+			if err := m.allocate(sizeOf(result)); err != nil {
+				return nil, err
+			}
+			m.push(result)
+		default:
+			return nil, fmt.Errorf("Unknown opcode '%s'.", opcode)
+		}
+	}
+}
+
+func (m *VM) callValue(callee value.Value, count int) error {
+	switch callee := callee.(type) {
+	case *Closure:
+		return m.call(callee, count)
+	case *Native:
+		arguments := slices.Clone(m.stack[len(m.stack)-count:])
+		result, err := callee.Function(m.ctx, arguments)
+		if err != nil {
+			return err
+		}
+		if err := m.allocate(resultSize(result, map[uintptr]bool{})); err != nil {
+			return err
+		}
+		if err := m.interrupted(); err != nil {
+			return err
+		}
+		m.stack = m.stack[:len(m.stack)-count-1]
+		m.push(result)
+		return nil
+	default:
+		return fmt.Errorf("Value of type '%s' is not callable.", callee.Type())
+	}
+}
+
+func (m *VM) call(closure *Closure, count int) error {
+	function := closure.Function
+	if count != function.Arity {
+		return fmt.Errorf("Function '%s' expects %d arguments but got %d.", function.Name, function.Arity, count)
+	}
+
+	if m.maxCallDepth > 0 && len(m.frames) >= m.maxCallDepth {
+		return &CallDepthError{Limit: m.maxCallDepth}
+	}
+
+	m.frames = append(m.frames, frame{closure: closure, base: len(m.stack) - count - 1})
+	return nil
+}
+
+func (m *VM) captureUpvalue(slot int) *upvalue {
+	for _, existing := range m.open {
+		if existing.slot == slot {
+			return existing
+		}
+	}
+
+	created := &upvalue{slot: slot, open: true}
+	m.open = append(m.open, created)
+	return created
+}
+
+func (m *VM) closeUpvalues(from int) {
+	remaining := m.open[:0]
+	for _, u := range m.open {
+		if u.slot < from {
+			remaining = append(remaining, u)
+			continue
+		}
+
+		u.closed = m.slotValue(u.slot)
+		u.open = false
+	}
+	m.open = remaining
+}
+
+func (m *VM) getUpvalue(u *upvalue) value.Value {
+	if u.open {
+		return m.slotValue(u.slot)
+	}
+	return u.closed
+}
+
+// slotValue reads the stack slot of an open upvalue. Upvalues are closed
+// before their slots are popped, so a slot past the top of the stack is a
+// bug in the VM rather than something a script can cause.
+func (m *VM) slotValue(slot int) value.Value {
+	if slot >= len(m.stack) {
+		panic(fmt.Sprintf("vm: open upvalue slot %d is outside of a stack of %d values", slot, len(m.stack)))
+	}
+	return m.stack[slot]
+}
+
+func (m *VM) setUpvalue(u *upvalue, v value.Value) {
+	if u.open {
+		m.stack[u.slot] = v
+		return
+	}
+	u.closed = v
+}
+
+func (m *VM) readByte(f *frame) int {
+	b := f.closure.Function.Chunk.Code[f.ip]
+	f.ip++
+	return int(b)
+}
+
+func (m *VM) readUint16(f *frame) int {
+	v := f.closure.Function.Chunk.ReadUint16(f.ip)
+	f.ip += 2
+	return v
+}
+
+func (m *VM) readName(f *frame) string {
+	return string(f.closure.Function.Chunk.Constants[m.readUint16(f)].(value.String))
+}
+
+func (m *VM) push(v value.Value) {
+	m.stack = append(m.stack, v)
+}
+
+func (m *VM) pop() value.Value {
+	v := m.stack[len(m.stack)-1]
+	m.stack = m.stack[:len(m.stack)-1]
+	return v
+}
+
+func (m *VM) peek(distance int) value.Value {
+	return m.stack[len(m.stack)-1-distance]
+}
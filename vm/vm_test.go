@@ -0,0 +1,109 @@
+package vm
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/joaovictorjs/adam-script/compiler"
+	"github.com/joaovictorjs/adam-script/parser"
+	"github.com/joaovictorjs/adam-script/value"
+	"github.com/stretchr/testify/assert"
+)
+
+func compile(t *testing.T, source string) *compiler.Function {
+	t.Helper()
+	program, err := parser.NewParser(source).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	function, err := compiler.Compile(program)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return function
+}
+
+func Test_GivenSeveralRuns_WhenRun_ThenShouldKeepGlobals(t *testing.T) {
+	machine := New()
+
+	_, err := machine.Run(context.Background(), compile(t, "let x = 1; fn add(n) { x = x + n; return x }"))
+	assert.NoError(t, err)
+
+	result, err := machine.Run(context.Background(), compile(t, "add(2); add(3)"))
+	assert.NoError(t, err)
+	assert.Equal(t, value.Number(6), result)
+
+	result, err = machine.Run(context.Background(), compile(t, "add"))
+	assert.NoError(t, err)
+	assert.Equal(t, "<fn add>", result.String())
+	assert.Equal(t, "function", result.Type())
+}
+
+func Test_GivenRuntimeError_WhenRun_ThenShouldRecover(t *testing.T) {
+	machine := New()
+
+	_, err := machine.Run(context.Background(), compile(t, "fn f(n) { return n + \"a\" } f(1)"))
+	assert.Equal(t, fmt.Errorf("Invalid operands 'number' and 'string' for operator '+'."), err)
+
+	result, err := machine.Run(context.Background(), compile(t, "f(\"b\")"))
+	assert.NoError(t, err)
+	assert.Equal(t, value.String("ba"), result)
+}
+
+func Test_GivenUnboundedRecursion_WhenRun_ThenShouldReturnCallDepthError(t *testing.T) {
+	_, err := New().Run(context.Background(), compile(t, "fn f() { return f() } f()"))
+	assert.Equal(t, &CallDepthError{Limit: 1024}, err)
+}
+
+func Test_GivenNonStringMapKey_WhenRun_ThenShouldReturnError(t *testing.T) {
+	function := &compiler.Function{Name: compiler.ScriptName}
+	function.Chunk.Code = []byte{
+		byte(compiler.OpConstant), 0, 0,
+		byte(compiler.OpNull),
+		byte(compiler.OpMap), 0, 1,
+		byte(compiler.OpReturn),
+	}
+	function.Chunk.Constants = []value.Value{value.Number(1)}
+
+	_, err := New().Run(context.Background(), function)
+	assert.Equal(t, fmt.Errorf("Map keys must be of type 'string' but got 'number'."), err)
+}
+
+func Test_GivenNativeGlobal_WhenCalled_ThenShouldReceiveArgumentsAndContext(t *testing.T) {
+	type contextKey struct{}
+	machine := New()
+	native := &Native{
+		Name: "join",
+		Function: func(ctx context.Context, arguments []value.Value) (value.Value, error) {
+			result := value.String(ctx.Value(contextKey{}).(string))
+			for _, argument := range arguments {
+				result += value.String(argument.String())
+			}
+			return result, nil
+		},
+	}
+	assert.NoError(t, machine.SetGlobal("join", native))
+
+	ctx := context.WithValue(context.Background(), contextKey{}, ">")
+	result, err := machine.Run(ctx, compile(t, `fn wrap(a) { return join(a, "b") + "!" } wrap(1)`))
+	assert.NoError(t, err)
+	assert.Equal(t, value.String(">1b!"), result)
+	assert.Equal(t, "<native join>", native.String())
+}
+
+func Test_GivenNativeError_WhenCaught_ThenShouldBindErrorValue(t *testing.T) {
+	machine := New()
+	assert.NoError(t, machine.SetGlobal("fail", &Native{
+		Name: "fail",
+		Function: func(context.Context, []value.Value) (value.Value, error) {
+			return nil, &value.Error{Name: "CustomError", Message: "Boom."}
+		},
+	}))
+
+	source := `fn attempt() { try { return fail() } catch (e) { return e.name + ": " + e.message } } attempt() + "!"`
+	result, err := machine.Run(context.Background(), compile(t, source))
+	assert.NoError(t, err)
+	assert.Equal(t, value.String("CustomError: Boom.!"), result)
+}